@@ -23,18 +23,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
 	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/approval"
 	"github.com/kubeshop/botkube/internal/audit"
 	"github.com/kubeshop/botkube/internal/command"
 	intconfig "github.com/kubeshop/botkube/internal/config"
 	"github.com/kubeshop/botkube/internal/config/reloader"
 	"github.com/kubeshop/botkube/internal/config/remote"
+	"github.com/kubeshop/botkube/internal/delivery"
 	"github.com/kubeshop/botkube/internal/health"
 	"github.com/kubeshop/botkube/internal/heartbeat"
+	"github.com/kubeshop/botkube/internal/history"
 	"github.com/kubeshop/botkube/internal/insights"
 	"github.com/kubeshop/botkube/internal/kubex"
 	"github.com/kubeshop/botkube/internal/source"
 	"github.com/kubeshop/botkube/internal/status"
 	"github.com/kubeshop/botkube/internal/storage"
+	"github.com/kubeshop/botkube/internal/throttle"
 	"github.com/kubeshop/botkube/pkg/action"
 	"github.com/kubeshop/botkube/pkg/bot"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
@@ -198,8 +202,8 @@ func run(ctx context.Context) (err error) {
 	schedulerChan := make(chan string)
 	pluginHealthStats := plugin.NewHealthStats(conf.Plugins.RestartPolicy.Threshold)
 	collector := plugin.NewCollector(logger)
-	enabledPluginExecutors, enabledPluginSources := collector.GetAllEnabledAndUsedPlugins(conf)
-	pluginManager := plugin.NewManager(logger, conf.Settings.Log, conf.Plugins, enabledPluginExecutors, enabledPluginSources, schedulerChan, pluginHealthStats)
+	enabledPluginExecutors, enabledPluginSources, enabledPluginTransformers := collector.GetAllEnabledAndUsedPlugins(conf)
+	pluginManager := plugin.NewManager(logger, conf.Settings.Log, conf.Plugins, enabledPluginExecutors, enabledPluginSources, enabledPluginTransformers, schedulerChan, pluginHealthStats)
 
 	// Health endpoint
 	healthChecker := health.NewChecker(ctx, conf, pluginHealthStats)
@@ -223,6 +227,10 @@ func run(ctx context.Context) (err error) {
 	})
 
 	cmdGuard := command.NewCommandGuard(logger.WithField(componentLogFieldKey, "Command Guard"), discoveryCli)
+	eventHistory := history.NewStore(conf.Settings.EventHistory.MaxEventsPerSource)
+	actionTracker := throttle.NewTracker()
+	actionApprovals := approval.NewStore()
+
 	// Create executor factory
 	cfgManager := config.NewManager(remoteCfgEnabled, logger.WithField(componentLogFieldKey, "Config manager"), conf.Settings.PersistentConfig, cfgVersion, k8sCli, gqlClient, deployClient)
 	executorFactory, err := execute.NewExecutorFactory(
@@ -237,6 +245,10 @@ func run(ctx context.Context) (err error) {
 			RestCfg:           kubeConfig,
 			AuditReporter:     auditReporter,
 			PluginHealthStats: pluginHealthStats,
+			EventHistory:      eventHistory,
+			ActionStats:       actionTracker,
+			ActionApprovals:   actionApprovals,
+			ActionPreviewer:   action.NewPreviewer(conf.Actions),
 		},
 	)
 	if err != nil {
@@ -244,7 +256,7 @@ func run(ctx context.Context) (err error) {
 	}
 
 	var (
-		sinkNotifiers []notifier.Sink
+		sinkNotifiers = map[string]notifier.Sink{}
 		bots          = map[string]bot.Bot{}
 	)
 
@@ -264,7 +276,7 @@ func run(ctx context.Context) (err error) {
 
 		scheduleNotifier := func(provider func() (notifier.Platform, error)) {
 			app, err := provider()
-			key := fmt.Sprintf("%s-%s", commGroupName, app.IntegrationName())
+			key := source.NotifierKey(commGroupName, app.IntegrationName())
 			if err != nil {
 				commGroupLogger.WithError(err).Errorf("while creating %s bot", app.IntegrationName())
 				healthChecker.AddNotifier(key, health.NewFailed(health.FailureReasonConnectionError, err.Error()))
@@ -275,7 +287,7 @@ func run(ctx context.Context) (err error) {
 
 			switch platform := app.(type) {
 			case notifier.Sink:
-				sinkNotifiers = append(sinkNotifiers, platform)
+				sinkNotifiers[key] = platform
 			case bot.Bot:
 				bots[key] = platform
 				errGroup.Go(func() error {
@@ -394,9 +406,31 @@ func run(ctx context.Context) (err error) {
 		})
 	}
 
-	actionProvider := action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), conf.Actions, executorFactory)
+	actionProvider := action.NewProvider(logger.WithField(componentLogFieldKey, "Action Provider"), conf.Actions, executorFactory, actionTracker, cfgManager, actionApprovals)
+
+	deliveryStore, err := delivery.NewStore(conf.Settings.Delivery.Spillover, k8sCli)
+	if err != nil {
+		return reportFatalError("while creating delivery spillover store", err)
+	}
+	router, err := source.NewRouter(logger.WithField(componentLogFieldKey, "Router"), conf.Communications)
+	if err != nil {
+		return reportFatalError("while creating notification router", err)
+	}
+	sourcePluginDispatcher := source.NewDispatcher(logger, conf.Settings.ClusterName, bots, sinkNotifiers, pluginManager, actionProvider, analyticsReporter, auditReporter, kubeConfig, router, eventHistory, conf.Settings.Delivery, deliveryStore, conf.Plugins.RestartPolicy, pluginHealthStats)
+	for _, queue := range sourcePluginDispatcher.DeliveryQueues() {
+		healthChecker.AddDeliveryQueue(queue.Key(), queue)
+	}
+	errGroup.Go(func() error {
+		defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+		return sourcePluginDispatcher.Run(ctx)
+	})
+
+	scheduledCmdRunner := source.NewScheduledCommandRunner(logger.WithField(componentLogFieldKey, "Scheduled Commands"), conf.Sources, executorFactory, sourcePluginDispatcher)
+	errGroup.Go(func() error {
+		defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
+		return scheduledCmdRunner.Run(ctx)
+	})
 
-	sourcePluginDispatcher := source.NewDispatcher(logger, conf.Settings.ClusterName, bots, sinkNotifiers, pluginManager, actionProvider, analyticsReporter, auditReporter, kubeConfig)
 	scheduler := source.NewScheduler(ctx, logger, conf, sourcePluginDispatcher, schedulerChan)
 	err = scheduler.Start(ctx)
 	if err != nil {
@@ -404,12 +438,17 @@ func run(ctx context.Context) (err error) {
 	}
 
 	if conf.Plugins.IncomingWebhook.Enabled {
-		incomingWebhookSrv := source.NewIncomingWebhookServer(
+		incomingWebhookSrv, err := source.NewIncomingWebhookServer(
+			ctx,
 			logger.WithField(componentLogFieldKey, "Incoming Webhook Server"),
 			conf,
 			sourcePluginDispatcher,
 			scheduler.StartedSourcePlugins(),
+			k8sCli,
 		)
+		if err != nil {
+			return reportFatalError("while creating incoming webhook server", err)
+		}
 
 		errGroup.Go(func() error {
 			defer analytics.ReportPanicIfOccurs(logger, analyticsReporter)
@@ -0,0 +1,19 @@
+package action
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmd returns a new cobra.Command subcommand for automated actions-related operations.
+func NewCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:     "action",
+		Aliases: []string{"actions", "act"},
+		Short:   "This command consists of multiple subcommands for working with automated actions",
+	}
+
+	root.AddCommand(
+		NewRender(),
+	)
+	return root
+}
@@ -0,0 +1,132 @@
+package action
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/kubeshop/botkube/internal/cli"
+	"github.com/kubeshop/botkube/internal/cli/analytics"
+	"github.com/kubeshop/botkube/internal/cli/heredoc"
+	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+// RenderOptions holds options for rendering actions.
+type RenderOptions struct {
+	ConfigPaths []string
+	EventPath   string
+	ActionName  string
+	SourceName  string
+}
+
+// NewRender returns a cobra.Command for rendering automated actions without executing them.
+func NewRender() *cobra.Command {
+	var opts RenderOptions
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Renders automated actions for a given event without executing them",
+		Long: heredoc.WithCLIName(`
+			Renders automated actions for a given event without executing them.
+
+			For each action, it prints its bindings, the condition result, the rendered command and cooldown key.
+			The command fails if any template can't be rendered, so it can be used to validate actions before deployment.`, cli.Name),
+		Example: heredoc.WithCLIName(`
+			# Render all actions defined in a given configuration
+			<cli> action render --config config.yaml --event event.json
+
+			# Render a given action for an event emitted by a given source
+			<cli> action render --config config.yaml --event event.json --action show-logs-on-error --source k8s-err-events
+		`, cli.Name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRender(cmd, opts)
+		},
+	}
+
+	cmd = analytics.InjectAnalyticsReporting(*cmd, "action render")
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opts.ConfigPaths, "config", nil, "Botkube configuration files. Only actions are read, and actions from later files override the ones with the same name.")
+	flags.StringVar(&opts.EventPath, "event", "", "JSON file with a sample event")
+	flags.StringVar(&opts.ActionName, "action", "", "Action to render. If not specified, all actions are rendered.")
+	flags.StringVar(&opts.SourceName, "source", "", "Source which emitted the event. If specified, it is checked against the action source bindings.")
+
+	_ = cmd.MarkFlagRequired("config")
+	_ = cmd.MarkFlagRequired("event")
+
+	return cmd
+}
+
+func runRender(cmd *cobra.Command, opts RenderOptions) error {
+	actions, err := loadActions(opts.ConfigPaths)
+	if err != nil {
+		return err
+	}
+
+	rawEvent, err := os.ReadFile(opts.EventPath)
+	if err != nil {
+		return fmt.Errorf("while reading event file: %w", err)
+	}
+	var event any
+	if err := json.Unmarshal(rawEvent, &event); err != nil {
+		return fmt.Errorf("while parsing event: %w", err)
+	}
+
+	names := maputil.SortKeys(actions)
+	if opts.ActionName != "" {
+		names = []string{opts.ActionName}
+	}
+	if len(names) == 0 {
+		return errors.New("configuration doesn't define any actions")
+	}
+
+	previewer := action.NewPreviewer(actions)
+	var failed []string
+	for _, name := range names {
+		preview, err := previewer.Render(name, event, opts.SourceName)
+		if preview.Name == "" {
+			return err
+		}
+		if err != nil {
+			failed = append(failed, name)
+		}
+
+		msg := action.PreviewMessage(preview, err)
+		cmd.Println(interactive.MessageToPlaintext(msg, interactive.NewlineFormatter))
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("rendering failed for %d action(s): %v", len(failed), failed)
+	}
+	return nil
+}
+
+// loadActions reads actions from given configuration files. Other configuration properties are ignored,
+// so the files don't need to contain a complete and valid Botkube configuration.
+func loadActions(paths []string) (config.Actions, error) {
+	out := config.Actions{}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("while reading configuration file: %w", err)
+		}
+
+		var cfg struct {
+			Actions config.Actions `yaml:"actions"`
+		}
+		if err := yaml.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("while parsing configuration file %q: %w", path, err)
+		}
+		for name, act := range cfg.Actions {
+			out[name] = act
+		}
+	}
+	return out, nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 	"go.szostok.io/version/extension"
 
+	"github.com/kubeshop/botkube/cmd/cli/cmd/action"
 	"github.com/kubeshop/botkube/cmd/cli/cmd/config"
 	"github.com/kubeshop/botkube/cmd/cli/cmd/telemetry"
 	"github.com/kubeshop/botkube/internal/cli"
@@ -43,6 +44,7 @@ func NewRoot() *cobra.Command {
 		NewInstall(),
 		NewUninstall(),
 		config.NewCmd(),
+		action.NewCmd(),
 		telemetry.NewCmd(),
 		extension.NewVersionCobraCmd(
 			extension.WithUpgradeNotice(orgName, repoName),
@@ -0,0 +1,104 @@
+package approval
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+)
+
+// ExecuteFn executes an approved action and returns its output.
+type ExecuteFn func(ctx context.Context) interactive.CoreMessage
+
+// Request represents an automated action or a destructive command waiting for a human approval.
+type Request struct {
+	ID          string
+	ActionName  string
+	DisplayName string
+	Command     string
+	// Approvers is a list of users who can decide. If empty, everyone can.
+	Approvers []string
+	ExpiresAt time.Time
+	Execute   ExecuteFn
+}
+
+// IsApprover returns true if a given user is allowed to decide. Users are matched by their ID or mention only,
+// as display names can be changed by users.
+func (r Request) IsApprover(mention string) bool {
+	if len(r.Approvers) == 0 {
+		return true
+	}
+
+	userID := strings.TrimSuffix(strings.TrimPrefix(mention, "<@"), ">")
+	for _, approver := range r.Approvers {
+		switch approver {
+		case "":
+		case mention, userID:
+			return true
+		}
+	}
+	return false
+}
+
+// Store keeps pending approval requests. Expired requests are removed lazily.
+type Store struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	requests map[string]Request
+}
+
+// NewStore returns a new Store instance.
+func NewStore() *Store {
+	return &Store{
+		now:      time.Now,
+		requests: map[string]Request{},
+	}
+}
+
+// Add stores a given request and returns its ID.
+func (s *Store) Add(req Request) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired()
+	req.ID = uuid.NewString()
+	s.requests[req.ID] = req
+	return req.ID
+}
+
+// Get returns a pending request with a given ID.
+func (s *Store) Get(id string) (Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired()
+	req, found := s.requests[id]
+	return req, found
+}
+
+// Remove removes a pending request with a given ID. It returns false if the request was already removed or it expired.
+func (s *Store) Remove(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired()
+	if _, found := s.requests[id]; !found {
+		return false
+	}
+	delete(s.requests, id)
+	return true
+}
+
+func (s *Store) removeExpired() {
+	now := s.now()
+	for id, req := range s.requests {
+		if !req.ExpiresAt.IsZero() && !now.Before(req.ExpiresAt) {
+			delete(s.requests, id)
+		}
+	}
+}
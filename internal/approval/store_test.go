@@ -0,0 +1,55 @@
+package approval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRequestLifecycle(t *testing.T) {
+	// given
+	now := time.Now()
+	store := NewStore()
+	store.now = func() time.Time { return now }
+
+	// when
+	id := store.Add(Request{ActionName: "scale-down", ExpiresAt: now.Add(time.Minute)})
+	expiredID := store.Add(Request{ActionName: "delete-pods", ExpiresAt: now.Add(time.Second)})
+	now = now.Add(2 * time.Second)
+
+	// then
+	req, found := store.Get(id)
+	require.True(t, found)
+	assert.Equal(t, "scale-down", req.ActionName)
+	assert.Equal(t, id, req.ID)
+
+	_, found = store.Get(expiredID)
+	assert.False(t, found)
+
+	assert.True(t, store.Remove(id))
+	assert.False(t, store.Remove(id))
+}
+
+func TestRequestIsApprover(t *testing.T) {
+	tests := []struct {
+		name      string
+		approvers []string
+		mention   string
+		expected  bool
+	}{
+		{name: "Everyone when allowlist is empty", mention: "<@U123>", expected: true},
+		{name: "Match by user ID", approvers: []string{"U123"}, mention: "<@U123>", expected: true},
+		{name: "Match by mention", approvers: []string{"<@U123>"}, mention: "<@U123>", expected: true},
+		{name: "Display name doesn't match", approvers: []string{"alice"}, mention: "<@U123>", expected: false},
+		{name: "No match", approvers: []string{"bob"}, mention: "<@U123>", expected: false},
+		{name: "Empty identity doesn't match empty entry", approvers: []string{""}, expected: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := Request{Approvers: tc.approvers}
+			assert.Equal(t, tc.expected, req.IsApprover(tc.mention))
+		})
+	}
+}
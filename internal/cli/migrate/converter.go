@@ -321,6 +321,7 @@ func graphqlPolicySubjectType(sub bkconfig.PolicySubjectType) gqlModel.PolicySub
 	case bkconfig.ChannelNamePolicySubjectType:
 		return gqlModel.PolicySubjectTypeChannelName
 	default:
+		// UserName and UserEmail are not supported by Botkube Cloud yet, so such subjects are migrated as empty ones.
 		return gqlModel.PolicySubjectTypeEmpty
 	}
 }
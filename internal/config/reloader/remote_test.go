@@ -153,9 +153,33 @@ func fixConfig(actionEnabled bool) config.Config {
 				Name:      "botkube-system",
 				Namespace: "botkube",
 			},
+			Delivery: config.Delivery{
+				Workers:        4,
+				BufferSize:     100,
+				OverflowPolicy: config.BlockDeliveryOverflowPolicy,
+				BlockTimeout:   10 * time.Second,
+				Retry: config.DeliveryRetry{
+					MaxAttempts:  5,
+					InitialDelay: time.Second,
+					MaxDelay:     30 * time.Second,
+				},
+				Spillover: config.DeliverySpillover{
+					MaxItems: 1000,
+					ConfigMap: config.K8sResourceRef{
+						Name:      "botkube-delivery-queue",
+						Namespace: "botkube",
+					},
+				},
+			},
+			EventHistory: config.EventHistory{
+				MaxEventsPerSource: 100,
+			},
 		},
 		Plugins: config.PluginManagement{
 			CacheDir: "/tmp",
+			IncomingWebhook: config.IncomingWebhook{
+				MaxBodySize: 1048576,
+			},
 		},
 		ConfigWatcher: config.CfgWatcher{
 			Remote: config.RemoteCfgWatcher{
@@ -0,0 +1,53 @@
+package delivery
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricsNamespace = "botkube"
+	metricsSubsystem = "delivery"
+
+	dropReasonBufferFull      = "buffer_full"
+	dropReasonRetriesExceeded = "retries_exceeded"
+	dropReasonPermanentError  = "permanent_error"
+	dropReasonStoreFull       = "store_full"
+)
+
+var (
+	queueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "queue_depth",
+		Help:      "Number of messages buffered in memory for a given notifier.",
+	}, []string{"queue"})
+
+	spilledItems = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "spilled_messages",
+		Help:      "Number of messages persisted in the spillover store for a given notifier.",
+	}, []string{"queue"})
+
+	deliveredTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "delivered_total",
+		Help:      "Total number of successfully delivered messages.",
+	}, []string{"queue"})
+
+	retriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "retries_total",
+		Help:      "Total number of delivery retries.",
+	}, []string{"queue"})
+
+	droppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "dropped_total",
+		Help:      "Total number of dropped messages.",
+	}, []string{"queue", "reason"})
+)
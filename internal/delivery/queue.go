@@ -0,0 +1,442 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/health"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+const flushTimeout = 10 * time.Second
+
+// Item represents a single message queued for delivery.
+type Item struct {
+	ID string `json:"id"`
+
+	// Message is set for bot notifiers.
+	Message *interactive.CoreMessage `json:"message,omitempty"`
+	// Event is set for sink notifiers.
+	Event any `json:"event,omitempty"`
+
+	Sources []string `json:"sources"`
+	// OrderingKey identifies items which must be delivered in order. If empty, items with the same sources are delivered in order.
+	OrderingKey string `json:"orderingKey,omitempty"`
+	// Routed is set for items routed by a routing rule. Source bindings are ignored for such items.
+	Routed bool `json:"routed,omitempty"`
+	// Channels contains routing rule target channels. It is used only for bot notifiers.
+	Channels []string `json:"channels,omitempty"`
+	// ThreadOf is the ID of an item in which message threads this item should be posted. It is used only for bot notifiers.
+	ThreadOf string `json:"threadOf,omitempty"`
+	// RetryChannels contains channels which failed to receive the message in a previous attempt. If set, only these channels are retried.
+	// It is used only for bot notifiers.
+	RetryChannels []string `json:"retryChannels,omitempty"`
+
+	PluginName      string                 `json:"pluginName,omitempty"`
+	AnalyticsLabels map[string]interface{} `json:"analyticsLabels,omitempty"`
+	// SkipReport is set for items which shouldn't be reported as handled events, e.g. automated action results.
+	SkipReport bool      `json:"skipReport,omitempty"`
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+}
+
+// DeliverFn delivers a given item. It is called until it succeeds, returns a notifier.PermanentError, or the max attempts limit is reached.
+// It may update the item, so the next attempt retries only the failed part of the delivery.
+type DeliverFn func(ctx context.Context, item *Item) error
+
+// ReportFn is called once for each item with the final delivery result.
+type ReportFn func(item Item, err error)
+
+// Queue delivers items to a single notifier using a bounded pool of workers. Failed deliveries are retried with exponential backoff.
+// Items with the same ordering key, or the same sources if the key is not set, are always handled by the same worker, so their order is preserved.
+// When the buffer is full, Enqueue either blocks or spills items over to a persistent store, depending on the overflow policy.
+type Queue struct {
+	log     logrus.FieldLogger
+	key     string
+	cfg     config.Delivery
+	store   Store
+	deliver DeliverFn
+	report  ReportFn
+
+	shards []chan Item
+
+	mu      sync.Mutex
+	spilled []Item
+	stats   queueStats
+}
+
+type queueStats struct {
+	delivered int
+	retries   int
+	dropped   int
+	lastError string
+}
+
+// NewQueue returns a new Queue instance.
+func NewQueue(log logrus.FieldLogger, key string, cfg config.Delivery, store Store, deliver DeliverFn, report ReportFn) *Queue {
+	if store == nil {
+		store = NoopStore{}
+	}
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	// the buffer size is shared between all workers
+	shardSize := (cfg.BufferSize + workers - 1) / workers
+	if shardSize < 1 {
+		shardSize = 1
+	}
+
+	shards := make([]chan Item, 0, workers)
+	for i := 0; i < workers; i++ {
+		shards = append(shards, make(chan Item, shardSize))
+	}
+
+	return &Queue{
+		log:     log.WithField("queue", key),
+		key:     key,
+		cfg:     cfg,
+		store:   store,
+		deliver: deliver,
+		report:  report,
+		shards:  shards,
+	}
+}
+
+// Key returns the queue identifier.
+func (q *Queue) Key() string {
+	return q.key
+}
+
+// Enqueue adds a given item to the queue.
+// For the Block overflow policy, it blocks until there is a room in the buffer, the block timeout elapses, or the context is canceled.
+// In the two latter cases, the item is spilled over.
+func (q *Queue) Enqueue(ctx context.Context, item Item) {
+	if item.ID == "" {
+		item.ID = uuid.New().String()
+	}
+	if item.EnqueuedAt.IsZero() {
+		item.EnqueuedAt = time.Now()
+	}
+	shard := q.shardFor(item)
+
+	if q.cfg.OverflowPolicy == config.BlockDeliveryOverflowPolicy && !q.isSpilling() {
+		var timeout <-chan time.Time
+		if q.cfg.BlockTimeout > 0 {
+			timer := time.NewTimer(q.cfg.BlockTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		select {
+		case shard <- item:
+			queueDepth.WithLabelValues(q.key).Inc()
+			return
+		case <-timeout:
+			q.log.WithField("itemID", item.ID).Warnf("Delivery queue is still full after %s. Spilling message over...", q.cfg.BlockTimeout)
+		case <-ctx.Done():
+			// agent is shutting down, keep the item for the next run.
+			// use separate ctx as parent ctx is already cancelled
+			spillCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+			defer cancel()
+
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.spillLocked(spillCtx, item)
+			return
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// preserve ordering: once we started spilling, all new items go to the spillover until the workers catch up
+	if len(q.spilled) == 0 {
+		select {
+		case shard <- item:
+			queueDepth.WithLabelValues(q.key).Inc()
+			return
+		default:
+		}
+	}
+
+	q.spillLocked(ctx, item)
+}
+
+// Run restores previously spilled items and starts workers which process the queue until the context is canceled.
+// On shutdown, all undelivered items are persisted in the spillover store.
+func (q *Queue) Run(ctx context.Context) {
+	q.restore(ctx)
+
+	var wg sync.WaitGroup
+	for _, shard := range q.shards {
+		wg.Add(1)
+		go func(shard chan Item) {
+			defer wg.Done()
+			q.runWorker(ctx, shard)
+		}(shard)
+	}
+	wg.Wait()
+
+	q.flush()
+}
+
+func (q *Queue) runWorker(ctx context.Context, shard chan Item) {
+	for {
+		q.refillFromSpillover(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case item := <-shard:
+			queueDepth.WithLabelValues(q.key).Dec()
+			q.process(ctx, item)
+		}
+	}
+}
+
+// GetStatus returns the current queue status.
+func (q *Queue) GetStatus() health.DeliveryQueueStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return health.DeliveryQueueStatus{
+		Depth:     q.bufferedLen() + len(q.spilled),
+		Spilled:   len(q.spilled),
+		Delivered: q.stats.delivered,
+		Retries:   q.stats.retries,
+		Dropped:   q.stats.dropped,
+		LastError: q.stats.lastError,
+	}
+}
+
+func (q *Queue) process(ctx context.Context, item Item) {
+	delay := q.cfg.Retry.InitialDelay
+	if delay <= 0 {
+		delay = time.Second
+	}
+	attempts := q.cfg.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	attempt := 0
+	opts := []retry.Option{
+		retry.OnRetry(func(n uint, err error) {
+			q.log.Debugf("Retrying delivery of %q (attempt no %d/%d): %s", item.ID, n+2, attempts, err)
+			q.incRetries()
+		}),
+		retry.Attempts(attempts),
+		retry.Delay(delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(func(err error) bool {
+			return !notifier.IsPermanentError(err)
+		}),
+		retry.Context(ctx),
+	}
+	if q.cfg.Retry.MaxDelay > 0 {
+		opts = append(opts, retry.MaxDelay(q.cfg.Retry.MaxDelay))
+	}
+
+	err := retry.Do(func() error {
+		attempt++
+		return q.deliver(ctx, &item)
+	}, opts...)
+
+	if err != nil && ctx.Err() != nil {
+		// agent is shutting down, keep the item for the next run
+		q.mu.Lock()
+		q.spilled = append([]Item{item}, q.spilled...)
+		q.mu.Unlock()
+		return
+	}
+
+	q.mu.Lock()
+	if err != nil {
+		reason := dropReasonRetriesExceeded
+		if notifier.IsPermanentError(err) {
+			reason = dropReasonPermanentError
+		}
+		q.stats.dropped++
+		q.stats.lastError = err.Error()
+		droppedTotal.WithLabelValues(q.key, reason).Inc()
+	} else {
+		q.stats.delivered++
+		deliveredTotal.WithLabelValues(q.key).Inc()
+	}
+	q.mu.Unlock()
+
+	if err != nil {
+		err = fmt.Errorf("while delivering message after %d attempts: %w", attempt, err)
+	}
+	if q.report != nil {
+		q.report(item, err)
+	}
+}
+
+func (q *Queue) incRetries() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.stats.retries++
+	retriesTotal.WithLabelValues(q.key).Inc()
+}
+
+// spillLocked must be called with q.mu held.
+func (q *Queue) spillLocked(ctx context.Context, item Item) {
+	if _, disabled := q.store.(NoopStore); disabled || len(q.spilled) >= q.cfg.Spillover.MaxItems {
+		q.stats.dropped++
+		droppedTotal.WithLabelValues(q.key, dropReasonBufferFull).Inc()
+		q.log.WithField("itemID", item.ID).Warn("Delivery queue is full. Dropping message...")
+		return
+	}
+
+	err := q.store.Append(ctx, q.key, []Item{item})
+	switch {
+	case errors.Is(err, ErrStoreFull):
+		q.stats.dropped++
+		droppedTotal.WithLabelValues(q.key, dropReasonStoreFull).Inc()
+		q.log.WithField("itemID", item.ID).Warnf("Dropping message: %s", err.Error())
+		return
+	case err != nil:
+		q.log.Errorf("while persisting spilled messages: %s", err.Error())
+	}
+
+	q.spilled = append(q.spilled, item)
+	spilledItems.WithLabelValues(q.key).Set(float64(len(q.spilled)))
+}
+
+func (q *Queue) refillFromSpillover(ctx context.Context) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.spilled) == 0 {
+		return
+	}
+
+	moved := 0
+	for _, item := range q.spilled {
+		select {
+		case q.shardFor(item) <- item:
+			queueDepth.WithLabelValues(q.key).Inc()
+			moved++
+			continue
+		default:
+		}
+		// stop on the first full shard to preserve ordering
+		break
+	}
+	if moved == 0 {
+		return
+	}
+
+	q.spilled = q.spilled[moved:]
+	spilledItems.WithLabelValues(q.key).Set(float64(len(q.spilled)))
+	if err := q.store.Trim(ctx, q.key, moved); err != nil {
+		q.log.Errorf("while persisting spilled messages: %s", err.Error())
+	}
+}
+
+func (q *Queue) isSpilling() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.spilled) > 0
+}
+
+func (q *Queue) bufferedLen() int {
+	out := 0
+	for _, shard := range q.shards {
+		out += len(shard)
+	}
+	return out
+}
+
+// shardFor returns a worker channel for a given item. Items with the same ordering key, or the same sources if the key is not set,
+// always go to the same worker.
+func (q *Queue) shardFor(item Item) chan Item {
+	if len(q.shards) == 1 {
+		return q.shards[0]
+	}
+	key := item.OrderingKey
+	if key == "" {
+		key = strings.Join(item.Sources, ",")
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return q.shards[h.Sum32()%uint32(len(q.shards))]
+}
+
+func (q *Queue) restore(ctx context.Context) {
+	items, err := q.store.Load(ctx, q.key)
+	if err != nil {
+		q.log.Errorf("while restoring spilled messages: %s", err.Error())
+		return
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	q.log.Infof("Restored %d undelivered messages", len(items))
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// items spilled before the restore are already persisted, so they are part of the loaded ones
+	restored := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		restored[item.ID] = struct{}{}
+	}
+	for _, item := range q.spilled {
+		if _, found := restored[item.ID]; !found {
+			items = append(items, item)
+		}
+	}
+	q.spilled = items
+	spilledItems.WithLabelValues(q.key).Set(float64(len(q.spilled)))
+}
+
+func (q *Queue) flush() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var pending []Item
+	for _, shard := range q.shards {
+	drain:
+		for {
+			select {
+			case item := <-shard:
+				queueDepth.WithLabelValues(q.key).Dec()
+				pending = append(pending, item)
+			default:
+				break drain
+			}
+		}
+	}
+	q.spilled = append(pending, q.spilled...)
+	// items were drained from multiple workers, restore the original order
+	sort.SliceStable(q.spilled, func(i, j int) bool {
+		return q.spilled[i].EnqueuedAt.Before(q.spilled[j].EnqueuedAt)
+	})
+
+	if _, disabled := q.store.(NoopStore); disabled {
+		if len(q.spilled) > 0 {
+			q.log.Warnf("Spillover is disabled. Dropping %d undelivered messages...", len(q.spilled))
+		}
+		return
+	}
+
+	// use separate ctx as parent ctx is already cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := q.store.Save(ctx, q.key, q.spilled); err != nil {
+		q.log.Errorf("while persisting undelivered messages: %s", err.Error())
+	}
+}
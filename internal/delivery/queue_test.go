@@ -0,0 +1,381 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+func TestQueueRetriesFailedDelivery(t *testing.T) {
+	// given
+	var (
+		mu       sync.Mutex
+		attempts int
+		results  = make(chan error, 1)
+	)
+	deliver := func(context.Context, *Item) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts < 3 {
+			return errors.New("platform unavailable")
+		}
+		return nil
+	}
+	report := func(_ Item, err error) {
+		results <- err
+	}
+
+	queue := NewQueue(loggerx.NewNoop(), "test", fixDeliveryConfig(5), NoopStore{}, deliver, report)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	// when
+	queue.Enqueue(ctx, Item{Sources: []string{"k8s-events"}})
+
+	// then
+	select {
+	case err := <-results:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("item was not delivered")
+	}
+
+	status := queue.GetStatus()
+	assert.Equal(t, 1, status.Delivered)
+	assert.Equal(t, 2, status.Retries)
+	assert.Equal(t, 0, status.Dropped)
+}
+
+func TestQueueReportsErrorWhenAttemptsExceeded(t *testing.T) {
+	// given
+	results := make(chan error, 1)
+	deliver := func(context.Context, *Item) error {
+		return errors.New("platform unavailable")
+	}
+	report := func(_ Item, err error) {
+		results <- err
+	}
+
+	queue := NewQueue(loggerx.NewNoop(), "test", fixDeliveryConfig(2), NoopStore{}, deliver, report)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	// when
+	queue.Enqueue(ctx, Item{})
+
+	// then
+	select {
+	case err := <-results:
+		require.EqualError(t, err, "while delivering message after 2 attempts: platform unavailable")
+	case <-time.After(5 * time.Second):
+		t.Fatal("item was not reported")
+	}
+
+	status := queue.GetStatus()
+	assert.Equal(t, 0, status.Delivered)
+	assert.Equal(t, 1, status.Dropped)
+	assert.Equal(t, "platform unavailable", status.LastError)
+}
+
+func TestQueueDoesNotRetryPermanentError(t *testing.T) {
+	// given
+	var (
+		mu       sync.Mutex
+		attempts int
+		results  = make(chan error, 1)
+	)
+	deliver := func(context.Context, *Item) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		return notifier.NewPermanentError(errors.New("channel not found"))
+	}
+	report := func(_ Item, err error) {
+		results <- err
+	}
+
+	queue := NewQueue(loggerx.NewNoop(), "test", fixDeliveryConfig(5), NoopStore{}, deliver, report)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	// when
+	queue.Enqueue(ctx, Item{})
+
+	// then
+	select {
+	case err := <-results:
+		require.EqualError(t, err, "while delivering message after 1 attempts: channel not found")
+	case <-time.After(5 * time.Second):
+		t.Fatal("item was not reported")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, attempts)
+	status := queue.GetStatus()
+	assert.Equal(t, 0, status.Retries)
+	assert.Equal(t, 1, status.Dropped)
+}
+
+func TestQueueDropsItemsWhenBufferIsFull(t *testing.T) {
+	// given
+	cfg := fixDeliveryConfig(1)
+	cfg.BufferSize = 2
+	queue := NewQueue(loggerx.NewNoop(), "test", cfg, NoopStore{}, nil, nil)
+
+	// when
+	for i := 0; i < 5; i++ {
+		queue.Enqueue(context.Background(), Item{})
+	}
+
+	// then
+	status := queue.GetStatus()
+	assert.Equal(t, 2, status.Depth)
+	assert.Equal(t, 3, status.Dropped)
+}
+
+func TestQueueSpillsOverAndRestoresItems(t *testing.T) {
+	// given
+	store, err := NewDiskStore(t.TempDir())
+	require.NoError(t, err)
+
+	cfg := fixDeliveryConfig(1)
+	cfg.BufferSize = 1
+	queue := NewQueue(loggerx.NewNoop(), "test", cfg, store, nil, nil)
+
+	// when
+	for _, id := range []string{"1", "2", "3"} {
+		queue.Enqueue(context.Background(), Item{ID: id})
+	}
+
+	// then
+	status := queue.GetStatus()
+	assert.Equal(t, 3, status.Depth)
+	assert.Equal(t, 2, status.Spilled)
+	assert.Equal(t, 0, status.Dropped)
+
+	// when agent is stopped before delivering anything
+	queue.flush()
+
+	// then all items are persisted in order
+	items, err := store.Load(context.Background(), "test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, itemIDs(items))
+
+	// when agent is started again
+	var (
+		mu        sync.Mutex
+		delivered []string
+		done      = make(chan struct{})
+	)
+	deliver := func(_ context.Context, item *Item) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, item.ID)
+		if len(delivered) == 3 {
+			close(done)
+		}
+		return nil
+	}
+	restarted := NewQueue(loggerx.NewNoop(), "test", cfg, store, deliver, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go restarted.Run(ctx)
+
+	// then
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("restored items were not delivered")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"1", "2", "3"}, delivered)
+}
+
+func TestQueuePreservesOrderPerSource(t *testing.T) {
+	// given
+	const itemsPerSource = 50
+	sources := []string{"k8s-err-events", "k8s-create-events", "cm-watcher", "keptn"}
+
+	var (
+		mu        sync.Mutex
+		delivered = map[string][]int{}
+		wg        sync.WaitGroup
+	)
+	wg.Add(len(sources) * itemsPerSource)
+	deliver := func(_ context.Context, item *Item) error {
+		defer wg.Done()
+		mu.Lock()
+		defer mu.Unlock()
+		delivered[item.Sources[0]] = append(delivered[item.Sources[0]], item.Event.(int))
+		return nil
+	}
+
+	cfg := fixDeliveryConfig(1)
+	cfg.Workers = 3
+	cfg.OverflowPolicy = config.BlockDeliveryOverflowPolicy
+	queue := NewQueue(loggerx.NewNoop(), "test", cfg, NoopStore{}, deliver, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	// when
+	for i := 0; i < itemsPerSource; i++ {
+		for _, src := range sources {
+			queue.Enqueue(ctx, Item{Event: i, Sources: []string{src}})
+		}
+	}
+	wg.Wait()
+
+	// then
+	mu.Lock()
+	defer mu.Unlock()
+	for _, src := range sources {
+		got := delivered[src]
+		require.Len(t, got, itemsPerSource)
+		for i := range got {
+			assert.Equal(t, i, got[i], "unexpected order for %s", src)
+		}
+	}
+}
+
+func TestQueueBlocksWhenBufferIsFull(t *testing.T) {
+	// given
+	cfg := fixDeliveryConfig(1)
+	cfg.BufferSize = 1
+	cfg.OverflowPolicy = config.BlockDeliveryOverflowPolicy
+
+	release := make(chan struct{})
+	deliver := func(context.Context, *Item) error {
+		<-release
+		return nil
+	}
+	queue := NewQueue(loggerx.NewNoop(), "test", cfg, NoopStore{}, deliver, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Run(ctx)
+
+	// first item is being processed, the second one fills the buffer
+	queue.Enqueue(ctx, Item{})
+	require.Eventually(t, func() bool {
+		return queue.GetStatus().Depth == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	queue.Enqueue(ctx, Item{})
+
+	// when
+	enqueued := make(chan struct{})
+	go func() {
+		queue.Enqueue(ctx, Item{})
+		close(enqueued)
+	}()
+
+	// then
+	select {
+	case <-enqueued:
+		t.Fatal("enqueue should block when buffer is full")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-enqueued:
+	case <-time.After(5 * time.Second):
+		t.Fatal("enqueue should be unblocked once there is a room in the buffer")
+	}
+	assert.Equal(t, 0, queue.GetStatus().Dropped)
+}
+
+func TestQueueSpillsOverWhenBlocked(t *testing.T) {
+	tests := []struct {
+		name         string
+		blockTimeout time.Duration
+		cancelCtx    bool
+	}{
+		{
+			name:         "Block timeout elapsed",
+			blockTimeout: 10 * time.Millisecond,
+		},
+		{
+			name:      "Context canceled",
+			cancelCtx: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			cfg := fixDeliveryConfig(1)
+			cfg.BufferSize = 1
+			cfg.OverflowPolicy = config.BlockDeliveryOverflowPolicy
+			cfg.BlockTimeout = tc.blockTimeout
+
+			store := &ctxAwareStore{}
+			queue := NewQueue(loggerx.NewNoop(), "test", cfg, store, nil, nil)
+			queue.Enqueue(context.Background(), Item{ID: "1"})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tc.cancelCtx {
+				cancel()
+			}
+			defer cancel()
+
+			// when
+			queue.Enqueue(ctx, Item{ID: "2"})
+
+			// then
+			assert.Equal(t, []string{"2"}, itemIDs(store.items))
+			status := queue.GetStatus()
+			assert.Equal(t, 1, status.Spilled)
+			assert.Equal(t, 0, status.Dropped)
+		})
+	}
+}
+
+func fixDeliveryConfig(attempts uint) config.Delivery {
+	return config.Delivery{
+		BufferSize: 10,
+		Retry: config.DeliveryRetry{
+			MaxAttempts:  attempts,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     10 * time.Millisecond,
+		},
+		Spillover: config.DeliverySpillover{
+			MaxItems: 10,
+		},
+	}
+}
+
+func itemIDs(items []Item) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.ID)
+	}
+	return out
+}
+
+// ctxAwareStore keeps items in memory and fails for canceled contexts, as the stores calling external APIs do.
+type ctxAwareStore struct {
+	NoopStore
+	items []Item
+}
+
+func (s *ctxAwareStore) Append(ctx context.Context, _ string, items []Item) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.items = append(s.items, items...)
+	return nil
+}
@@ -0,0 +1,630 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// maxConfigMapDataSize limits the size of items persisted in a ConfigMap, so it stays below the 1 MiB limit of Kubernetes objects.
+const maxConfigMapDataSize = 900 * 1024
+
+var invalidKeyCharsRegex = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+// ErrStoreFull is returned when items can't be persisted, as the store reached its size limit.
+var ErrStoreFull = errors.New("spillover store is full")
+
+// Store persists queued items, so they survive agent restarts.
+type Store interface {
+	// Load returns all persisted items for a given queue.
+	Load(ctx context.Context, key string) ([]Item, error)
+	// Append persists given items after the already persisted ones.
+	Append(ctx context.Context, key string, items []Item) error
+	// Trim removes a given number of the oldest persisted items.
+	Trim(ctx context.Context, key string, n int) error
+	// Save replaces all persisted items for a given queue. Saving an empty list removes the entry.
+	Save(ctx context.Context, key string, items []Item) error
+}
+
+// NewStore returns a Store for a given spillover configuration.
+func NewStore(cfg config.DeliverySpillover, k8sCli kubernetes.Interface) (Store, error) {
+	switch cfg.Type {
+	case config.NoneDeliverySpilloverType:
+		return NoopStore{}, nil
+	case config.DiskDeliverySpilloverType:
+		return NewDiskStore(cfg.Path)
+	case config.ConfigMapDeliverySpilloverType:
+		return NewConfigMapStore(cfg.ConfigMap.Namespace, cfg.ConfigMap.Name, k8sCli), nil
+	default:
+		return nil, fmt.Errorf("unknown spillover type %q", cfg.Type)
+	}
+}
+
+// NoopStore doesn't persist anything.
+type NoopStore struct{}
+
+// Load returns no items.
+func (NoopStore) Load(context.Context, string) ([]Item, error) {
+	return nil, nil
+}
+
+// Append does nothing.
+func (NoopStore) Append(context.Context, string, []Item) error {
+	return nil
+}
+
+// Trim does nothing.
+func (NoopStore) Trim(context.Context, string, int) error {
+	return nil
+}
+
+// Save does nothing.
+func (NoopStore) Save(context.Context, string, []Item) error {
+	return nil
+}
+
+// DiskStore persists items in a given directory. Each queue is stored as a log file with one JSON item per line,
+// and a separate file with the number of already consumed lines. Consumed lines are removed once they make up
+// the majority of the log, so both appending and trimming are amortized O(1).
+type DiskStore struct {
+	dir string
+
+	mu   sync.Mutex
+	logs map[string]*diskLog
+}
+
+type diskLog struct {
+	// total is the number of lines in the log file.
+	total int
+	// offset is the number of consumed lines.
+	offset int
+}
+
+// NewDiskStore returns a new DiskStore instance.
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("while creating spillover directory: %w", err)
+	}
+	return &DiskStore{dir: dir, logs: map[string]*diskLog{}}, nil
+}
+
+// Load returns all persisted items for a given queue.
+func (s *DiskStore) Load(_ context.Context, key string) ([]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lines, offset, err := s.read(key)
+	if err != nil {
+		return nil, err
+	}
+	s.logs[key] = &diskLog{total: len(lines), offset: offset}
+
+	var items []Item
+	for _, line := range lines[offset:] {
+		var item Item
+		if err := json.Unmarshal(line, &item); err != nil {
+			return nil, fmt.Errorf("while unmarshaling spilled item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Append persists given items at the end of the queue log.
+func (s *DiskStore) Append(_ context.Context, key string, items []Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log, err := s.logLocked(key)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("while marshaling spilled item: %w", err)
+		}
+		buf.Write(raw)
+		buf.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(s.logPath(key), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("while opening spillover file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("while writing spillover file: %w", err)
+	}
+
+	log.total += len(items)
+	return nil
+}
+
+// Trim marks a given number of the oldest items as consumed.
+func (s *DiskStore) Trim(_ context.Context, key string, n int) error {
+	if n <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log, err := s.logLocked(key)
+	if err != nil {
+		return err
+	}
+
+	log.offset += n
+	if log.offset > log.total {
+		log.offset = log.total
+	}
+
+	switch {
+	case log.offset == log.total:
+		return s.saveLocked(key, nil)
+	case log.offset*2 >= log.total:
+		lines, _, err := s.read(key)
+		if err != nil {
+			return err
+		}
+		if log.offset > len(lines) {
+			return s.saveLocked(key, nil)
+		}
+		return s.writeLinesLocked(key, lines[log.offset:])
+	default:
+		return writeFileAtomically(s.offsetPath(key), []byte(strconv.Itoa(log.offset)))
+	}
+}
+
+// Save replaces all persisted items for a given queue.
+func (s *DiskStore) Save(_ context.Context, key string, items []Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveLocked(key, items)
+}
+
+func (s *DiskStore) saveLocked(key string, items []Item) error {
+	var lines [][]byte
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("while marshaling spilled item: %w", err)
+		}
+		lines = append(lines, raw)
+	}
+	return s.writeLinesLocked(key, lines)
+}
+
+func (s *DiskStore) writeLinesLocked(key string, lines [][]byte) error {
+	s.logs[key] = &diskLog{total: len(lines)}
+
+	if err := os.Remove(s.offsetPath(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("while removing spillover offset file: %w", err)
+	}
+	if len(lines) == 0 {
+		if err := os.Remove(s.logPath(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("while removing spillover file: %w", err)
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return writeFileAtomically(s.logPath(key), buf.Bytes())
+}
+
+// logLocked returns the state of a given queue log. It's read from disk if the log wasn't loaded yet.
+func (s *DiskStore) logLocked(key string) (*diskLog, error) {
+	if log, found := s.logs[key]; found {
+		return log, nil
+	}
+	lines, offset, err := s.read(key)
+	if err != nil {
+		return nil, err
+	}
+	log := &diskLog{total: len(lines), offset: offset}
+	s.logs[key] = log
+	return log, nil
+}
+
+// read returns all lines from the queue log and the number of consumed lines.
+func (s *DiskStore) read(key string) ([][]byte, int, error) {
+	raw, err := os.ReadFile(s.logPath(key))
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return nil, 0, nil
+	default:
+		return nil, 0, fmt.Errorf("while reading spillover file: %w", err)
+	}
+
+	var lines [][]byte
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			// skip empty lines, including a partially written one after a crash
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	offset := 0
+	rawOffset, err := os.ReadFile(s.offsetPath(key))
+	switch {
+	case err == nil:
+		offset, err = strconv.Atoi(strings.TrimSpace(string(rawOffset)))
+		if err != nil {
+			return nil, 0, fmt.Errorf("while parsing spillover offset: %w", err)
+		}
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return nil, 0, fmt.Errorf("while reading spillover offset file: %w", err)
+	}
+	if offset > len(lines) {
+		offset = len(lines)
+	}
+
+	return lines, offset, nil
+}
+
+func (s *DiskStore) logPath(key string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s.jsonl", normalizeKey(key)))
+}
+
+func (s *DiskStore) offsetPath(key string) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s.offset", normalizeKey(key)))
+}
+
+// writeFileAtomically writes to a temporary file first, so we don't end up with a partially written file.
+func writeFileAtomically(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("while writing spillover file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("while renaming spillover file: %w", err)
+	}
+	return nil
+}
+
+// ConfigMapStore persists items in a given ConfigMap. Each item is stored under a separate key,
+// which consists of the normalized queue key and a sequence number. As a result, appending and trimming
+// items sends only the changed keys instead of the whole queue.
+// The ConfigMap is shared by all queues, so its total size is limited. Items which don't fit are rejected with ErrStoreFull.
+type ConfigMapStore struct {
+	namespace string
+	name      string
+	k8sCli    kubernetes.Interface
+	maxSize   int
+
+	mu     sync.Mutex
+	queues map[string]*configMapQueue
+	// dataSizes holds sizes of the ConfigMap data entries. It's nil until the ConfigMap is read.
+	dataSizes map[string]int
+	dataSize  int
+}
+
+type configMapQueue struct {
+	// seqs holds sequence numbers of persisted items, from the oldest one.
+	seqs []uint64
+	next uint64
+}
+
+// NewConfigMapStore returns a new ConfigMapStore instance.
+func NewConfigMapStore(ns, name string, k8sCli kubernetes.Interface) *ConfigMapStore {
+	return &ConfigMapStore{
+		namespace: ns,
+		name:      name,
+		k8sCli:    k8sCli,
+		maxSize:   maxConfigMapDataSize,
+		queues:    map[string]*configMapQueue{},
+	}
+}
+
+// Load returns all persisted items for a given queue.
+func (s *ConfigMapStore) Load(ctx context.Context, key string) ([]Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.getData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := queueFromConfigMapData(data, key)
+	s.queues[key] = queue
+
+	var items []Item
+	for _, seq := range queue.seqs {
+		var item Item
+		if err := json.Unmarshal([]byte(data[itemConfigMapKey(key, seq)]), &item); err != nil {
+			return nil, fmt.Errorf("while unmarshaling spilled item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Append persists given items after the already persisted ones.
+func (s *ConfigMapStore) Append(ctx context.Context, key string, items []Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, err := s.queueLocked(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	changes := map[string]*string{}
+	var seqs []uint64
+	for i, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("while marshaling spilled item: %w", err)
+		}
+		seq := queue.next + uint64(i)
+		val := string(raw)
+		changes[itemConfigMapKey(key, seq)] = &val
+		seqs = append(seqs, seq)
+	}
+
+	if err := s.ensureFitsLocked(ctx, changes); err != nil {
+		return err
+	}
+	if err := s.patch(ctx, changes); err != nil {
+		return err
+	}
+	queue.seqs = append(queue.seqs, seqs...)
+	queue.next += uint64(len(items))
+	return nil
+}
+
+// Trim removes a given number of the oldest persisted items.
+func (s *ConfigMapStore) Trim(ctx context.Context, key string, n int) error {
+	if n <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue, err := s.queueLocked(ctx, key)
+	if err != nil {
+		return err
+	}
+	if n > len(queue.seqs) {
+		n = len(queue.seqs)
+	}
+	if n == 0 {
+		return nil
+	}
+
+	changes := map[string]*string{}
+	for _, seq := range queue.seqs[:n] {
+		changes[itemConfigMapKey(key, seq)] = nil
+	}
+	if err := s.patch(ctx, changes); err != nil {
+		return err
+	}
+	queue.seqs = queue.seqs[n:]
+	return nil
+}
+
+// Save replaces all persisted items for a given queue.
+func (s *ConfigMapStore) Save(ctx context.Context, key string, items []Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.getData(ctx)
+	if err != nil {
+		return err
+	}
+	old := queueFromConfigMapData(data, key)
+
+	changes := map[string]*string{}
+	for _, seq := range old.seqs {
+		changes[itemConfigMapKey(key, seq)] = nil
+	}
+	queue := &configMapQueue{next: old.next}
+	for _, item := range items {
+		raw, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("while marshaling spilled item: %w", err)
+		}
+		val := string(raw)
+		changes[itemConfigMapKey(key, queue.next)] = &val
+		queue.seqs = append(queue.seqs, queue.next)
+		queue.next++
+	}
+
+	if err := s.ensureFitsLocked(ctx, changes); err != nil {
+		return err
+	}
+	if err := s.patch(ctx, changes); err != nil {
+		return err
+	}
+	s.queues[key] = queue
+	return nil
+}
+
+// queueLocked returns the state of a given queue. It's read from the ConfigMap if the queue wasn't loaded yet.
+func (s *ConfigMapStore) queueLocked(ctx context.Context, key string) (*configMapQueue, error) {
+	if queue, found := s.queues[key]; found {
+		return queue, nil
+	}
+	data, err := s.getData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queue := queueFromConfigMapData(data, key)
+	s.queues[key] = queue
+	return queue, nil
+}
+
+// ensureFitsLocked returns ErrStoreFull if the ConfigMap data would exceed the size limit after given changes.
+func (s *ConfigMapStore) ensureFitsLocked(ctx context.Context, changes map[string]*string) error {
+	if s.dataSizes == nil {
+		if _, err := s.getData(ctx); err != nil {
+			return err
+		}
+	}
+
+	size := s.dataSize
+	for key, val := range changes {
+		size -= s.dataSizes[key]
+		if val != nil {
+			size += len(key) + len(*val)
+		}
+	}
+	if size > s.maxSize {
+		return fmt.Errorf("%w: persisting items would exceed the ConfigMap size limit of %d bytes", ErrStoreFull, s.maxSize)
+	}
+	return nil
+}
+
+func (s *ConfigMapStore) getData(ctx context.Context) (map[string]string, error) {
+	cm, err := s.k8sCli.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	var data map[string]string
+	switch {
+	case err == nil:
+		data = cm.Data
+	case apierrors.IsNotFound(err):
+	default:
+		return nil, fmt.Errorf("while getting the ConfigMap: %w", err)
+	}
+
+	s.dataSizes = map[string]int{}
+	s.dataSize = 0
+	for key, val := range data {
+		s.setDataSize(key, &val)
+	}
+	return data, nil
+}
+
+func (s *ConfigMapStore) setDataSize(key string, val *string) {
+	s.dataSize -= s.dataSizes[key]
+	delete(s.dataSizes, key)
+	if val == nil {
+		return
+	}
+	s.dataSizes[key] = len(key) + len(*val)
+	s.dataSize += s.dataSizes[key]
+}
+
+// patch sets given keys in the ConfigMap data. Keys with nil values are removed.
+func (s *ConfigMapStore) patch(ctx context.Context, changes map[string]*string) error {
+	if err := s.applyPatch(ctx, changes); err != nil {
+		return err
+	}
+	if s.dataSizes != nil {
+		for key, val := range changes {
+			s.setDataSize(key, val)
+		}
+	}
+	return nil
+}
+
+func (s *ConfigMapStore) applyPatch(ctx context.Context, changes map[string]*string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"data": changes,
+	})
+	if err != nil {
+		return fmt.Errorf("while marshaling ConfigMap patch: %w", err)
+	}
+
+	_, err = s.k8sCli.CoreV1().ConfigMaps(s.namespace).Patch(ctx, s.name, types.MergePatchType, raw, metav1.PatchOptions{})
+	switch {
+	case err == nil:
+		return nil
+	case apierrors.IsNotFound(err):
+	default:
+		return fmt.Errorf("while updating the ConfigMap with spilled items: %w", err)
+	}
+
+	data := map[string]string{}
+	for key, val := range changes {
+		if val != nil {
+			data[key] = *val
+		}
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name,
+			Namespace: s.namespace,
+		},
+		Data: data,
+	}
+	_, err = s.k8sCli.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("while creating the ConfigMap with spilled items: %w", err)
+	}
+	return nil
+}
+
+// queueFromConfigMapData returns sequence numbers of items persisted for a given queue, sorted from the oldest one.
+func queueFromConfigMapData(data map[string]string, key string) *configMapQueue {
+	prefix := normalizeKey(key) + "."
+	queue := &configMapQueue{}
+	for dataKey := range data {
+		if !strings.HasPrefix(dataKey, prefix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimPrefix(dataKey, prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		queue.seqs = append(queue.seqs, seq)
+	}
+	sort.Slice(queue.seqs, func(i, j int) bool {
+		return queue.seqs[i] < queue.seqs[j]
+	})
+	if len(queue.seqs) > 0 {
+		queue.next = queue.seqs[len(queue.seqs)-1] + 1
+	}
+	return queue
+}
+
+func itemConfigMapKey(key string, seq uint64) string {
+	return fmt.Sprintf("%s.%020d", normalizeKey(key), seq)
+}
+
+func normalizeKey(key string) string {
+	return invalidKeyCharsRegex.ReplaceAllString(key, "_")
+}
@@ -0,0 +1,122 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStoresAppendAndTrimItems(t *testing.T) {
+	diskStore, err := NewDiskStore(t.TempDir())
+	require.NoError(t, err)
+	k8sCli := fake.NewSimpleClientset()
+
+	tests := []struct {
+		name  string
+		store Store
+		// reopen returns a new store instance for the same storage, to simulate agent restart
+		reopen func() Store
+	}{
+		{
+			name:  "Disk",
+			store: diskStore,
+			reopen: func() Store {
+				store, err := NewDiskStore(diskStore.dir)
+				require.NoError(t, err)
+				return store
+			},
+		},
+		{
+			name:  "ConfigMap",
+			store: NewConfigMapStore("botkube", "botkube-delivery-queue", k8sCli),
+			reopen: func() Store {
+				return NewConfigMapStore("botkube", "botkube-delivery-queue", k8sCli)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// when
+			for i := 1; i <= 5; i++ {
+				require.NoError(t, tc.store.Append(ctx, "socketSlack/default", []Item{{ID: fmt.Sprint(i)}}))
+			}
+			require.NoError(t, tc.store.Append(ctx, "webhook/default", []Item{{ID: "other"}}))
+			require.NoError(t, tc.store.Trim(ctx, "socketSlack/default", 2))
+
+			// then
+			items, err := tc.reopen().Load(ctx, "socketSlack/default")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"3", "4", "5"}, itemIDs(items))
+
+			// when the rest is appended and trimmed after restart
+			reopened := tc.reopen()
+			require.NoError(t, reopened.Append(ctx, "socketSlack/default", []Item{{ID: "6"}}))
+			require.NoError(t, reopened.Trim(ctx, "socketSlack/default", 3))
+
+			// then
+			items, err = tc.reopen().Load(ctx, "socketSlack/default")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"6"}, itemIDs(items))
+
+			// when all items are consumed
+			require.NoError(t, reopened.Trim(ctx, "socketSlack/default", 1))
+
+			// then other queues are not affected
+			items, err = tc.reopen().Load(ctx, "socketSlack/default")
+			require.NoError(t, err)
+			assert.Empty(t, items)
+			items, err = tc.reopen().Load(ctx, "webhook/default")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"other"}, itemIDs(items))
+		})
+	}
+}
+
+func TestConfigMapStoreSavesItemsUnderSeparateKeys(t *testing.T) {
+	// given
+	ctx := context.Background()
+	k8sCli := fake.NewSimpleClientset()
+	store := NewConfigMapStore("botkube", "botkube-delivery-queue", k8sCli)
+	require.NoError(t, store.Append(ctx, "socketSlack/default", []Item{{ID: "1"}, {ID: "2"}}))
+
+	// when
+	require.NoError(t, store.Save(ctx, "socketSlack/default", []Item{{ID: "3"}}))
+
+	// then
+	cm, err := k8sCli.CoreV1().ConfigMaps("botkube").Get(ctx, "botkube-delivery-queue", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Len(t, cm.Data, 1)
+	assert.Contains(t, cm.Data, "socketSlack_default.00000000000000000002")
+}
+
+func TestConfigMapStoreLimitsTotalSize(t *testing.T) {
+	// given
+	ctx := context.Background()
+	k8sCli := fake.NewSimpleClientset()
+	store := NewConfigMapStore("botkube", "botkube-delivery-queue", k8sCli)
+	store.maxSize = 200
+	require.NoError(t, store.Append(ctx, "socketSlack/default", []Item{{ID: "1"}}))
+
+	// when
+	err := store.Append(ctx, "discord/default", []Item{{ID: "2"}, {ID: "3"}})
+
+	// then
+	require.ErrorIs(t, err, ErrStoreFull)
+
+	// when
+	require.NoError(t, store.Trim(ctx, "socketSlack/default", 1))
+	err = store.Append(ctx, "discord/default", []Item{{ID: "2"}})
+
+	// then
+	require.NoError(t, err)
+	items, err := NewConfigMapStore("botkube", "botkube-delivery-queue", k8sCli).Load(ctx, "discord/default")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, itemIDs(items))
+}
@@ -23,6 +23,11 @@ type Notifier interface {
 	GetStatus() PlatformStatus
 }
 
+// DeliveryQueue represents notifier delivery queue interface
+type DeliveryQueue interface {
+	GetStatus() DeliveryQueueStatus
+}
+
 // Checker gives health bot agent status.
 type Checker struct {
 	applicationStarted bool
@@ -30,6 +35,7 @@ type Checker struct {
 	config             *config.Config
 	pluginHealthStats  *plugin.HealthStats
 	notifiers          map[string]Notifier
+	deliveryQueues     map[string]DeliveryQueue
 }
 
 // NewChecker create new health checker.
@@ -40,6 +46,7 @@ func NewChecker(ctx context.Context, config *config.Config, stats *plugin.Health
 		config:             config,
 		pluginHealthStats:  stats,
 		notifiers:          map[string]Notifier{},
+		deliveryQueues:     map[string]DeliveryQueue{},
 	}
 }
 
@@ -85,6 +92,11 @@ func (h *Checker) AddNotifier(key string, notifier Notifier) {
 	h.notifiers[key] = notifier
 }
 
+// AddDeliveryQueue add notifier delivery queue instance
+func (h *Checker) AddDeliveryQueue(key string, queue DeliveryQueue) {
+	h.deliveryQueues[key] = queue
+}
+
 func (h *Checker) GetStatus() *Status {
 	pluginsStats := make(map[string]PluginStatus)
 	h.collectSourcePluginsStatuses(pluginsStats)
@@ -94,8 +106,9 @@ func (h *Checker) GetStatus() *Status {
 		Botkube: BotStatus{
 			Status: h.getBotkubeStatus(),
 		},
-		Plugins:   pluginsStats,
-		Platforms: h.getPlatformsStatus(),
+		Plugins:        pluginsStats,
+		Platforms:      h.getPlatformsStatus(),
+		DeliveryQueues: h.getDeliveryQueuesStatus(),
 	}
 }
 
@@ -107,6 +120,16 @@ func (h *Checker) collectSourcePluginsStatuses(plugins map[string]PluginStatus)
 		for pluginName, pluginValues := range sourceValues.GetPlugins() {
 			h.collectPluginStatus(plugins, pluginConfigName, pluginName, pluginValues.Enabled)
 		}
+
+		pluginStatus := plugins[pluginConfigName]
+		status, restarts, threshold, timestamp, lastErr := h.pluginHealthStats.GetStreamStats(pluginConfigName)
+		pluginStatus.Stream = &StreamStatus{
+			Status:             status,
+			Restarts:           fmt.Sprintf("%d/%d", restarts, threshold),
+			LastTransitionTime: timestamp,
+			LastError:          lastErr,
+		}
+		plugins[pluginConfigName] = pluginStatus
 	}
 }
 
@@ -147,3 +170,11 @@ func (h *Checker) getPlatformsStatus() platformStatuses {
 
 	return defaultStatuses
 }
+
+func (h *Checker) getDeliveryQueuesStatus() map[string]DeliveryQueueStatus {
+	statuses := map[string]DeliveryQueueStatus{}
+	for key, queue := range h.deliveryQueues {
+		statuses[key] = queue.GetStatus()
+	}
+	return statuses
+}
@@ -28,19 +28,39 @@ type PlatformStatus struct {
 	ErrorMsg string            `json:"errorMsg,omitempty"`
 }
 
+// DeliveryQueueStatus defines single notifier delivery queue status.
+type DeliveryQueueStatus struct {
+	Depth     int    `json:"depth"`
+	Spilled   int    `json:"spilled"`
+	Delivered int    `json:"delivered"`
+	Retries   int    `json:"retries"`
+	Dropped   int    `json:"dropped"`
+	LastError string `json:"lastError,omitempty"`
+}
+
 // Status defines bot agent status.
 type Status struct {
-	Botkube   BotStatus               `json:"botkube"`
-	Plugins   map[string]PluginStatus `json:"plugins,omitempty"`
-	Platforms platformStatuses        `json:"platforms,omitempty"`
+	Botkube        BotStatus                      `json:"botkube"`
+	Plugins        map[string]PluginStatus        `json:"plugins,omitempty"`
+	Platforms      platformStatuses               `json:"platforms,omitempty"`
+	DeliveryQueues map[string]DeliveryQueueStatus `json:"deliveryQueues,omitempty"`
 }
 
 type platformStatuses map[string]PlatformStatus
 
 type PluginStatus struct {
-	Enabled  bool   `json:"enabled,omitempty"`
-	Status   string `json:"status,omitempty"`
-	Restarts string `json:"restarts,omitempty"`
+	Enabled  bool          `json:"enabled,omitempty"`
+	Status   string        `json:"status,omitempty"`
+	Restarts string        `json:"restarts,omitempty"`
+	Stream   *StreamStatus `json:"stream,omitempty"`
+}
+
+// StreamStatus holds the status of a supervised source stream.
+type StreamStatus struct {
+	Status             string `json:"status,omitempty"`
+	Restarts           string `json:"restarts,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+	LastError          string `json:"lastError,omitempty"`
 }
 
 type BotStatus struct {
@@ -0,0 +1,153 @@
+package history
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+// Event represents a single dispatched source event.
+type Event struct {
+	ID                string
+	SourceName        string
+	SourceDisplayName string
+	PluginName        string
+	Message           api.Message
+	RawObject         any
+	CreatedAt         time.Time
+}
+
+// ListFilter holds criteria for listing events.
+type ListFilter struct {
+	// SourceName limits events to a given source. Empty value means all sources.
+	SourceName string
+	// Since limits events to the ones created after a given time. Zero value means no limit.
+	Since time.Time
+}
+
+// Store keeps a bounded history of recently dispatched events for each source.
+// When the limit for a given source is reached, the oldest event is overwritten.
+type Store struct {
+	maxPerSource int
+
+	mu      sync.RWMutex
+	seq     uint64
+	buffers map[string]*ringBuffer
+}
+
+// NewStore returns a new Store instance. If maxPerSource is lower than 1, events are not recorded.
+func NewStore(maxPerSource int) *Store {
+	return &Store{
+		maxPerSource: maxPerSource,
+		buffers:      map[string]*ringBuffer{},
+	}
+}
+
+// Record adds a given event to the history and returns its ID. Event ID and creation time are set if missing.
+func (s *Store) Record(event Event) string {
+	if s == nil || s.maxPerSource < 1 {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	if event.ID == "" {
+		event.ID = strconv.FormatUint(s.seq, 10)
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	buf, found := s.buffers[event.SourceName]
+	if !found {
+		buf = newRingBuffer(s.maxPerSource)
+		s.buffers[event.SourceName] = buf
+	}
+	buf.add(event)
+	return event.ID
+}
+
+// List returns events matching a given filter, sorted from the oldest to the newest one.
+func (s *Store) List(filter ListFilter) []Event {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out []Event
+	for name, buf := range s.buffers {
+		if filter.SourceName != "" && filter.SourceName != name {
+			continue
+		}
+		for _, event := range buf.items() {
+			if !filter.Since.IsZero() && event.CreatedAt.Before(filter.Since) {
+				continue
+			}
+			out = append(out, event)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out
+}
+
+// Get returns an event with a given ID.
+func (s *Store) Get(id string) (Event, bool) {
+	if s == nil {
+		return Event{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, buf := range s.buffers {
+		for _, event := range buf.items() {
+			if event.ID == id {
+				return event, true
+			}
+		}
+	}
+	return Event{}, false
+}
+
+// ringBuffer is a fixed-size circular buffer. It is not safe for concurrent use.
+type ringBuffer struct {
+	events []Event
+	next   int
+	full   bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{
+		events: make([]Event, size),
+	}
+}
+
+func (r *ringBuffer) add(event Event) {
+	r.events[r.next] = event
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// items returns buffered events from the oldest to the newest one.
+func (r *ringBuffer) items() []Event {
+	if !r.full {
+		return append([]Event(nil), r.events[:r.next]...)
+	}
+
+	out := make([]Event, 0, len(r.events))
+	out = append(out, r.events[r.next:]...)
+	out = append(out, r.events[:r.next]...)
+	return out
+}
@@ -0,0 +1,55 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreKeepsRecentEventsPerSource(t *testing.T) {
+	// given
+	store := NewStore(2)
+	now := time.Now()
+
+	// when
+	for i, src := range []string{"k8s-err", "k8s-err", "keptn", "k8s-err"} {
+		store.Record(Event{
+			SourceName: src,
+			CreatedAt:  now.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	// then the oldest k8s-err event is overwritten
+	assert.Equal(t, []string{"2", "3", "4"}, eventIDs(store.List(ListFilter{})))
+	assert.Equal(t, []string{"2", "4"}, eventIDs(store.List(ListFilter{SourceName: "k8s-err"})))
+	assert.Equal(t, []string{"3", "4"}, eventIDs(store.List(ListFilter{Since: now.Add(90 * time.Second)})))
+
+	event, found := store.Get("3")
+	require.True(t, found)
+	assert.Equal(t, "keptn", event.SourceName)
+
+	_, found = store.Get("1")
+	assert.False(t, found)
+}
+
+func TestStoreDisabled(t *testing.T) {
+	// given
+	store := NewStore(0)
+
+	// when
+	id := store.Record(Event{SourceName: "k8s-err"})
+
+	// then
+	assert.Empty(t, id)
+	assert.Empty(t, store.List(ListFilter{}))
+}
+
+func eventIDs(events []Event) []string {
+	var out []string
+	for _, e := range events {
+		out = append(out, e.ID)
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+// Package deprecation detects Kubernetes objects which use deprecated or removed API versions.
+package deprecation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Origin describes where a deprecated API version was found.
+type Origin string
+
+const (
+	// ObjectOrigin means that the object itself was read with a deprecated API version.
+	ObjectOrigin Origin = "object"
+	// LastAppliedOrigin means that the object was applied with a deprecated API version,
+	// so re-applying the same manifest fails once the API version is removed.
+	LastAppliedOrigin Origin = "last-applied-configuration"
+)
+
+// Finding describes a single usage of a deprecated API version.
+type Finding struct {
+	API    API
+	Origin Origin
+}
+
+// All returns all known deprecated and removed API versions.
+func All() []API {
+	return append([]API{}, table...)
+}
+
+// Lookup returns deprecation details for a given API version and kind.
+func Lookup(apiVersion, kind string) (API, bool) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return API{}, false
+	}
+
+	wanted := gv.WithKind(kind)
+	for _, api := range table {
+		if api.GroupVersionKind == wanted {
+			return api, true
+		}
+	}
+	return API{}, false
+}
+
+// Check returns deprecated API versions used by a given object.
+// Both the object API version and the one from the `kubectl.kubernetes.io/last-applied-configuration` annotation are checked.
+func Check(obj *unstructured.Unstructured) []Finding {
+	if obj == nil {
+		return nil
+	}
+
+	var out []Finding
+	if api, found := Lookup(obj.GetAPIVersion(), obj.GetKind()); found {
+		out = append(out, Finding{API: api, Origin: ObjectOrigin})
+	}
+
+	lastApplied, found := lastAppliedTypeMeta(obj)
+	if !found || lastApplied.APIVersion == obj.GetAPIVersion() {
+		return out
+	}
+	if api, found := Lookup(lastApplied.APIVersion, lastApplied.Kind); found {
+		out = append(out, Finding{API: api, Origin: LastAppliedOrigin})
+	}
+	return out
+}
+
+// IsRemovedIn returns true if the API version is not served by a given Kubernetes version.
+func (a API) IsRemovedIn(kubeVersion *version.Version) bool {
+	if kubeVersion == nil {
+		return false
+	}
+	removedIn, err := version.ParseGeneric(a.RemovedIn)
+	if err != nil {
+		return false
+	}
+	return kubeVersion.AtLeast(removedIn)
+}
+
+// Description returns a human-readable description of the deprecation, including the replacement API version.
+func (a API) Description() string {
+	out := fmt.Sprintf("%s %s is deprecated since %s and removed in %s.", a.GroupVersion().String(), a.Kind, a.DeprecatedIn, a.RemovedIn)
+	if a.Replacement.Empty() {
+		return out + " There is no replacement API version."
+	}
+	return fmt.Sprintf("%s Use %s instead.", out, a.Replacement.String())
+}
+
+// ReplacementString returns the replacement API version or "-" if there is no replacement.
+func (a API) ReplacementString() string {
+	if a.Replacement.Empty() {
+		return "-"
+	}
+	return a.Replacement.String()
+}
+
+type typeMeta struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+func lastAppliedTypeMeta(obj *unstructured.Unstructured) (typeMeta, bool) {
+	raw, found := obj.GetAnnotations()[corev1.LastAppliedConfigAnnotation]
+	if !found || raw == "" {
+		return typeMeta{}, false
+	}
+
+	var out typeMeta
+	if err := json.Unmarshal([]byte(raw), &out); err != nil || out.APIVersion == "" {
+		return typeMeta{}, false
+	}
+	if out.Kind == "" {
+		out.Kind = obj.GetKind()
+	}
+	return out, true
+}
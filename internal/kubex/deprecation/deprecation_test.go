@@ -0,0 +1,66 @@
+package deprecation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+func TestCheck(t *testing.T) {
+	// given
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("networking.k8s.io/v1beta1")
+	obj.SetKind("Ingress")
+	obj.SetAnnotations(map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion":"extensions/v1beta1","kind":"Ingress","metadata":{"name":"api"}}`,
+	})
+
+	// when
+	findings := Check(obj)
+
+	// then
+	require.Len(t, findings, 2)
+	assert.Equal(t, ObjectOrigin, findings[0].Origin)
+	assert.Equal(t, "v1.22", findings[0].API.RemovedIn)
+	assert.Equal(t, LastAppliedOrigin, findings[1].Origin)
+	assert.Equal(t, "extensions", findings[1].API.Group)
+	assert.Equal(t, "networking.k8s.io/v1", findings[1].API.Replacement.String())
+}
+
+func TestCheckIgnoresInvalidLastApplied(t *testing.T) {
+	// given
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("networking.k8s.io/v1")
+	obj.SetKind("Ingress")
+	obj.SetAnnotations(map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": `{invalid`,
+	})
+
+	// when
+	findings := Check(obj)
+
+	// then
+	assert.Empty(t, findings)
+}
+
+func TestAPIIsRemovedIn(t *testing.T) {
+	api, found := Lookup("batch/v1beta1", "CronJob")
+	require.True(t, found)
+
+	assert.False(t, api.IsRemovedIn(version.MustParseGeneric("v1.24.3")))
+	assert.True(t, api.IsRemovedIn(version.MustParseGeneric("v1.25.0")))
+	assert.True(t, api.IsRemovedIn(version.MustParseGeneric("1.29")))
+}
+
+func TestAPIDescription(t *testing.T) {
+	psp, found := Lookup("policy/v1beta1", "PodSecurityPolicy")
+	require.True(t, found)
+	assert.Equal(t, "policy/v1beta1 PodSecurityPolicy is deprecated since v1.21 and removed in v1.25. There is no replacement API version.", psp.Description())
+
+	cronJob, found := Lookup("batch/v1beta1", "CronJob")
+	require.True(t, found)
+	assert.Equal(t, "batch/v1beta1 CronJob is deprecated since v1.21 and removed in v1.25. Use batch/v1 instead.", cronJob.Description())
+}
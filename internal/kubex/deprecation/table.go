@@ -0,0 +1,102 @@
+package deprecation
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// API describes a deprecated or removed Kubernetes API version of a given kind.
+type API struct {
+	schema.GroupVersionKind
+	// Resource is the plural resource name, e.g. "deployments".
+	Resource string
+	// DeprecatedIn is the Kubernetes version in which the API version was deprecated.
+	DeprecatedIn string
+	// RemovedIn is the Kubernetes version in which the API version is no longer served.
+	RemovedIn string
+	// Replacement is the API group version which should be used instead. Empty if there is no replacement.
+	Replacement schema.GroupVersion
+}
+
+// GroupVersionResource returns the deprecated GVR.
+func (a API) GroupVersionResource() schema.GroupVersionResource {
+	return a.GroupVersion().WithResource(a.Resource)
+}
+
+// ReplacementGroupVersionResource returns the GVR which should be used instead.
+// Returns false if there is no replacement.
+func (a API) ReplacementGroupVersionResource() (schema.GroupVersionResource, bool) {
+	if a.Replacement.Empty() {
+		return schema.GroupVersionResource{}, false
+	}
+	return a.Replacement.WithResource(a.Resource), true
+}
+
+// table is based on the official Kubernetes deprecated API migration guide: https://kubernetes.io/docs/reference/using-api/deprecation-guide/.
+// Only persisted resources are listed.
+var table = []API{
+	// v1.16
+	{GroupVersionKind: gvk("extensions", "v1beta1", "Deployment"), Resource: "deployments", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("extensions", "v1beta1", "DaemonSet"), Resource: "daemonsets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("extensions", "v1beta1", "ReplicaSet"), Resource: "replicasets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("extensions", "v1beta1", "NetworkPolicy"), Resource: "networkpolicies", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("networking.k8s.io", "v1")},
+	{GroupVersionKind: gvk("extensions", "v1beta1", "PodSecurityPolicy"), Resource: "podsecuritypolicies", DeprecatedIn: "v1.10", RemovedIn: "v1.16", Replacement: gv("policy", "v1beta1")},
+	{GroupVersionKind: gvk("apps", "v1beta1", "Deployment"), Resource: "deployments", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("apps", "v1beta1", "StatefulSet"), Resource: "statefulsets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("apps", "v1beta2", "Deployment"), Resource: "deployments", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("apps", "v1beta2", "StatefulSet"), Resource: "statefulsets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("apps", "v1beta2", "DaemonSet"), Resource: "daemonsets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+	{GroupVersionKind: gvk("apps", "v1beta2", "ReplicaSet"), Resource: "replicasets", DeprecatedIn: "v1.9", RemovedIn: "v1.16", Replacement: gv("apps", "v1")},
+
+	// v1.22
+	{GroupVersionKind: gvk("extensions", "v1beta1", "Ingress"), Resource: "ingresses", DeprecatedIn: "v1.14", RemovedIn: "v1.22", Replacement: gv("networking.k8s.io", "v1")},
+	{GroupVersionKind: gvk("networking.k8s.io", "v1beta1", "Ingress"), Resource: "ingresses", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: gv("networking.k8s.io", "v1")},
+	{GroupVersionKind: gvk("networking.k8s.io", "v1beta1", "IngressClass"), Resource: "ingressclasses", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: gv("networking.k8s.io", "v1")},
+	{GroupVersionKind: gvk("admissionregistration.k8s.io", "v1beta1", "MutatingWebhookConfiguration"), Resource: "mutatingwebhookconfigurations", DeprecatedIn: "v1.16", RemovedIn: "v1.22", Replacement: gv("admissionregistration.k8s.io", "v1")},
+	{GroupVersionKind: gvk("admissionregistration.k8s.io", "v1beta1", "ValidatingWebhookConfiguration"), Resource: "validatingwebhookconfigurations", DeprecatedIn: "v1.16", RemovedIn: "v1.22", Replacement: gv("admissionregistration.k8s.io", "v1")},
+	{GroupVersionKind: gvk("apiextensions.k8s.io", "v1beta1", "CustomResourceDefinition"), Resource: "customresourcedefinitions", DeprecatedIn: "v1.16", RemovedIn: "v1.22", Replacement: gv("apiextensions.k8s.io", "v1")},
+	{GroupVersionKind: gvk("apiregistration.k8s.io", "v1beta1", "APIService"), Resource: "apiservices", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: gv("apiregistration.k8s.io", "v1")},
+	{GroupVersionKind: gvk("certificates.k8s.io", "v1beta1", "CertificateSigningRequest"), Resource: "certificatesigningrequests", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: gv("certificates.k8s.io", "v1")},
+	{GroupVersionKind: gvk("coordination.k8s.io", "v1beta1", "Lease"), Resource: "leases", DeprecatedIn: "v1.14", RemovedIn: "v1.22", Replacement: gv("coordination.k8s.io", "v1")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io", "v1beta1", "ClusterRole"), Resource: "clusterroles", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: gv("rbac.authorization.k8s.io", "v1")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io", "v1beta1", "ClusterRoleBinding"), Resource: "clusterrolebindings", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: gv("rbac.authorization.k8s.io", "v1")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io", "v1beta1", "Role"), Resource: "roles", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: gv("rbac.authorization.k8s.io", "v1")},
+	{GroupVersionKind: gvk("rbac.authorization.k8s.io", "v1beta1", "RoleBinding"), Resource: "rolebindings", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: gv("rbac.authorization.k8s.io", "v1")},
+	{GroupVersionKind: gvk("scheduling.k8s.io", "v1beta1", "PriorityClass"), Resource: "priorityclasses", DeprecatedIn: "v1.14", RemovedIn: "v1.22", Replacement: gv("scheduling.k8s.io", "v1")},
+	{GroupVersionKind: gvk("storage.k8s.io", "v1beta1", "CSIDriver"), Resource: "csidrivers", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: gv("storage.k8s.io", "v1")},
+	{GroupVersionKind: gvk("storage.k8s.io", "v1beta1", "CSINode"), Resource: "csinodes", DeprecatedIn: "v1.17", RemovedIn: "v1.22", Replacement: gv("storage.k8s.io", "v1")},
+	{GroupVersionKind: gvk("storage.k8s.io", "v1beta1", "StorageClass"), Resource: "storageclasses", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: gv("storage.k8s.io", "v1")},
+	{GroupVersionKind: gvk("storage.k8s.io", "v1beta1", "VolumeAttachment"), Resource: "volumeattachments", DeprecatedIn: "v1.19", RemovedIn: "v1.22", Replacement: gv("storage.k8s.io", "v1")},
+
+	// v1.25
+	{GroupVersionKind: gvk("batch", "v1beta1", "CronJob"), Resource: "cronjobs", DeprecatedIn: "v1.21", RemovedIn: "v1.25", Replacement: gv("batch", "v1")},
+	{GroupVersionKind: gvk("discovery.k8s.io", "v1beta1", "EndpointSlice"), Resource: "endpointslices", DeprecatedIn: "v1.21", RemovedIn: "v1.25", Replacement: gv("discovery.k8s.io", "v1")},
+	{GroupVersionKind: gvk("events.k8s.io", "v1beta1", "Event"), Resource: "events", DeprecatedIn: "v1.19", RemovedIn: "v1.25", Replacement: gv("events.k8s.io", "v1")},
+	{GroupVersionKind: gvk("autoscaling", "v2beta1", "HorizontalPodAutoscaler"), Resource: "horizontalpodautoscalers", DeprecatedIn: "v1.22", RemovedIn: "v1.25", Replacement: gv("autoscaling", "v2")},
+	{GroupVersionKind: gvk("policy", "v1beta1", "PodDisruptionBudget"), Resource: "poddisruptionbudgets", DeprecatedIn: "v1.21", RemovedIn: "v1.25", Replacement: gv("policy", "v1")},
+	{GroupVersionKind: gvk("policy", "v1beta1", "PodSecurityPolicy"), Resource: "podsecuritypolicies", DeprecatedIn: "v1.21", RemovedIn: "v1.25"},
+	{GroupVersionKind: gvk("node.k8s.io", "v1beta1", "RuntimeClass"), Resource: "runtimeclasses", DeprecatedIn: "v1.20", RemovedIn: "v1.25", Replacement: gv("node.k8s.io", "v1")},
+
+	// v1.26
+	{GroupVersionKind: gvk("autoscaling", "v2beta2", "HorizontalPodAutoscaler"), Resource: "horizontalpodautoscalers", DeprecatedIn: "v1.23", RemovedIn: "v1.26", Replacement: gv("autoscaling", "v2")},
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io", "v1beta1", "FlowSchema"), Resource: "flowschemas", DeprecatedIn: "v1.23", RemovedIn: "v1.26", Replacement: gv("flowcontrol.apiserver.k8s.io", "v1")},
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io", "v1beta1", "PriorityLevelConfiguration"), Resource: "prioritylevelconfigurations", DeprecatedIn: "v1.23", RemovedIn: "v1.26", Replacement: gv("flowcontrol.apiserver.k8s.io", "v1")},
+
+	// v1.27
+	{GroupVersionKind: gvk("storage.k8s.io", "v1beta1", "CSIStorageCapacity"), Resource: "csistoragecapacities", DeprecatedIn: "v1.24", RemovedIn: "v1.27", Replacement: gv("storage.k8s.io", "v1")},
+
+	// v1.29
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io", "v1beta2", "FlowSchema"), Resource: "flowschemas", DeprecatedIn: "v1.26", RemovedIn: "v1.29", Replacement: gv("flowcontrol.apiserver.k8s.io", "v1")},
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io", "v1beta2", "PriorityLevelConfiguration"), Resource: "prioritylevelconfigurations", DeprecatedIn: "v1.26", RemovedIn: "v1.29", Replacement: gv("flowcontrol.apiserver.k8s.io", "v1")},
+
+	// v1.32
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io", "v1beta3", "FlowSchema"), Resource: "flowschemas", DeprecatedIn: "v1.29", RemovedIn: "v1.32", Replacement: gv("flowcontrol.apiserver.k8s.io", "v1")},
+	{GroupVersionKind: gvk("flowcontrol.apiserver.k8s.io", "v1beta3", "PriorityLevelConfiguration"), Resource: "prioritylevelconfigurations", DeprecatedIn: "v1.29", RemovedIn: "v1.32", Replacement: gv("flowcontrol.apiserver.k8s.io", "v1")},
+}
+
+func gvk(group, version, kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: group, Version: version, Kind: kind}
+}
+
+func gv(group, version string) schema.GroupVersion {
+	return schema.GroupVersion{Group: group, Version: version}
+}
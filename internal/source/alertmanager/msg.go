@@ -0,0 +1,265 @@
+package alertmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+const (
+	alertNameLabel  = "alertname"
+	namespaceLabel  = "namespace"
+	podLabel        = "pod"
+	deploymentLabel = "deployment"
+	nodeLabel       = "node"
+
+	summaryAnnotation     = "summary"
+	descriptionAnnotation = "description"
+	messageAnnotation     = "message"
+
+	// maxButtons limits the number of buttons, as an alert group may contain many alerts for different resources.
+	maxButtons = 10
+)
+
+var emojiForStatus = map[AlertStatus]string{
+	AlertStatusFiring:   "🔥",
+	AlertStatusResolved: "✅",
+}
+
+// MessageBuilder builds messages for Alertmanager alert groups.
+type MessageBuilder struct {
+	isInteractivitySupported bool
+	clusterName              string
+}
+
+// NewMessageBuilder returns a new MessageBuilder instance.
+func NewMessageBuilder(isInteractivitySupported bool, clusterName string) *MessageBuilder {
+	return &MessageBuilder{
+		isInteractivitySupported: isInteractivitySupported,
+		clusterName:              clusterName,
+	}
+}
+
+// FromPayload returns a message for a given alert group.
+func (m *MessageBuilder) FromPayload(payload WebhookPayload) api.Message {
+	msg := api.Message{
+		Timestamp: m.lastChangeTime(payload.Alerts),
+		Sections: []api.Section{
+			m.groupSection(payload),
+		},
+	}
+
+	if !m.isInteractivitySupported {
+		msg.Type = api.NonInteractiveSingleSection
+		return msg
+	}
+
+	btns := m.buttons(payload)
+	if len(btns) > 0 {
+		msg.Sections = append(msg.Sections, api.Section{
+			Buttons: btns,
+		})
+	}
+
+	return msg
+}
+
+func (m *MessageBuilder) groupSection(payload WebhookPayload) api.Section {
+	var firing, resolved []Alert
+	for _, alert := range payload.Alerts {
+		if alert.Status == AlertStatusResolved {
+			resolved = append(resolved, alert)
+			continue
+		}
+		firing = append(firing, alert)
+	}
+
+	status := payload.Status
+	if status == "" {
+		status = AlertStatusFiring
+		if len(firing) == 0 {
+			status = AlertStatusResolved
+		}
+	}
+
+	header := fmt.Sprintf("%s [%s", emojiForStatus[status], strings.ToUpper(string(status)))
+	if status == AlertStatusFiring {
+		header = fmt.Sprintf("%s:%d", header, len(firing))
+	}
+	header = fmt.Sprintf("%s] %s", header, m.groupName(payload))
+
+	section := api.Section{
+		Base: api.Base{
+			Header:      header,
+			Description: firstNonEmpty(payload.CommonAnnotations, summaryAnnotation, descriptionAnnotation, messageAnnotation),
+		},
+	}
+
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Cluster", m.clusterName)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Receiver", payload.Receiver)
+	for _, key := range maputil.SortKeys(payload.CommonLabels) {
+		if key == alertNameLabel {
+			continue
+		}
+		section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, key, payload.CommonLabels[key])
+	}
+
+	section.BulletLists = appendBulletListIfNotEmpty(section.BulletLists, "Firing", m.alertItems(firing, payload))
+	section.BulletLists = appendBulletListIfNotEmpty(section.BulletLists, "Resolved", m.alertItems(resolved, payload))
+
+	if payload.TruncatedAlerts > 0 {
+		section.Context = append(section.Context, api.ContextItem{
+			Text: fmt.Sprintf("%d more alert(s) truncated by Alertmanager.", payload.TruncatedAlerts),
+		})
+	}
+
+	return section
+}
+
+// groupName returns the name of the alert group based on its group labels, e.g. 'HighMemoryUsage'.
+func (m *MessageBuilder) groupName(payload WebhookPayload) string {
+	if name := payload.CommonLabels[alertNameLabel]; name != "" {
+		return name
+	}
+
+	var values []string
+	for _, key := range maputil.SortKeys(payload.GroupLabels) {
+		values = append(values, payload.GroupLabels[key])
+	}
+	if len(values) == 0 {
+		return "Alertmanager alerts"
+	}
+	return strings.Join(values, " ")
+}
+
+// alertItems returns alert descriptions. Labels common for the whole group are skipped, as they are already displayed in the text fields.
+func (m *MessageBuilder) alertItems(alerts []Alert, payload WebhookPayload) []string {
+	var out []string
+	for _, alert := range alerts {
+		var labels []string
+		for _, key := range maputil.SortKeys(alert.Labels) {
+			if _, common := payload.CommonLabels[key]; common {
+				continue
+			}
+			labels = append(labels, fmt.Sprintf("%s=%s", key, alert.Labels[key]))
+		}
+
+		item := alert.Labels[alertNameLabel]
+		if len(labels) > 0 {
+			item = strings.TrimSpace(fmt.Sprintf("%s {%s}", item, strings.Join(labels, ", ")))
+		}
+
+		annotation := firstNonEmpty(alert.Annotations, summaryAnnotation, descriptionAnnotation, messageAnnotation)
+		if annotation != "" && annotation != firstNonEmpty(payload.CommonAnnotations, summaryAnnotation, descriptionAnnotation, messageAnnotation) {
+			item = fmt.Sprintf("%s: %s", item, annotation)
+		}
+
+		if item == "" {
+			item = alert.Fingerprint
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// buttons returns buttons for resources referenced by alert labels, e.g. describe pod, when both 'namespace' and 'pod' labels are present.
+// Buttons are rendered only for firing alerts, as resources of resolved alerts are usually no longer interesting.
+func (m *MessageBuilder) buttons(payload WebhookPayload) api.Buttons {
+	btnBuilder := api.NewMessageButtonBuilder()
+
+	var (
+		btns api.Buttons
+		seen = map[string]struct{}{}
+	)
+	add := func(btn api.Button) {
+		key := btn.Command + btn.URL
+		if _, found := seen[key]; found || len(btns) >= maxButtons {
+			return
+		}
+		seen[key] = struct{}{}
+		btns = append(btns, btn)
+	}
+
+	for _, alert := range payload.Alerts {
+		if alert.Status == AlertStatusResolved {
+			continue
+		}
+
+		// label values are used in commands, so resources with invalid names are skipped
+		ns := alert.Labels[namespaceLabel]
+		if len(validation.IsDNS1123Label(ns)) > 0 {
+			ns = ""
+		}
+		if pod := alert.Labels[podLabel]; isValidResourceName(pod) && ns != "" {
+			add(btnBuilder.ForCommandWithoutDesc(fmt.Sprintf("Describe pod %s", pod), fmt.Sprintf("kubectl describe pod %s -n %s", pod, ns), api.ButtonStylePrimary))
+			add(btnBuilder.ForCommandWithoutDesc(fmt.Sprintf("Logs of %s", pod), fmt.Sprintf("kubectl logs pod/%s -n %s --tail 50", pod, ns)))
+		}
+		if deploy := alert.Labels[deploymentLabel]; isValidResourceName(deploy) && ns != "" {
+			add(btnBuilder.ForCommandWithoutDesc(fmt.Sprintf("Describe deployment %s", deploy), fmt.Sprintf("kubectl describe deployment %s -n %s", deploy, ns), api.ButtonStylePrimary))
+		}
+		if node := alert.Labels[nodeLabel]; isValidResourceName(node) {
+			add(btnBuilder.ForCommandWithoutDesc(fmt.Sprintf("Describe node %s", node), fmt.Sprintf("kubectl describe node %s", node), api.ButtonStylePrimary))
+		}
+	}
+
+	if payload.ExternalURL != "" {
+		add(btnBuilder.ForURL("Open Alertmanager", payload.ExternalURL))
+	}
+
+	return btns
+}
+
+func isValidResourceName(name string) bool {
+	return name != "" && len(validation.IsDNS1123Subdomain(name)) == 0
+}
+
+// lastChangeTime returns the time of the most recent change in the alert group.
+func (m *MessageBuilder) lastChangeTime(alerts []Alert) time.Time {
+	var out time.Time
+	for _, alert := range alerts {
+		changed := alert.StartsAt
+		if alert.Status == AlertStatusResolved && alert.EndsAt.After(changed) {
+			changed = alert.EndsAt
+		}
+		if changed.After(out) {
+			out = changed
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(in map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if val := strings.TrimSpace(in[key]); val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+func appendTextFieldIfNotEmpty(fields api.TextFields, title, value string) api.TextFields {
+	if value == "" {
+		return fields
+	}
+	return append(fields, api.TextField{
+		Key:   title,
+		Value: value,
+	})
+}
+
+func appendBulletListIfNotEmpty(bulletLists api.BulletLists, title string, items []string) api.BulletLists {
+	if len(items) == 0 {
+		return bulletLists
+	}
+	sort.Strings(items)
+	return append(bulletLists, api.BulletList{
+		Title: title,
+		Items: items,
+	})
+}
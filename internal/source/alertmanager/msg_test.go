@@ -0,0 +1,234 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+const firingPayload = `{
+  "version": "4",
+  "groupKey": "{}:{alertname=\"KubePodCrashLooping\"}",
+  "status": "firing",
+  "receiver": "botkube",
+  "groupLabels": {"alertname": "KubePodCrashLooping"},
+  "commonLabels": {"alertname": "KubePodCrashLooping", "namespace": "default", "severity": "warning"},
+  "commonAnnotations": {"summary": "Pod is crash looping."},
+  "externalURL": "http://alertmanager.monitoring:9093",
+  "alerts": [
+    {
+      "status": "firing",
+      "labels": {"alertname": "KubePodCrashLooping", "namespace": "default", "severity": "warning", "pod": "nginx-1"},
+      "annotations": {"summary": "Pod is crash looping."},
+      "startsAt": "2023-10-05T12:00:00Z",
+      "endsAt": "0001-01-01T00:00:00Z",
+      "fingerprint": "a1"
+    },
+    {
+      "status": "resolved",
+      "labels": {"alertname": "KubePodCrashLooping", "namespace": "default", "severity": "warning", "pod": "nginx-2"},
+      "annotations": {"summary": "Pod is crash looping."},
+      "startsAt": "2023-10-05T11:00:00Z",
+      "endsAt": "2023-10-05T12:30:00Z",
+      "fingerprint": "a2"
+    }
+  ]
+}`
+
+func TestHandleExternalRequest(t *testing.T) {
+	// given
+	src := NewSource("dev")
+	in := source.ExternalRequestInput{
+		Payload: []byte(firingPayload),
+		Context: source.ExternalRequestInputContext{
+			CommonSourceContext: source.CommonSourceContext{
+				IsInteractivitySupported: true,
+				ClusterName:              "prod",
+				SourceName:               "alerts",
+			},
+		},
+	}
+
+	expMsg := api.Message{
+		Timestamp: time.Date(2023, 10, 5, 12, 30, 0, 0, time.UTC),
+		UpdateKey: `alertmanager/alerts/{}:{alertname="KubePodCrashLooping"}/1696503600`,
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "🔥 [FIRING:1] KubePodCrashLooping",
+					Description: "Pod is crash looping.",
+				},
+				TextFields: api.TextFields{
+					{Key: "Cluster", Value: "prod"},
+					{Key: "Receiver", Value: "botkube"},
+					{Key: "namespace", Value: "default"},
+					{Key: "severity", Value: "warning"},
+				},
+				BulletLists: api.BulletLists{
+					{Title: "Firing", Items: []string{"KubePodCrashLooping {pod=nginx-1}"}},
+					{Title: "Resolved", Items: []string{"KubePodCrashLooping {pod=nginx-2}"}},
+				},
+			},
+			{
+				Buttons: api.Buttons{
+					{
+						Name:    "Describe pod nginx-1",
+						Command: "{{BotName}} kubectl describe pod nginx-1 -n default",
+						Style:   api.ButtonStylePrimary,
+					},
+					{
+						Name:    "Logs of nginx-1",
+						Command: "{{BotName}} kubectl logs pod/nginx-1 -n default --tail 50",
+					},
+					{
+						Name: "Open Alertmanager",
+						URL:  "http://alertmanager.monitoring:9093",
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	out, err := src.HandleExternalRequest(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expMsg, out.Event.Message)
+
+	payload, ok := out.Event.RawObject.(WebhookPayload)
+	require.True(t, ok)
+	assert.Len(t, payload.Alerts, 2)
+}
+
+func TestHandleExternalRequestUpdateKeyPerEpisode(t *testing.T) {
+	// given
+	src := NewSource("dev")
+	send := func(status AlertStatus, startsAt time.Time) string {
+		t.Helper()
+		payload, err := json.Marshal(WebhookPayload{
+			GroupKey: "{}:{alertname=\"NodeDown\"}",
+			Status:   status,
+			Alerts: []Alert{
+				{Status: status, Labels: map[string]string{"alertname": "NodeDown"}, StartsAt: startsAt},
+			},
+		})
+		require.NoError(t, err)
+
+		out, err := src.HandleExternalRequest(context.Background(), source.ExternalRequestInput{
+			Payload: payload,
+			Context: source.ExternalRequestInputContext{
+				CommonSourceContext: source.CommonSourceContext{SourceName: "alerts"},
+			},
+		})
+		require.NoError(t, err)
+		return out.Event.Message.UpdateKey
+	}
+	firstStart := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+	secondStart := time.Date(2023, 10, 5, 14, 0, 0, 0, time.UTC)
+
+	// when
+	firing := send(AlertStatusFiring, firstStart)
+	repeated := send(AlertStatusFiring, firstStart)
+	resolved := send(AlertStatusResolved, firstStart)
+	firingAgain := send(AlertStatusFiring, secondStart)
+
+	// then
+	assert.Equal(t, firing, repeated)
+	assert.Equal(t, firing, resolved)
+	assert.NotEqual(t, firing, firingAgain)
+}
+
+func TestHandleExternalRequestForgetsStaleEpisodes(t *testing.T) {
+	// given
+	now := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+	src := NewSource("dev")
+	src.nowFn = func() time.Time { return now }
+	for _, groupKey := range []string{"stale", "active"} {
+		src.episodeUpdateKey(groupKey, WebhookPayload{
+			Status: AlertStatusFiring,
+			Alerts: []Alert{{Status: AlertStatusFiring, StartsAt: now}},
+		})
+	}
+
+	// when
+	now = now.Add(episodeTTL / 2)
+	src.episodeUpdateKey("active", WebhookPayload{Status: AlertStatusFiring, Alerts: []Alert{{Status: AlertStatusFiring}}})
+	now = now.Add(episodeTTL/2 + time.Minute)
+	src.episodeUpdateKey("new", WebhookPayload{Status: AlertStatusFiring, Alerts: []Alert{{Status: AlertStatusFiring}}})
+
+	// then
+	assert.ElementsMatch(t, []string{"active", "new"}, maputil.SortKeys(src.episodes))
+}
+
+func TestFromPayloadResolvedNonInteractive(t *testing.T) {
+	// given
+	payload := WebhookPayload{
+		Status:      AlertStatusResolved,
+		GroupLabels: map[string]string{"job": "node-exporter", "severity": "critical"},
+		Alerts: []Alert{
+			{
+				Status:      AlertStatusResolved,
+				Labels:      map[string]string{"alertname": "NodeDown", "node": "worker-1"},
+				Annotations: map[string]string{"description": "Node is unreachable."},
+			},
+		},
+	}
+
+	expMsg := api.Message{
+		Type: api.NonInteractiveSingleSection,
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header: "✅ [RESOLVED] node-exporter critical",
+				},
+				BulletLists: api.BulletLists{
+					{Title: "Resolved", Items: []string{"NodeDown {alertname=NodeDown, node=worker-1}: Node is unreachable."}},
+				},
+			},
+		},
+	}
+
+	// when
+	msg := NewMessageBuilder(false, "").FromPayload(payload)
+
+	// then
+	assert.Equal(t, expMsg, msg)
+}
+
+func TestButtonsSkipInvalidResourceNames(t *testing.T) {
+	// given
+	payload := WebhookPayload{
+		Status: AlertStatusFiring,
+		Alerts: []Alert{
+			{
+				Status: AlertStatusFiring,
+				Labels: map[string]string{"namespace": "prod", "pod": "api-0; kubectl delete ns prod", "node": "worker-1"},
+			},
+			{
+				Status: AlertStatusFiring,
+				Labels: map[string]string{"namespace": "prod --all-namespaces", "deployment": "api"},
+			},
+		},
+	}
+
+	// when
+	btns := NewMessageBuilder(true, "").buttons(payload)
+
+	// then
+	assert.Equal(t, api.Buttons{
+		{
+			Name:    "Describe node worker-1",
+			Command: "{{BotName}} kubectl describe node worker-1",
+			Style:   api.ButtonStylePrimary,
+		},
+	}, btns)
+}
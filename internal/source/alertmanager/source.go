@@ -0,0 +1,202 @@
+package alertmanager
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+var _ source.Source = (*Source)(nil)
+
+var (
+	//go:embed config_schema.json
+	configJSONSchema string
+
+	//go:embed webhook_schema.json
+	webhookJSONSchema string
+)
+
+const (
+	// PluginName is the name of the Alertmanager Botkube plugin.
+	PluginName = "alertmanager"
+
+	description = "Get notifications about Prometheus Alertmanager alerts sent via the Botkube incoming webhook."
+
+	// episodeTTL defines how long an episode is remembered without notifications. Alertmanager repeats notifications
+	// for firing groups, so groups which weren't notified for a long time stopped firing without the resolved notification.
+	episodeTTL = 24 * time.Hour
+	// maxEpisodes limits the number of remembered episodes. The least recently notified ones are forgotten first.
+	maxEpisodes = 1000
+)
+
+// AlertStatus defines the status of an Alertmanager alert or alert group.
+type AlertStatus string
+
+const (
+	// AlertStatusFiring defines firing status.
+	AlertStatusFiring AlertStatus = "firing"
+	// AlertStatusResolved defines resolved status.
+	AlertStatusResolved AlertStatus = "resolved"
+)
+
+// WebhookPayload is the payload sent by the Alertmanager webhook receiver.
+// See: https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+type WebhookPayload struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Status            AlertStatus       `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []Alert           `json:"alerts"`
+}
+
+// Alert holds a single Alertmanager alert.
+type Alert struct {
+	Status       AlertStatus       `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Source Alertmanager source plugin data structure.
+type Source struct {
+	pluginVersion string
+
+	// episodes holds alert groups which are currently firing, indexed by the group key.
+	episodes   map[string]episode
+	episodesMu sync.Mutex
+	nowFn      func() time.Time
+
+	source.StreamUnimplemented
+}
+
+// NewSource returns a new instance of Source.
+func NewSource(version string) *Source {
+	return &Source{
+		pluginVersion: version,
+		episodes:      map[string]episode{},
+		nowFn:         time.Now,
+	}
+}
+
+// episode describes a firing episode of an alert group.
+type episode struct {
+	updateKey string
+	lastSeen  time.Time
+}
+
+// Metadata returns metadata of Alertmanager source.
+func (s *Source) Metadata(_ context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     s.pluginVersion,
+		Description: description,
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+		ExternalRequest: api.ExternalRequestMetadata{
+			Payload: api.ExternalRequestPayload{
+				JSONSchema: api.JSONSchema{
+					Value: webhookJSONSchema,
+				},
+			},
+		},
+	}, nil
+}
+
+// HandleExternalRequest converts the Alertmanager webhook payload into a message.
+// Notifications for the same firing episode of an alert group share the update key, so platforms which support editing messages
+// update the firing alert message once it's resolved instead of posting a new one. Once the group fires again, a new message is posted.
+func (s *Source) HandleExternalRequest(_ context.Context, in source.ExternalRequestInput) (source.ExternalRequestOutput, error) {
+	var payload WebhookPayload
+	if err := json.Unmarshal(in.Payload, &payload); err != nil {
+		return source.ExternalRequestOutput{}, fmt.Errorf("while unmarshaling payload: %w", err)
+	}
+
+	if len(payload.Alerts) == 0 {
+		return source.ExternalRequestOutput{}, fmt.Errorf("alerts cannot be empty")
+	}
+
+	msg := NewMessageBuilder(in.Context.IsInteractivitySupported, in.Context.ClusterName).FromPayload(payload)
+	if payload.GroupKey != "" {
+		msg.UpdateKey = s.episodeUpdateKey(fmt.Sprintf("%s/%s/%s", PluginName, in.Context.SourceName, payload.GroupKey), payload)
+	}
+
+	return source.ExternalRequestOutput{
+		Event: source.Event{
+			Message:   msg,
+			RawObject: payload,
+			AnalyticsLabels: map[string]interface{}{
+				"status":      string(payload.Status),
+				"alertsCount": len(payload.Alerts),
+			},
+		},
+	}, nil
+}
+
+// episodeUpdateKey returns the update key of the current firing episode of a given alert group.
+// The episode starts with the first firing notification and ends with the resolved one, so a group which fires again
+// after being resolved doesn't edit the old message.
+func (s *Source) episodeUpdateKey(groupKey string, payload WebhookPayload) string {
+	s.episodesMu.Lock()
+	defer s.episodesMu.Unlock()
+
+	now := s.nowFn()
+	s.forgetStaleEpisodes(now)
+
+	ep, found := s.episodes[groupKey]
+	if !found {
+		ep.updateKey = fmt.Sprintf("%s/%d", groupKey, episodeStart(payload.Alerts).Unix())
+	}
+
+	if payload.Status == AlertStatusResolved {
+		delete(s.episodes, groupKey)
+		return ep.updateKey
+	}
+	ep.lastSeen = now
+	s.episodes[groupKey] = ep
+	return ep.updateKey
+}
+
+// forgetStaleEpisodes removes episodes which weren't notified within the TTL, and the least recently notified ones above the limit.
+// It must be called with episodesMu held.
+func (s *Source) forgetStaleEpisodes(now time.Time) {
+	for groupKey, ep := range s.episodes {
+		if now.Sub(ep.lastSeen) > episodeTTL {
+			delete(s.episodes, groupKey)
+		}
+	}
+
+	for len(s.episodes) >= maxEpisodes {
+		var oldestKey string
+		for groupKey, ep := range s.episodes {
+			if oldestKey == "" || ep.lastSeen.Before(s.episodes[oldestKey].lastSeen) {
+				oldestKey = groupKey
+			}
+		}
+		delete(s.episodes, oldestKey)
+	}
+}
+
+// episodeStart returns the earliest start time of given alerts.
+func episodeStart(alerts []Alert) time.Time {
+	var out time.Time
+	for _, alert := range alerts {
+		if out.IsZero() || alert.StartsAt.Before(out) {
+			out = alert.StartsAt
+		}
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package certexpiry
+
+import (
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// Config holds the certificate expiry source configuration.
+type Config struct {
+	Log config.Logger `yaml:"log"`
+	// Namespaces holds constraints for namespaces of scanned Secrets.
+	Namespaces *config.RegexConstraints `yaml:"namespaces"`
+	// Interval defines how often Secrets are scanned.
+	Interval time.Duration `yaml:"interval"`
+	// ThresholdDays defines when warnings are emitted, e.g. 30, 7 and 1 day before the expiry. Each threshold is reported once per certificate.
+	ThresholdDays []int `yaml:"thresholdDays"`
+	// CertManager configures scanning cert-manager Certificate resources.
+	CertManager CertManager `yaml:"certManager"`
+	// State configures persistence of the reported thresholds, so they are not reported again after restart.
+	State State `yaml:"state"`
+}
+
+// State holds configuration of the reported thresholds persistence.
+type State struct {
+	// ConfigMap is the ConfigMap where the reported thresholds are stored. The plugin RBAC group must be allowed to get, create and patch it.
+	// If the name is empty, the reported thresholds are kept in memory only.
+	ConfigMap config.K8sResourceRef `yaml:"configMap"`
+}
+
+// CertManager holds cert-manager integration configuration.
+type CertManager struct {
+	// Enabled adds Certificate names to warnings, and reports Certificates with expiry date in status even if their Secrets are not readable.
+	Enabled bool `yaml:"enabled"`
+}
+
+var defaultConfig = Config{
+	Log: config.Logger{
+		Level: "info",
+	},
+	Namespaces: &config.RegexConstraints{
+		Include: []string{".*"},
+	},
+	Interval:      time.Hour,
+	ThresholdDays: []int{30, 7, 1},
+	State: State{
+		ConfigMap: config.K8sResourceRef{
+			Name:      "botkube-cert-expiry-state",
+			Namespace: "botkube",
+		},
+	},
+}
+
+// MergeConfigs merges all input configuration.
+func MergeConfigs(configs []*source.Config) (Config, error) {
+	var out Config
+	if err := plugin.MergeSourceConfigsWithDefaults(defaultConfig, configs, &out); err != nil {
+		return Config{}, err
+	}
+	if out.Interval <= 0 {
+		out.Interval = defaultConfig.Interval
+	}
+	return out, nil
+}
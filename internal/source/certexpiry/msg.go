@@ -0,0 +1,96 @@
+package certexpiry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+// MessageBuilder builds messages for expiring certificates.
+type MessageBuilder struct {
+	isInteractivitySupported bool
+	clusterName              string
+}
+
+// NewMessageBuilder returns a new MessageBuilder instance.
+func NewMessageBuilder(isInteractivitySupported bool, clusterName string) *MessageBuilder {
+	return &MessageBuilder{
+		isInteractivitySupported: isInteractivitySupported,
+		clusterName:              clusterName,
+	}
+}
+
+// FromCertificate returns a warning message for a given certificate.
+func (m *MessageBuilder) FromCertificate(cert ExpiringCertificate) api.Message {
+	section := api.Section{
+		Base: api.Base{
+			Header: m.header(cert),
+		},
+	}
+
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Namespace", cert.Namespace)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Secret", cert.SecretName)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Certificate", cert.CertificateName)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Common name", cert.CommonName)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "DNS names", strings.Join(cert.DNSNames, ", "))
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Expires", cert.NotAfter.Format(time.RFC3339))
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Cluster", m.clusterName)
+
+	if len(cert.Ingresses) > 0 {
+		section.BulletLists = append(section.BulletLists, api.BulletList{
+			Title: "Referenced by Ingresses",
+			Items: cert.Ingresses,
+		})
+	}
+
+	msg := api.Message{
+		Sections: []api.Section{section},
+	}
+
+	if !m.isInteractivitySupported {
+		msg.Type = api.NonInteractiveSingleSection
+		return msg
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	var btns api.Buttons
+	if cert.CertificateName != "" {
+		btns = append(btns, btnBuilder.ForCommandWithoutDesc("Describe Certificate", fmt.Sprintf("kubectl describe certificate %s -n %s", cert.CertificateName, cert.Namespace), api.ButtonStylePrimary))
+	}
+	if cert.SecretName != "" {
+		btns = append(btns, btnBuilder.ForCommandWithoutDesc("Describe Secret", fmt.Sprintf("kubectl describe secret %s -n %s", cert.SecretName, cert.Namespace)))
+	}
+	if len(btns) > 0 {
+		msg.Sections = append(msg.Sections, api.Section{
+			Buttons: btns,
+		})
+	}
+
+	return msg
+}
+
+func (m *MessageBuilder) header(cert ExpiringCertificate) string {
+	name := cert.SecretName
+	kind := "Secret"
+	if name == "" {
+		name = cert.CertificateName
+		kind = "Certificate"
+	}
+
+	if cert.Expired {
+		return fmt.Sprintf("❗ TLS certificate in %s %s/%s has expired", kind, cert.Namespace, name)
+	}
+	return fmt.Sprintf("⚠️ TLS certificate in %s %s/%s expires in %d day(s)", kind, cert.Namespace, name, cert.DaysLeft)
+}
+
+func appendTextFieldIfNotEmpty(fields api.TextFields, title, value string) api.TextFields {
+	if value == "" {
+		return fields
+	}
+	return append(fields, api.TextField{
+		Key:   title,
+		Value: value,
+	})
+}
@@ -0,0 +1,367 @@
+package certexpiry
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubeshop/botkube/internal/source/kubernetes/recommendation"
+	"github.com/kubeshop/botkube/pkg/k8sx"
+)
+
+var (
+	secretGVR = schema.GroupVersionResource{
+		Version:  "v1",
+		Resource: "secrets",
+	}
+	ingressGVR = schema.GroupVersionResource{
+		Group:    "networking.k8s.io",
+		Version:  "v1",
+		Resource: "ingresses",
+	}
+	certificateGVR = schema.GroupVersionResource{
+		Group:    "cert-manager.io",
+		Version:  "v1",
+		Resource: "certificates",
+	}
+)
+
+// ExpiringCertificate holds details about a certificate which crossed one of the configured thresholds.
+type ExpiringCertificate struct {
+	Namespace       string    `json:"namespace"`
+	SecretName      string    `json:"secretName,omitempty"`
+	CertificateName string    `json:"certificateName,omitempty"`
+	CommonName      string    `json:"commonName,omitempty"`
+	DNSNames        []string  `json:"dnsNames,omitempty"`
+	NotAfter        time.Time `json:"notAfter"`
+	DaysLeft        int       `json:"daysLeft"`
+	ThresholdDays   int       `json:"thresholdDays"`
+	Expired         bool      `json:"expired"`
+	Ingresses       []string  `json:"ingresses,omitempty"`
+}
+
+// certificateKeyPrefix prefixes keys of candidates based on cert-manager Certificates.
+const certificateKeyPrefix = "certificate/"
+
+// Scanner finds TLS certificates which crossed the configured expiry thresholds.
+// Each threshold is reported only once per certificate. Renewed certificates are tracked from scratch.
+type Scanner struct {
+	log        logrus.FieldLogger
+	dynamicCli dynamic.Interface
+	cfg        Config
+	nowFn      func() time.Time
+
+	// reported holds the last reported threshold for a given certificate. It's loaded from the store on the first scan.
+	reported       map[string]int
+	store          *reportedStore
+	reportedLoaded bool
+}
+
+// NewScanner returns a new Scanner instance. The reported thresholds are persisted under the given state key, see StateKey.
+func NewScanner(log logrus.FieldLogger, dynamicCli dynamic.Interface, cfg Config, stateKey string) *Scanner {
+	return &Scanner{
+		log:        log,
+		dynamicCli: dynamicCli,
+		cfg:        cfg,
+		nowFn:      time.Now,
+		reported:   map[string]int{},
+		store:      newReportedStore(dynamicCli, cfg.State.ConfigMap, stateKey),
+	}
+}
+
+type candidate struct {
+	key  string
+	cert ExpiringCertificate
+}
+
+// Scan returns certificates which crossed a threshold since the previous scan.
+// Failures of listing optional resources, such as Ingresses or cert-manager Certificates, are logged and don't stop the scan.
+func (s *Scanner) Scan(ctx context.Context) ([]ExpiringCertificate, error) {
+	s.loadReported(ctx)
+
+	candidates, err := s.secretCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	certificatesListed := true
+	if s.cfg.CertManager.Enabled {
+		certificates, err := s.listCertificates(ctx)
+		if err != nil {
+			s.log.Warnf("Failed to list cert-manager Certificates. Skipping them in this scan: %s", err)
+			certificatesListed = false
+		} else {
+			candidates, err = s.withCertificates(candidates, certificates)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ingresses, err := s.ingressesBySecret(ctx)
+	if err != nil {
+		s.log.Warnf("Failed to list Ingresses. Reporting certificates without Ingress names: %s", err)
+		ingresses = map[string][]string{}
+	}
+
+	var (
+		out     []ExpiringCertificate
+		now     = s.nowFn()
+		seen    = map[string]struct{}{}
+		changed bool
+	)
+	for _, c := range candidates {
+		seen[c.key] = struct{}{}
+
+		threshold, ok := s.crossedThreshold(c.cert.NotAfter.Sub(now))
+		if !ok {
+			continue
+		}
+		if prev, reported := s.reported[c.key]; reported && prev <= threshold {
+			continue
+		}
+		s.reported[c.key] = threshold
+		changed = true
+
+		cert := c.cert
+		cert.ThresholdDays = threshold
+		cert.Expired = !now.Before(cert.NotAfter)
+		cert.DaysLeft = daysLeft(cert.NotAfter.Sub(now))
+		cert.Ingresses = ingresses[secretKey(cert.Namespace, cert.SecretName)]
+		out = append(out, cert)
+	}
+
+	// forget certificates which were renewed or removed
+	for key := range s.reported {
+		if _, found := seen[key]; found {
+			continue
+		}
+		if !certificatesListed && strings.HasPrefix(key, certificateKeyPrefix) {
+			continue
+		}
+		delete(s.reported, key)
+		changed = true
+	}
+
+	if changed {
+		if err := s.store.Save(ctx, s.reported); err != nil {
+			s.log.Warnf("Failed to persist reported certificates. They may be reported again after restart: %s", err)
+		}
+	}
+
+	return out, nil
+}
+
+func (s *Scanner) loadReported(ctx context.Context) {
+	if s.reportedLoaded {
+		return
+	}
+	s.reportedLoaded = true
+
+	reported, err := s.store.Load(ctx)
+	if err != nil {
+		s.log.Warnf("Failed to load reported certificates. Already reported thresholds may be reported again: %s", err)
+		return
+	}
+	s.reported = reported
+}
+
+// crossedThreshold returns the lowest crossed threshold in days. Expired certificates have 0 threshold.
+func (s *Scanner) crossedThreshold(remaining time.Duration) (int, bool) {
+	if remaining <= 0 {
+		return 0, true
+	}
+
+	thresholds := append([]int{}, s.cfg.ThresholdDays...)
+	sort.Ints(thresholds)
+	for _, days := range thresholds {
+		if days > 0 && remaining <= time.Duration(days)*24*time.Hour {
+			return days, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Scanner) secretCandidates(ctx context.Context) ([]candidate, error) {
+	list, err := s.dynamicCli.Resource(secretGVR).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("while listing TLS Secrets: %w", err)
+	}
+
+	var out []candidate
+	for i := range list.Items {
+		allowed, err := s.isNamespaceAllowed(list.Items[i].GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+
+		var secret corev1.Secret
+		if err := k8sx.TransformIntoTypedObject(&list.Items[i], &secret); err != nil {
+			return nil, fmt.Errorf("while transforming object type %T into type: %T: %w", list.Items[i], secret, err)
+		}
+		if secret.Type != corev1.SecretTypeTLS {
+			continue
+		}
+
+		cert, err := parseLeafCertificate(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			s.log.WithField("secret", secretKey(secret.Namespace, secret.Name)).Debugf("Skipping Secret with invalid certificate: %s", err)
+			continue
+		}
+
+		out = append(out, candidate{
+			key: fmt.Sprintf("secret/%s/%s", secretKey(secret.Namespace, secret.Name), hex.EncodeToString(cert.SerialNumber.Bytes())),
+			cert: ExpiringCertificate{
+				Namespace:  secret.Namespace,
+				SecretName: secret.Name,
+				CommonName: cert.Subject.CommonName,
+				DNSNames:   cert.DNSNames,
+				NotAfter:   cert.NotAfter.UTC(),
+			},
+		})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].key < out[j].key
+	})
+	return out, nil
+}
+
+// listCertificates returns cert-manager Certificates. Empty list is returned if the Certificate resource is not installed.
+func (s *Scanner) listCertificates(ctx context.Context) ([]unstructured.Unstructured, error) {
+	list, err := s.dynamicCli.Resource(certificateGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			s.log.Debug("cert-manager Certificate resource not found. Skipping...")
+			return nil, nil
+		}
+		return nil, fmt.Errorf("while listing cert-manager Certificates: %w", err)
+	}
+	return list.Items, nil
+}
+
+// withCertificates adds cert-manager Certificate names to the Secret candidates.
+// Certificates whose Secrets are not available, are reported based on the expiry date from the Certificate status.
+func (s *Scanner) withCertificates(candidates []candidate, certificates []unstructured.Unstructured) ([]candidate, error) {
+
+	bySecret := map[string]int{}
+	for idx, c := range candidates {
+		bySecret[secretKey(c.cert.Namespace, c.cert.SecretName)] = idx
+	}
+
+	for i := range certificates {
+		item := &certificates[i]
+		allowed, err := s.isNamespaceAllowed(item.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			continue
+		}
+
+		secretName, _, _ := unstructured.NestedString(item.Object, "spec", "secretName")
+		if idx, found := bySecret[secretKey(item.GetNamespace(), secretName)]; found {
+			candidates[idx].cert.CertificateName = item.GetName()
+			continue
+		}
+
+		notAfterRaw, _, _ := unstructured.NestedString(item.Object, "status", "notAfter")
+		if notAfterRaw == "" {
+			continue
+		}
+		notAfter, err := time.Parse(time.RFC3339, notAfterRaw)
+		if err != nil {
+			s.log.WithField("certificate", secretKey(item.GetNamespace(), item.GetName())).Debugf("Skipping Certificate with invalid expiry date: %s", err)
+			continue
+		}
+		commonName, _, _ := unstructured.NestedString(item.Object, "spec", "commonName")
+		dnsNames, _, _ := unstructured.NestedStringSlice(item.Object, "spec", "dnsNames")
+
+		candidates = append(candidates, candidate{
+			key: fmt.Sprintf("%s%s/%s", certificateKeyPrefix, secretKey(item.GetNamespace(), item.GetName()), notAfterRaw),
+			cert: ExpiringCertificate{
+				Namespace:       item.GetNamespace(),
+				SecretName:      secretName,
+				CertificateName: item.GetName(),
+				CommonName:      commonName,
+				DNSNames:        dnsNames,
+				NotAfter:        notAfter.UTC(),
+			},
+		})
+	}
+
+	return candidates, nil
+}
+
+// ingressesBySecret returns names of Ingresses which refer to a given TLS Secret.
+func (s *Scanner) ingressesBySecret(ctx context.Context) (map[string][]string, error) {
+	list, err := s.dynamicCli.Resource(ingressGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while listing Ingresses: %w", err)
+	}
+
+	out := map[string][]string{}
+	for i := range list.Items {
+		var ingress networkingv1.Ingress
+		if err := k8sx.TransformIntoTypedObject(&list.Items[i], &ingress); err != nil {
+			return nil, fmt.Errorf("while transforming object type %T into type: %T: %w", list.Items[i], ingress, err)
+		}
+
+		for _, ref := range recommendation.IngressTLSSecretRefs(ingress) {
+			key := secretKey(ref.Namespace, ref.Name)
+			out[key] = append(out[key], ingress.Name)
+		}
+	}
+	return out, nil
+}
+
+func (s *Scanner) isNamespaceAllowed(ns string) (bool, error) {
+	if s.cfg.Namespaces == nil || !s.cfg.Namespaces.AreConstraintsDefined() {
+		return true, nil
+	}
+	return s.cfg.Namespaces.IsAllowed(ns)
+}
+
+// parseLeafCertificate returns the first certificate from a given PEM bundle.
+func parseLeafCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("unexpected PEM block type %q", block.Type)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func daysLeft(remaining time.Duration) int {
+	if remaining <= 0 {
+		return 0
+	}
+	return int(remaining / (24 * time.Hour))
+}
+
+func secretKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
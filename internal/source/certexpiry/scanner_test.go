@@ -0,0 +1,250 @@
+package certexpiry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestScannerScan(t *testing.T) {
+	// given
+	now := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+	notAfter := now.Add(5 * 24 * time.Hour)
+
+	dynamicCli := fake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, map[schema.GroupVersionResource]string{
+		certificateGVR: "CertificateList",
+	},
+		fixTLSSecret(t, "prod", "api-tls", notAfter),
+		fixTLSSecret(t, "kube-system", "internal-tls", notAfter),
+		fixIngress("prod", "api", "api-tls"),
+		fixCertificate("prod", "api", "api-tls", notAfter),
+	)
+
+	cfg := Config{
+		Namespaces: &config.RegexConstraints{
+			Include: []string{".*"},
+			Exclude: []string{"kube-system"},
+		},
+		ThresholdDays: []int{30, 7, 1},
+		CertManager:   CertManager{Enabled: true},
+		State: State{
+			ConfigMap: config.K8sResourceRef{Name: "botkube-cert-expiry-state", Namespace: "botkube"},
+		},
+	}
+	scanner := NewScanner(loggerx.NewNoop(), dynamicCli, cfg, "cert-expiry")
+
+	expCert := ExpiringCertificate{
+		Namespace:       "prod",
+		SecretName:      "api-tls",
+		CertificateName: "api",
+		CommonName:      "api.example.com",
+		DNSNames:        []string{"api.example.com"},
+		NotAfter:        notAfter,
+		Ingresses:       []string{"api"},
+	}
+
+	steps := []struct {
+		name          string
+		now           time.Time
+		restart       bool
+		expThresholds []int
+	}{
+		{name: "First threshold crossed", now: now, expThresholds: []int{7}},
+		{name: "Same threshold is not reported again", now: now.Add(time.Hour), expThresholds: nil},
+		{name: "Same threshold is not reported after restart", now: now.Add(2 * time.Hour), restart: true, expThresholds: nil},
+		{name: "Next threshold crossed", now: notAfter.Add(-12 * time.Hour), expThresholds: []int{1}},
+		{name: "Expired", now: notAfter.Add(time.Minute), expThresholds: []int{0}},
+		{name: "Expired is reported once", now: notAfter.Add(time.Hour), expThresholds: nil},
+	}
+	for _, step := range steps {
+		if step.restart {
+			scanner = NewScanner(loggerx.NewNoop(), dynamicCli, cfg, "cert-expiry")
+		}
+		scanner.nowFn = func() time.Time { return step.now }
+
+		// when
+		certs, err := scanner.Scan(context.Background())
+
+		// then
+		require.NoError(t, err, step.name)
+		var gotThresholds []int
+		for _, cert := range certs {
+			gotThresholds = append(gotThresholds, cert.ThresholdDays)
+
+			exp := expCert
+			exp.ThresholdDays = cert.ThresholdDays
+			exp.DaysLeft = daysLeft(notAfter.Sub(step.now))
+			exp.Expired = !step.now.Before(notAfter)
+			assert.Equal(t, exp, cert, step.name)
+		}
+		assert.Equal(t, step.expThresholds, gotThresholds, step.name)
+	}
+}
+
+func TestScannerScanTracksStreamsSeparately(t *testing.T) {
+	// given
+	now := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+	notAfter := now.Add(5 * 24 * time.Hour)
+
+	dynamicCli := fake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, map[schema.GroupVersionResource]string{
+		certificateGVR: "CertificateList",
+	},
+		fixTLSSecret(t, "prod", "api-tls", notAfter),
+	)
+	cfg := Config{
+		ThresholdDays: []int{30, 7, 1},
+		State: State{
+			ConfigMap: config.K8sResourceRef{Name: "botkube-cert-expiry-state", Namespace: "botkube"},
+		},
+	}
+	interactive := NewScanner(loggerx.NewNoop(), dynamicCli, cfg, StateKey("cert-expiry", true))
+	interactive.nowFn = func() time.Time { return now }
+	plaintext := NewScanner(loggerx.NewNoop(), dynamicCli, cfg, StateKey("cert-expiry", false))
+	plaintext.nowFn = func() time.Time { return now.Add(time.Minute) }
+
+	// when
+	interactiveCerts, err := interactive.Scan(context.Background())
+	require.NoError(t, err)
+	plaintextCerts, err := plaintext.Scan(context.Background())
+	require.NoError(t, err)
+
+	// then
+	assert.Len(t, interactiveCerts, 1)
+	assert.Len(t, plaintextCerts, 1)
+}
+
+func TestScannerScanSkipsFailedLists(t *testing.T) {
+	// given
+	now := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+	notAfter := now.Add(5 * 24 * time.Hour)
+
+	dynamicCli := fake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, map[schema.GroupVersionResource]string{
+		certificateGVR: "CertificateList",
+	},
+		fixTLSSecret(t, "prod", "api-tls", notAfter),
+		fixIngress("prod", "api", "api-tls"),
+	)
+	for _, resource := range []string{"ingresses", "certificates"} {
+		dynamicCli.PrependReactor("list", resource, func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+	}
+
+	scanner := NewScanner(loggerx.NewNoop(), dynamicCli, Config{
+		ThresholdDays: []int{30, 7, 1},
+		CertManager:   CertManager{Enabled: true},
+	}, "cert-expiry")
+	scanner.nowFn = func() time.Time { return now }
+
+	// when
+	certs, err := scanner.Scan(context.Background())
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	assert.Equal(t, "api-tls", certs[0].SecretName)
+	assert.Empty(t, certs[0].Ingresses)
+}
+
+func TestScannerCrossedThreshold(t *testing.T) {
+	scanner := NewScanner(loggerx.NewNoop(), nil, Config{ThresholdDays: []int{30, 7, 1}}, "cert-expiry")
+
+	tests := []struct {
+		remaining    time.Duration
+		expThreshold int
+		expOK        bool
+	}{
+		{remaining: 40 * 24 * time.Hour, expOK: false},
+		{remaining: 30 * 24 * time.Hour, expThreshold: 30, expOK: true},
+		{remaining: 6 * 24 * time.Hour, expThreshold: 7, expOK: true},
+		{remaining: time.Hour, expThreshold: 1, expOK: true},
+		{remaining: -time.Hour, expThreshold: 0, expOK: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.remaining.String(), func(t *testing.T) {
+			threshold, ok := scanner.crossedThreshold(tc.remaining)
+			assert.Equal(t, tc.expOK, ok)
+			assert.Equal(t, tc.expThreshold, threshold)
+		})
+	}
+}
+
+func fixTLSSecret(t *testing.T, namespace, name string, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "api.example.com"},
+		DNSNames:     []string{"api.example.com"},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
+
+func fixIngress(namespace, name, secretName string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"api.example.com"}, SecretName: secretName},
+			},
+		},
+	}
+}
+
+func fixCertificate(namespace, name, secretName string, notAfter time.Time) runtime.Object {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"secretName": secretName,
+			},
+			"status": map[string]interface{}{
+				"notAfter": notAfter.Format(time.RFC3339),
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package certexpiry
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	pkgConfig "github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+var _ source.Source = (*Source)(nil)
+
+//go:embed config_schema.json
+var configJSONSchema string
+
+const (
+	// PluginName is the name of the certificate expiry Botkube plugin.
+	PluginName = "cert-expiry"
+
+	description = "Get warnings about TLS certificates in Kubernetes Secrets and cert-manager Certificates which are about to expire."
+)
+
+// Source certificate expiry source plugin data structure.
+type Source struct {
+	pluginVersion string
+
+	source.HandleExternalRequestUnimplemented
+}
+
+// NewSource returns a new instance of Source.
+func NewSource(version string) *Source {
+	return &Source{
+		pluginVersion: version,
+	}
+}
+
+// Metadata returns metadata of certificate expiry source.
+func (s *Source) Metadata(_ context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     s.pluginVersion,
+		Description: description,
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+	}, nil
+}
+
+// Stream periodically scans TLS Secrets and sends warnings for certificates which crossed the configured thresholds.
+func (s *Source) Stream(ctx context.Context, input source.StreamInput) (source.StreamOutput, error) {
+	kubeConfig := input.Context.KubeConfig
+	if err := plugin.ValidateKubeConfigProvided(PluginName, kubeConfig); err != nil {
+		return source.StreamOutput{}, err
+	}
+
+	cfg, err := MergeConfigs(input.Configs)
+	if err != nil {
+		return source.StreamOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return source.StreamOutput{}, fmt.Errorf("while reading kube config: %w", err)
+	}
+	dynamicCli, err := dynamic.NewForConfig(restCfg)
+	if err != nil {
+		return source.StreamOutput{}, fmt.Errorf("while creating dynamic K8s client: %w", err)
+	}
+
+	log := loggerx.NewStderr(pkgConfig.Logger{
+		Level: cfg.Log.Level,
+	}).WithField("source", input.Context.SourceName)
+
+	out := source.StreamOutput{
+		Event: make(chan source.Event),
+	}
+
+	scanner := NewScanner(log, dynamicCli, cfg, StateKey(input.Context.SourceName, input.Context.IsInteractivitySupported))
+	msgBuilder := NewMessageBuilder(input.Context.IsInteractivitySupported, input.Context.ClusterName)
+	go s.run(ctx, log, cfg.Interval, scanner, msgBuilder, out.Event)
+
+	return out, nil
+}
+
+func (s *Source) run(ctx context.Context, log logrus.FieldLogger, interval time.Duration, scanner *Scanner, msgBuilder *MessageBuilder, sink chan<- source.Event) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		log.Debug("Scanning TLS certificates...")
+		certs, err := scanner.Scan(ctx)
+		if err != nil {
+			log.Errorf("Failed to scan TLS certificates: %s", err)
+		}
+
+		for _, cert := range certs {
+			event := source.Event{
+				Message:   msgBuilder.FromCertificate(cert),
+				RawObject: cert,
+				AnalyticsLabels: map[string]interface{}{
+					"thresholdDays": cert.ThresholdDays,
+				},
+			}
+			select {
+			case sink <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package certexpiry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+var configMapGVR = schema.GroupVersionResource{
+	Version:  "v1",
+	Resource: "configmaps",
+}
+
+// invalidConfigMapKeyCharsRegex matches characters which are not allowed in ConfigMap data keys.
+var invalidConfigMapKeyCharsRegex = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+// reportedStore persists thresholds reported for certificates in a ConfigMap, so they are not reported again after restart.
+// Each source stream uses a separate ConfigMap data key.
+type reportedStore struct {
+	dynamicCli dynamic.Interface
+	ref        config.K8sResourceRef
+	key        string
+}
+
+// newReportedStore returns a new reportedStore instance. If the ConfigMap name is not specified, nil is returned
+// and the reported thresholds are not persisted.
+func newReportedStore(dynamicCli dynamic.Interface, ref config.K8sResourceRef, stateKey string) *reportedStore {
+	if ref.Name == "" {
+		return nil
+	}
+	key := invalidConfigMapKeyCharsRegex.ReplaceAllString(stateKey, "_")
+	if key == "" {
+		key = PluginName
+	}
+	return &reportedStore{
+		dynamicCli: dynamicCli,
+		ref:        ref,
+		key:        key,
+	}
+}
+
+// StateKey returns a state key for a given source stream. The source is started separately for interactive
+// and non-interactive notifiers, so each stream must track reported thresholds on its own.
+// Otherwise, the first stream that reports a certificate would suppress the warning for the other one.
+func StateKey(sourceName string, isInteractivitySupported bool) string {
+	if isInteractivitySupported {
+		return sourceName + ".interactive"
+	}
+	return sourceName + ".plaintext"
+}
+
+// Load returns the persisted thresholds. Empty map is returned if they weren't persisted yet.
+func (s *reportedStore) Load(ctx context.Context) (map[string]int, error) {
+	out := map[string]int{}
+	if s == nil {
+		return out, nil
+	}
+
+	cm, err := s.dynamicCli.Resource(configMapGVR).Namespace(s.ref.Namespace).Get(ctx, s.ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return out, nil
+		}
+		return nil, fmt.Errorf("while getting ConfigMap %s/%s: %w", s.ref.Namespace, s.ref.Name, err)
+	}
+
+	raw, _, _ := unstructured.NestedString(cm.Object, "data", s.key)
+	if raw == "" {
+		return out, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &out); err != nil {
+		return nil, fmt.Errorf("while unmarshaling %q key of ConfigMap %s/%s: %w", s.key, s.ref.Namespace, s.ref.Name, err)
+	}
+	return out, nil
+}
+
+// Save persists given thresholds. The ConfigMap is created if it doesn't exist.
+func (s *reportedStore) Save(ctx context.Context, reported map[string]int) error {
+	if s == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(reported)
+	if err != nil {
+		return fmt.Errorf("while marshaling reported thresholds: %w", err)
+	}
+	data := map[string]interface{}{
+		s.key: string(raw),
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": data,
+	})
+	if err != nil {
+		return fmt.Errorf("while marshaling patch: %w", err)
+	}
+
+	cli := s.dynamicCli.Resource(configMapGVR).Namespace(s.ref.Namespace)
+	_, err = cli.Patch(ctx, s.ref.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("while patching ConfigMap %s/%s: %w", s.ref.Namespace, s.ref.Name, err)
+	}
+
+	cm := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      s.ref.Name,
+				"namespace": s.ref.Namespace,
+			},
+			"data": data,
+		},
+	}
+	if _, err := cli.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("while creating ConfigMap %s/%s: %w", s.ref.Namespace, s.ref.Name, err)
+	}
+	return nil
+}
@@ -4,20 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"k8s.io/client-go/rest"
 
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/delivery"
+	"github.com/kubeshop/botkube/internal/history"
 	"github.com/kubeshop/botkube/pkg/action"
 	"github.com/kubeshop/botkube/pkg/api/source"
 	"github.com/kubeshop/botkube/pkg/bot"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/maputil"
 	"github.com/kubeshop/botkube/pkg/multierror"
 	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/plugin"
@@ -27,14 +33,22 @@ import (
 type Dispatcher struct {
 	log                  logrus.FieldLogger
 	manager              *plugin.Manager
+	transformerProvider  TransformerProvider
 	actionProvider       ActionProvider
 	reporter             AnalyticsReporter
 	auditReporter        audit.AuditReporter
-	markdownNotifiers    []notifier.Bot
-	interactiveNotifiers []notifier.Bot
-	sinkNotifiers        []notifier.Sink
+	markdownNotifiers    []*delivery.Queue
+	interactiveNotifiers []*delivery.Queue
+	sinkNotifiers        []*delivery.Queue
 	restCfg              *rest.Config
 	clusterName          string
+	router               *Router
+	history              *history.Store
+	restartPolicy        config.PluginRestartPolicy
+	healthStats          *plugin.HealthStats
+
+	streamsMu sync.Mutex
+	streams   map[string]context.CancelFunc
 }
 
 // ActionProvider defines a provider that is responsible for automated actions.
@@ -59,35 +73,70 @@ type AnalyticsReporter interface {
 }
 
 // NewDispatcher create a new Dispatcher instance.
-func NewDispatcher(log logrus.FieldLogger, clusterName string, notifiers map[string]bot.Bot, sinkNotifiers []notifier.Sink, manager *plugin.Manager, actionProvider ActionProvider, reporter AnalyticsReporter, auditReporter audit.AuditReporter, restCfg *rest.Config) *Dispatcher {
-	var (
-		interactiveNotifiers []notifier.Bot
-		markdownNotifiers    []notifier.Bot
-	)
-	for _, n := range notifiers {
+func NewDispatcher(log logrus.FieldLogger, clusterName string, notifiers map[string]bot.Bot, sinkNotifiers map[string]notifier.Sink, manager *plugin.Manager, actionProvider ActionProvider, reporter AnalyticsReporter, auditReporter audit.AuditReporter, restCfg *rest.Config, router *Router, eventHistory *history.Store, deliveryCfg config.Delivery, deliveryStore delivery.Store, restartPolicy config.PluginRestartPolicy, healthStats *plugin.HealthStats) *Dispatcher {
+	d := &Dispatcher{
+		log:                 log,
+		manager:             manager,
+		transformerProvider: manager,
+		actionProvider:      actionProvider,
+		reporter:            reporter,
+		auditReporter:       auditReporter,
+		restCfg:             restCfg,
+		clusterName:         clusterName,
+		router:              router,
+		history:             eventHistory,
+		restartPolicy:       restartPolicy,
+		healthStats:         healthStats,
+		streams:             map[string]context.CancelFunc{},
+	}
+
+	for _, key := range maputil.SortKeys(notifiers) {
+		n := notifiers[key]
+		queue := delivery.NewQueue(log, key, deliveryCfg, deliveryStore, d.deliverBotMessageFn(n), d.reportDeliveryFn(n))
 		if n.IntegrationName().IsInteractive() {
-			interactiveNotifiers = append(interactiveNotifiers, n)
+			d.interactiveNotifiers = append(d.interactiveNotifiers, queue)
 			continue
 		}
 
-		markdownNotifiers = append(markdownNotifiers, n)
+		d.markdownNotifiers = append(d.markdownNotifiers, queue)
 	}
 
-	return &Dispatcher{
-		log:                  log,
-		manager:              manager,
-		actionProvider:       actionProvider,
-		reporter:             reporter,
-		auditReporter:        auditReporter,
-		interactiveNotifiers: interactiveNotifiers,
-		markdownNotifiers:    markdownNotifiers,
-		sinkNotifiers:        sinkNotifiers,
-		restCfg:              restCfg,
-		clusterName:          clusterName,
+	for _, key := range maputil.SortKeys(sinkNotifiers) {
+		n := sinkNotifiers[key]
+		queue := delivery.NewQueue(log, key, deliveryCfg, deliveryStore, d.deliverSinkEventFn(n), d.reportDeliveryFn(n))
+		d.sinkNotifiers = append(d.sinkNotifiers, queue)
+	}
+
+	return d
+}
+
+// DeliveryQueues returns delivery queues for all notifiers.
+func (d *Dispatcher) DeliveryQueues() []*delivery.Queue {
+	var out []*delivery.Queue
+	out = append(out, d.interactiveNotifiers...)
+	out = append(out, d.markdownNotifiers...)
+	out = append(out, d.sinkNotifiers...)
+	return out
+}
+
+// Run processes notifier delivery queues until the context is canceled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, queue := range d.DeliveryQueues() {
+		wg.Add(1)
+		go func(queue *delivery.Queue) {
+			defer wg.Done()
+			defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
+			queue.Run(ctx)
+		}(queue)
 	}
+
+	wg.Wait()
+	return nil
 }
 
 // Dispatch starts a given plugin, watches for incoming events and calling all notifiers to dispatch received event.
+// The stream is supervised, so it's re-opened with backoff when the plugin closes it.
 func (d *Dispatcher) Dispatch(dispatch PluginDispatch) error {
 	log := d.log.WithFields(logrus.Fields{
 		"pluginName": dispatch.pluginName,
@@ -96,17 +145,51 @@ func (d *Dispatcher) Dispatch(dispatch PluginDispatch) error {
 
 	log.Info("Start source streaming...")
 
+	ctx, cancel := context.WithCancel(dispatch.ctx)
+	out, err := d.openStream(ctx, dispatch)
+	if err != nil {
+		cancel()
+		statusErr, ok := status.FromError(err)
+		if ok && statusErr.Code() == codes.Unimplemented {
+			log.Debugf("Source %q does not implement streaming. Returning without error...", dispatch.pluginName)
+			return nil
+		}
+
+		return err
+	}
+
+	// the plugin was restarted and scheduled again, so the previous supervisor is not needed anymore
+	d.replaceStream(streamKey(dispatch), cancel)
+
+	supervisor := newStreamSupervisor(log, dispatch.sourceName, streamKey(dispatch), d.restartPolicy, d.healthStats,
+		func(ctx context.Context) (chan source.Event, error) {
+			out, err := d.openStream(ctx, dispatch)
+			if err != nil {
+				return nil, err
+			}
+			return out.Event, nil
+		},
+		func(ctx context.Context, event source.Event) {
+			// dispatchMsg blocks when delivery queues are full, so we stop reading from the stream
+			// and the backpressure is propagated to the source plugin.
+			d.dispatchMsg(ctx, event, dispatch)
+		},
+	)
+	go supervisor.Run(ctx, out.Event)
+	return nil
+}
+
+func (d *Dispatcher) openStream(ctx context.Context, dispatch PluginDispatch) (source.StreamOutput, error) {
 	sourceClient, err := d.manager.GetSource(dispatch.pluginName)
 	if err != nil {
-		return fmt.Errorf("while getting source client for %s: %w", dispatch.pluginName, err)
+		return source.StreamOutput{}, fmt.Errorf("while getting source client for %s: %w", dispatch.pluginName, err)
 	}
 
 	kubeconfig, err := plugin.GenerateKubeConfig(d.restCfg, d.clusterName, dispatch.pluginContext, plugin.KubeConfigInput{})
 	if err != nil {
-		return fmt.Errorf("while generating kube config for %s: %w", dispatch.pluginName, err)
+		return source.StreamOutput{}, fmt.Errorf("while generating kube config for %s: %w", dispatch.pluginName, err)
 	}
 
-	ctx := dispatch.ctx
 	out, err := sourceClient.Stream(ctx, source.StreamInput{
 		Configs: []*source.Config{dispatch.pluginConfig},
 		Context: source.StreamInputContext{
@@ -115,31 +198,23 @@ func (d *Dispatcher) Dispatch(dispatch PluginDispatch) error {
 		},
 	})
 	if err != nil {
-		statusErr, ok := status.FromError(err)
-		if ok && statusErr.Code() == codes.Unimplemented {
-			log.Debugf("Source %q does not implement streaming. Returning without error...", dispatch.pluginName)
-			return nil
-		}
+		return source.StreamOutput{}, fmt.Errorf(`while opening stream for "%s.%s" source: %w`, dispatch.sourceName, dispatch.pluginName, err)
+	}
+	return out, nil
+}
 
-		return fmt.Errorf(`while opening stream for "%s.%s" source: %w`, dispatch.sourceName, dispatch.pluginName, err)
-	}
-
-	go func() {
-		for {
-			select {
-			case msg, ok := <-out.Event:
-				if !ok {
-					log.WithError(fmt.Errorf("stream for %s.%s source was closed", dispatch.sourceName, dispatch.pluginName)).Error("Stream closed")
-					return
-				}
-				log.WithField("message", msg).Debug("Dispatching received message...")
-				d.dispatchMsg(ctx, msg, dispatch)
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
-	return nil
+func (d *Dispatcher) replaceStream(key string, cancel context.CancelFunc) {
+	d.streamsMu.Lock()
+	defer d.streamsMu.Unlock()
+
+	if prev, found := d.streams[key]; found {
+		prev()
+	}
+	d.streams[key] = cancel
+}
+
+func streamKey(dispatch PluginDispatch) string {
+	return fmt.Sprintf("%s/%s/interactive/%v", dispatch.sourceName, dispatch.pluginName, dispatch.isInteractivitySupported)
 }
 
 // DispatchExternalRequest dispatches a single message for a given plugin.
@@ -163,85 +238,129 @@ func (d *Dispatcher) DispatchExternalRequest(dispatch ExternalRequestDispatch) e
 		return fmt.Errorf(`while handling external request for "%s.%s" source: %w`, dispatch.sourceName, dispatch.pluginName, err)
 	}
 
+	if out.Event.Message.IsEmpty() && out.Event.RawObject == nil {
+		d.log.Debugf("External request for %q source didn't produce any event. Skipping...", dispatch.sourceName)
+		return nil
+	}
+
 	d.dispatchMsg(ctx, out.Event, dispatch.PluginDispatch)
 
 	return nil
 }
 
-func (d *Dispatcher) getBotNotifiers(dispatch PluginDispatch) []notifier.Bot {
+// DispatchBuiltInEvent dispatches an event produced by a built-in source, such as scheduled commands.
+func (d *Dispatcher) DispatchBuiltInEvent(ctx context.Context, sourceName, sourceDisplayName, pluginName string, event source.Event) {
+	d.dispatchMsg(ctx, event, PluginDispatch{
+		ctx:               ctx,
+		pluginName:        pluginName,
+		sourceName:        sourceName,
+		sourceDisplayName: sourceDisplayName,
+		builtIn:           true,
+	})
+}
+
+func (d *Dispatcher) getBotNotifiers(dispatch PluginDispatch) []*delivery.Queue {
+	if dispatch.builtIn {
+		return d.getAllBotNotifiers()
+	}
 	if dispatch.isInteractivitySupported {
 		return d.interactiveNotifiers
 	}
 	return d.markdownNotifiers
 }
 
-func (d *Dispatcher) getSinkNotifiers(dispatch PluginDispatch) []notifier.Sink {
-	if dispatch.isInteractivitySupported {
-		return nil // we shouldn't forward interactive events
+func (d *Dispatcher) getAllBotNotifiers() []*delivery.Queue {
+	return append(append([]*delivery.Queue{}, d.interactiveNotifiers...), d.markdownNotifiers...)
+}
+
+func (d *Dispatcher) getSinkNotifiers(dispatch PluginDispatch) []*delivery.Queue {
+	if dispatch.isInteractivitySupported && !dispatch.builtIn && !dispatch.forwardToSinks {
+		return nil // sinks are served by the non-interactive stream for the same source
 	}
 	return d.sinkNotifiers
 }
 
+// sinkEvent returns an event representation which can be sent to sinks.
+func sinkEvent(event source.Event, dispatch PluginDispatch) any {
+	if !dispatch.forwardToSinks {
+		return event.RawObject
+	}
+
+	// interactive messages contain buttons and selects which are meaningless for sinks
+	return notifier.NewPlaintextEvent(event.RawObject, interactive.MessageToPlaintext(interactive.CoreMessage{Message: event.Message}, interactive.NewlineFormatter))
+}
+
 func (d *Dispatcher) dispatchMsg(ctx context.Context, event source.Event, dispatch PluginDispatch) {
+	event, ok := d.transformEvent(ctx, event, dispatch)
+	if !ok {
+		return
+	}
+
 	var (
 		pluginName = dispatch.pluginName
 		sources    = []string{dispatch.sourceName}
+		routes     = d.router.Resolve(RoutingInput{
+			SourceName: dispatch.sourceName,
+			PluginName: pluginName,
+			Event:      event.RawObject,
+		})
 	)
 
-	for _, n := range d.getBotNotifiers(dispatch) {
-		go func(n notifier.Bot) {
-			defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
-			msg := interactive.CoreMessage{
-				Message: event.Message,
-			}
-			err := n.SendMessage(ctx, msg, sources)
-			if err != nil {
-				reportErr := d.reportError(err, n, pluginName, event)
-				if reportErr != nil {
-					err = multierror.Append(err, fmt.Errorf("while reporting error: %w", reportErr))
-				}
-
-				d.log.Errorf("while sending bot message: %s", err.Error())
-				return
-			}
-
-			reportErr := d.reportSuccess(n, pluginName, event)
-			if reportErr != nil {
-				d.log.Error(err)
-			}
-		}(n)
+	if dispatch.recordHistory || dispatch.builtIn {
+		d.history.Record(history.Event{
+			SourceName:        dispatch.sourceName,
+			SourceDisplayName: dispatch.sourceDisplayName,
+			PluginName:        pluginName,
+			Message:           event.Message,
+			RawObject:         event.RawObject,
+		})
 	}
 
-	for _, n := range d.getSinkNotifiers(dispatch) {
-		go func(n notifier.Sink) {
-			defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
-			err := n.SendEvent(ctx, event.RawObject, sources)
-			if err != nil {
-				reportErr := d.reportError(err, n, pluginName, event)
-				if reportErr != nil {
-					err = multierror.Append(err, fmt.Errorf("while reporting error: %w", reportErr))
-				}
-
-				d.log.Errorf("while sending sink message: %s", err.Error())
-				return
-			}
+	// the same ID is used for all bot queues, so automated action results can be posted in the event message threads
+	eventItemID := uuid.New().String()
+	var items []queuedItem
+	for _, queue := range d.getBotNotifiers(dispatch) {
+		item := d.routeBotItem(queue, routes, delivery.Item{
+			ID: eventItemID,
+			Message: &interactive.CoreMessage{
+				Message: event.Message,
+			},
+			Sources:         sources,
+			PluginName:      pluginName,
+			AnalyticsLabels: event.AnalyticsLabels,
+		})
+		items = append(items, queuedItem{queue: queue, item: item})
+	}
 
-			reportErr := d.reportSuccess(n, pluginName, event)
-			if reportErr != nil {
-				d.log.Error(err)
-			}
-		}(n)
+	for _, queue := range d.getSinkNotifiers(dispatch) {
+		item, ok := d.routeSinkItem(queue, routes, delivery.Item{
+			Event:           sinkEvent(event, dispatch),
+			Sources:         sources,
+			PluginName:      pluginName,
+			AnalyticsLabels: event.AnalyticsLabels,
+		})
+		if !ok {
+			continue
+		}
+		items = append(items, queuedItem{queue: queue, item: item})
 	}
+	enqueue(ctx, items)
 
 	if err := d.reportAuditEvent(ctx, pluginName, event.RawObject, dispatch.sourceName, dispatch.sourceDisplayName); err != nil {
 		d.log.Errorf("while reporting audit event for source %q: %s", dispatch.sourceName, err.Error())
 	}
 
+	// a source can be streamed twice, for interactive and non-interactive platforms, so actions are executed only by the stream
+	// which records the history, and their results are sent to all bot notifiers
+	if !dispatch.recordHistory && !dispatch.builtIn {
+		return
+	}
+
 	// execute actions
 	actions, err := d.actionProvider.RenderedActions(event.RawObject, sources)
 	if err != nil {
+		// actions which were rendered successfully are still executed
 		d.log.Errorf("while rendering automated actions: %s", err.Error())
-		return
 	}
 	for _, act := range actions {
 		log := d.log.WithFields(logrus.Fields{
@@ -252,23 +371,200 @@ func (d *Dispatcher) dispatchMsg(ctx context.Context, event source.Event, dispat
 		genericMsg := d.actionProvider.ExecuteAction(ctx, act)
 		log.WithField("message", fmt.Sprintf("%+v", genericMsg)).Debug("Automated action executed. Printing output message...")
 
-		for _, n := range d.getBotNotifiers(dispatch) {
-			go func(n notifier.Bot) {
-				defer analytics.ReportPanicIfOccurs(d.log, d.reporter)
-				err := n.SendMessage(ctx, genericMsg, sources)
-				if err != nil {
-					d.log.Errorf("while sending action result to %q bot: %s", n.IntegrationName(), err.Error())
-				}
-			}(n)
+		// action results follow the same routes as the event which triggered them
+		// notifiers served by the other stream of the source don't know the event message, so they get the results as top-level messages
+		var results []queuedItem
+		for _, queue := range d.getAllBotNotifiers() {
+			msg := genericMsg
+			item := d.routeBotItem(queue, routes, delivery.Item{
+				Message:    &msg,
+				Sources:    sources,
+				ThreadOf:   eventItemID,
+				SkipReport: true,
+			})
+			results = append(results, queuedItem{queue: queue, item: item})
+		}
+
+		for _, queue := range d.getSinkNotifiers(dispatch) {
+			item, ok := d.routeSinkItem(queue, routes, delivery.Item{
+				Event:      genericMsg,
+				Sources:    sources,
+				SkipReport: true,
+			})
+			if !ok {
+				continue
+			}
+			results = append(results, queuedItem{queue: queue, item: item})
+		}
+		enqueue(ctx, results)
+	}
+}
+
+type queuedItem struct {
+	queue *delivery.Queue
+	item  delivery.Item
+}
+
+// enqueue adds given items to their queues concurrently, so a notifier with a full buffer doesn't delay the other ones.
+func enqueue(ctx context.Context, items []queuedItem) {
+	if len(items) == 1 {
+		items[0].queue.Enqueue(ctx, items[0].item)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, in := range items {
+		wg.Add(1)
+		go func(in queuedItem) {
+			defer wg.Done()
+			in.queue.Enqueue(ctx, in.item)
+		}(in)
+	}
+	wg.Wait()
+}
+
+// routeBotItem applies routing rules matched in the bot communication group.
+// If the matched rules don't target any channels, the item is delivered based on source bindings.
+func (d *Dispatcher) routeBotItem(queue *delivery.Queue, routes map[string]Route, item delivery.Item) delivery.Item {
+	route, found := routes[d.router.CommGroupForNotifier(queue.Key())]
+	if !found || len(route.Channels) == 0 {
+		item.OrderingKey = d.router.OrderingKey(queue.Key(), item.Sources, nil)
+		return item
+	}
+
+	item.Routed = true
+	item.Channels = route.Channels
+	item.OrderingKey = d.router.OrderingKey(queue.Key(), item.Sources, item.Channels)
+	return item
+}
+
+// routeSinkItem applies routing rules matched in the sink communication group.
+// If the matched rules don't target any sinks, the item is delivered based on source bindings.
+// It returns false if the item shouldn't be delivered to a given sink.
+func (d *Dispatcher) routeSinkItem(queue *delivery.Queue, routes map[string]Route, item delivery.Item) (delivery.Item, bool) {
+	group := d.router.CommGroupForNotifier(queue.Key())
+	route, found := routes[group]
+	if !found || len(route.Sinks) == 0 {
+		return item, true
+	}
+
+	for _, sink := range route.Sinks {
+		if NotifierKey(group, sink) != queue.Key() {
+			continue
+		}
+		item.Routed = true
+		return item, true
+	}
+	return item, false
+}
+
+func (d *Dispatcher) deliverBotMessageFn(n notifier.Bot) delivery.DeliverFn {
+	threads := newEventThreads()
+	return func(ctx context.Context, item *delivery.Item) error {
+		if item.Message == nil {
+			return nil
+		}
+
+		// if the parent message wasn't delivered or the platform doesn't support threads, fall back to a top-level message
+		if refs, found := threads.Get(item.ThreadOf); found {
+			if item.RetryChannels != nil {
+				refs = refsInChannels(refs, item.RetryChannels)
+			}
+			err := sendInThreads(ctx, n, *item.Message, refs)
+			return retryOnlyFailedChannels(item, err)
+		}
+
+		var (
+			refs []notifier.MessageRef
+			err  error
+		)
+		switch {
+		case item.RetryChannels != nil:
+			refs, err = n.SendMessageToChannels(ctx, *item.Message, item.RetryChannels)
+		case item.Routed:
+			refs, err = n.SendMessageToChannels(ctx, *item.Message, item.Channels)
+		default:
+			refs, err = n.SendMessage(ctx, *item.Message, item.Sources)
+		}
+		threads.Add(item.ID, refs)
+		return retryOnlyFailedChannels(item, err)
+	}
+}
+
+// retryOnlyFailedChannels updates a given item, so the next delivery attempt sends the message only to channels which failed temporarily.
+// If all failed channels failed permanently, it returns a permanent error, so the delivery isn't retried.
+// If it's unknown which channels failed, the whole message is retried.
+func retryOnlyFailedChannels(item *delivery.Item, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	channels, ok := notifier.FailedChannels(err)
+	if !ok {
+		return err
+	}
+	if len(channels) == 0 {
+		return notifier.NewPermanentError(err)
+	}
+
+	item.RetryChannels = channels
+	return err
+}
+
+func refsInChannels(refs []notifier.MessageRef, channels []string) []notifier.MessageRef {
+	var out []notifier.MessageRef
+	for _, ref := range refs {
+		if slices.Contains(channels, ref.Channel) {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
+
+// sendInThreads sends a given message as a reply to the referenced messages.
+func sendInThreads(ctx context.Context, n notifier.Bot, msg interactive.CoreMessage, refs []notifier.MessageRef) error {
+	errs := multierror.New()
+	for _, ref := range refs {
+		reply := msg
+		reply.ParentActivityID = ref.ID
+		if _, err := n.SendMessageToChannels(ctx, reply, []string{ref.Channel}); err != nil {
+			errs = multierror.Append(errs, notifier.NewChannelError(ref.Channel, fmt.Errorf("while sending reply to message %q in channel %q: %w", ref.ID, ref.Channel, err)))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (d *Dispatcher) deliverSinkEventFn(n notifier.Sink) delivery.DeliverFn {
+	return func(ctx context.Context, item *delivery.Item) error {
+		if item.Routed {
+			return n.SendRoutedEvent(ctx, item.Event, item.Sources)
+		}
+		return n.SendEvent(ctx, item.Event, item.Sources)
+	}
+}
+
+func (d *Dispatcher) reportDeliveryFn(n genericNotifier) delivery.ReportFn {
+	return func(item delivery.Item, err error) {
+		if item.SkipReport {
+			if err != nil {
+				d.log.Errorf("while sending action result to %q %s: %s", n.IntegrationName(), n.Type(), err.Error())
+			}
+			return
+		}
+
+		if err != nil {
+			reportErr := d.reportError(err, n, item.PluginName, item.AnalyticsLabels)
+			if reportErr != nil {
+				err = multierror.Append(err, fmt.Errorf("while reporting error: %w", reportErr))
+			}
+
+			d.log.Errorf("while sending %s message: %s", n.Type(), err.Error())
+			return
 		}
 
-		for _, n := range d.getSinkNotifiers(dispatch) {
-			go func(n notifier.Sink) {
-				err := n.SendEvent(ctx, genericMsg, sources)
-				if err != nil {
-					d.log.Errorf("while sending action result to %q sink: %s", n.IntegrationName(), err.Error())
-				}
-			}(n)
+		reportErr := d.reportSuccess(n, item.PluginName, item.AnalyticsLabels)
+		if reportErr != nil {
+			d.log.Error(reportErr)
 		}
 	}
 }
@@ -296,13 +592,13 @@ type genericNotifier interface {
 	Type() config.IntegrationType
 }
 
-func (d *Dispatcher) reportSuccess(n genericNotifier, pluginName string, event source.Event) error {
+func (d *Dispatcher) reportSuccess(n genericNotifier, pluginName string, analyticsLabels map[string]interface{}) error {
 	errs := multierror.New()
 	reportErr := d.reporter.ReportHandledEventSuccess(analytics.ReportEventInput{
 		IntegrationType:       n.Type(),
 		Platform:              n.IntegrationName(),
 		PluginName:            pluginName,
-		AnonymizedEventFields: event.AnalyticsLabels,
+		AnonymizedEventFields: analyticsLabels,
 	})
 	if reportErr != nil {
 		errs = multierror.Append(errs, fmt.Errorf("while reporting %s analytics: %w", n.Type(), reportErr))
@@ -310,13 +606,13 @@ func (d *Dispatcher) reportSuccess(n genericNotifier, pluginName string, event s
 	return errs.ErrorOrNil()
 }
 
-func (d *Dispatcher) reportError(err error, n genericNotifier, pluginName string, event source.Event) error {
+func (d *Dispatcher) reportError(err error, n genericNotifier, pluginName string, analyticsLabels map[string]interface{}) error {
 	errs := multierror.New()
 	reportErr := d.reporter.ReportHandledEventError(analytics.ReportEventInput{
 		IntegrationType:       n.Type(),
 		Platform:              n.IntegrationName(),
 		PluginName:            pluginName,
-		AnonymizedEventFields: event.AnalyticsLabels,
+		AnonymizedEventFields: analyticsLabels,
 	}, err)
 	if reportErr != nil {
 		errs = multierror.Append(errs, fmt.Errorf("while reporting %s analytics: %w", n.Type(), reportErr))
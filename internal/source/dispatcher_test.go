@@ -0,0 +1,111 @@
+package source
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/delivery"
+	"github.com/kubeshop/botkube/internal/history"
+	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestDispatcherExecutesActionsInSingleStream(t *testing.T) {
+	// given
+	var (
+		mu        sync.Mutex
+		delivered = map[string][]string{}
+		wg        sync.WaitGroup
+	)
+	// each notifier gets the event from its own stream and a single action result
+	wg.Add(4)
+	deliverFn := func(name string) delivery.DeliverFn {
+		return func(_ context.Context, item *delivery.Item) error {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			delivered[name] = append(delivered[name], item.Message.BaseBody.Plaintext)
+			return nil
+		}
+	}
+	cfg := config.Delivery{BufferSize: 10}
+	interactiveQueue := delivery.NewQueue(loggerx.NewNoop(), "slack", cfg, nil, deliverFn("slack"), nil)
+	markdownQueue := delivery.NewQueue(loggerx.NewNoop(), "mattermost", cfg, nil, deliverFn("mattermost"), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go interactiveQueue.Run(ctx)
+	go markdownQueue.Run(ctx)
+
+	actions := &fakeActionProvider{}
+	d := &Dispatcher{
+		log:                  loggerx.NewNoop(),
+		actionProvider:       actions,
+		auditReporter:        &audit.NoopAuditReporter{},
+		history:              history.NewStore(10),
+		interactiveNotifiers: []*delivery.Queue{interactiveQueue},
+		markdownNotifiers:    []*delivery.Queue{markdownQueue},
+	}
+
+	// when
+	d.dispatchMsg(ctx, fixPlaintextEvent("interactive event"), PluginDispatch{sourceName: "k8s", isInteractivitySupported: true})
+	d.dispatchMsg(ctx, fixPlaintextEvent("plaintext event"), PluginDispatch{sourceName: "k8s", recordHistory: true})
+
+	// then
+	waitCh := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(waitCh)
+	}()
+	select {
+	case <-waitCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("messages were not delivered")
+	}
+
+	assert.Equal(t, 1, actions.executed)
+	mu.Lock()
+	defer mu.Unlock()
+	for _, msgs := range delivered {
+		sort.Strings(msgs)
+	}
+	assert.Equal(t, map[string][]string{
+		"slack":      {"action result", "interactive event"},
+		"mattermost": {"action result", "plaintext event"},
+	}, delivered)
+}
+
+func fixPlaintextEvent(text string) source.Event {
+	return source.Event{
+		Message: api.Message{
+			BaseBody: api.Body{Plaintext: text},
+		},
+		RawObject: text,
+	}
+}
+
+type fakeActionProvider struct {
+	mu       sync.Mutex
+	executed int
+}
+
+func (f *fakeActionProvider) RenderedActions(any, []string) ([]action.Action, error) {
+	return []action.Action{{Name: "describe", DisplayName: "Describe"}}, nil
+}
+
+func (f *fakeActionProvider) ExecuteAction(context.Context, action.Action) interactive.CoreMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.executed++
+	return *fixPlaintextMsg("action result")
+}
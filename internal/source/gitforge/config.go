@@ -0,0 +1,78 @@
+package gitforge
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// Config holds the Git forge source configuration.
+type Config struct {
+	// Events defines reported event types. If not specified, all supported events are reported.
+	Events []EventType `yaml:"events"`
+	// Repositories holds constraints for the full repository name, e.g. 'kubeshop/botkube'. If not specified, all repositories are matched.
+	Repositories *config.RegexConstraints `yaml:"repositories"`
+	// Branches holds constraints for the branch name. It's not checked for events without branch, e.g. tag pushes.
+	Branches *config.RegexConstraints `yaml:"branches"`
+	// DeploymentAnnotation is the key of the 'key: namespace/name' lines in pull request descriptions, release notes and commit messages.
+	// For each referenced Deployment, a button which checks its rollout status is added. References with invalid namespace or name are ignored.
+	DeploymentAnnotation string `yaml:"deploymentAnnotation"`
+	// Buttons defines additional buttons with Botkube commands.
+	Buttons []Button `yaml:"buttons"`
+}
+
+// Button defines a button with a Botkube command.
+type Button struct {
+	// DisplayName is the button name.
+	DisplayName string `yaml:"displayName"`
+	// CommandTpl is a Go template with the Event fields, e.g. 'kubectl get deploy -l app={{ .Repository | base }}'.
+	// The fields are provided by the Git forge users, so characters other than letters, digits and `._/:@+=-` are replaced with '-'.
+	CommandTpl string `yaml:"commandTpl"`
+	// Events defines event types for which the button is displayed. If not specified, it's displayed for all events.
+	Events []EventType `yaml:"events"`
+}
+
+var defaultConfig = Config{
+	DeploymentAnnotation: "botkube.io/deployment",
+}
+
+// MergeConfigs merges all input configuration.
+func MergeConfigs(configs []*source.Config) (Config, error) {
+	var out Config
+	if err := plugin.MergeSourceConfigsWithDefaults(defaultConfig, configs, &out); err != nil {
+		return Config{}, err
+	}
+	return out, nil
+}
+
+// Matches returns true if a given event passes the event type, repository and branch filters.
+func (c Config) Matches(event Event) (bool, error) {
+	if len(c.Events) > 0 && !slices.Contains(c.Events, event.Type) {
+		return false, nil
+	}
+
+	matched, err := isAllowed(c.Repositories, event.Repository)
+	if err != nil {
+		return false, fmt.Errorf("while matching repositories: %w", err)
+	}
+	if !matched || event.Branch == "" {
+		return matched, nil
+	}
+
+	matched, err = isAllowed(c.Branches, event.Branch)
+	if err != nil {
+		return false, fmt.Errorf("while matching branches: %w", err)
+	}
+	return matched, nil
+}
+
+func isAllowed(constraints *config.RegexConstraints, value string) (bool, error) {
+	if constraints == nil || !constraints.AreConstraintsDefined() {
+		return true, nil
+	}
+	return constraints.IsAllowed(value)
+}
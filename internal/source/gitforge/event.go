@@ -0,0 +1,362 @@
+package gitforge
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Provider defines the Git forge which sent the webhook.
+type Provider string
+
+const (
+	// GitHubProvider defines GitHub webhooks.
+	GitHubProvider Provider = "GitHub"
+	// GitLabProvider defines GitLab webhooks.
+	GitLabProvider Provider = "GitLab"
+)
+
+// EventType defines the normalized type of Git forge event.
+type EventType string
+
+const (
+	// PushEvent is reported for branch and tag pushes.
+	PushEvent EventType = "push"
+	// PullRequestEvent is reported for GitHub pull requests and GitLab merge requests.
+	PullRequestEvent EventType = "pull_request"
+	// ReleaseEvent is reported for published releases.
+	ReleaseEvent EventType = "release"
+	// PipelineEvent is reported for finished GitHub workflow runs and GitLab pipelines.
+	PipelineEvent EventType = "pipeline"
+)
+
+// Event holds the normalized Git forge event. It is used in button templates, routing rules and sent to sinks.
+type Event struct {
+	Provider   Provider  `json:"provider"`
+	Type       EventType `json:"type"`
+	Action     string    `json:"action,omitempty"`
+	Repository string    `json:"repository"`
+	Branch     string    `json:"branch,omitempty"`
+	Tag        string    `json:"tag,omitempty"`
+	Author     string    `json:"author,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	Body       string    `json:"body,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Number     int       `json:"number,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Commits    []Commit  `json:"commits,omitempty"`
+}
+
+// Commit holds details about a pushed commit.
+type Commit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
+
+// ParseEvent detects the provider and type of a given webhook payload and returns the normalized event.
+// It returns false if the payload describes an event which is not reported, e.g. GitHub ping or a pull request label change.
+func ParseEvent(payload []byte) (Event, bool, error) {
+	var probe struct {
+		ObjectKind string `json:"object_kind"`
+	}
+	if err := json.Unmarshal(payload, &probe); err != nil {
+		return Event{}, false, fmt.Errorf("while unmarshaling payload: %w", err)
+	}
+
+	if probe.ObjectKind != "" {
+		return parseGitLabEvent(probe.ObjectKind, payload)
+	}
+	return parseGitHubEvent(payload)
+}
+
+type (
+	githubRepository struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	}
+	githubUser struct {
+		Login string `json:"login"`
+	}
+	githubCommit struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+	}
+	githubPayload struct {
+		Action     string           `json:"action"`
+		Ref        string           `json:"ref"`
+		Compare    string           `json:"compare"`
+		Deleted    bool             `json:"deleted"`
+		Commits    []githubCommit   `json:"commits"`
+		Repository githubRepository `json:"repository"`
+		Sender     githubUser       `json:"sender"`
+		Pusher     *struct {
+			Name string `json:"name"`
+		} `json:"pusher"`
+		PullRequest *struct {
+			Number  int    `json:"number"`
+			Title   string `json:"title"`
+			Body    string `json:"body"`
+			HTMLURL string `json:"html_url"`
+			Merged  bool   `json:"merged"`
+			Base    struct {
+				Ref string `json:"ref"`
+			} `json:"base"`
+		} `json:"pull_request"`
+		Release *struct {
+			TagName         string `json:"tag_name"`
+			Name            string `json:"name"`
+			Body            string `json:"body"`
+			HTMLURL         string `json:"html_url"`
+			TargetCommitish string `json:"target_commitish"`
+		} `json:"release"`
+		WorkflowRun *struct {
+			Name       string `json:"name"`
+			Conclusion string `json:"conclusion"`
+			HeadBranch string `json:"head_branch"`
+			HTMLURL    string `json:"html_url"`
+			HeadCommit *struct {
+				Message string `json:"message"`
+			} `json:"head_commit"`
+		} `json:"workflow_run"`
+	}
+)
+
+func parseGitHubEvent(payload []byte) (Event, bool, error) {
+	var in githubPayload
+	if err := json.Unmarshal(payload, &in); err != nil {
+		return Event{}, false, fmt.Errorf("while unmarshaling GitHub payload: %w", err)
+	}
+
+	out := Event{
+		Provider:   GitHubProvider,
+		Repository: in.Repository.FullName,
+		Author:     in.Sender.Login,
+	}
+
+	switch {
+	case in.PullRequest != nil:
+		action, ok := normalizePullRequestAction(in.Action, in.PullRequest.Merged)
+		if !ok {
+			return Event{}, false, nil
+		}
+		out.Type = PullRequestEvent
+		out.Action = action
+		out.Number = in.PullRequest.Number
+		out.Title = in.PullRequest.Title
+		out.Body = in.PullRequest.Body
+		out.URL = in.PullRequest.HTMLURL
+		out.Branch = in.PullRequest.Base.Ref
+	case in.Release != nil:
+		if in.Action != "published" {
+			return Event{}, false, nil
+		}
+		out.Type = ReleaseEvent
+		out.Action = in.Action
+		out.Tag = in.Release.TagName
+		out.Title = in.Release.Name
+		out.Body = in.Release.Body
+		out.URL = in.Release.HTMLURL
+		out.Branch = in.Release.TargetCommitish
+	case in.WorkflowRun != nil:
+		status := normalizePipelineStatus(in.WorkflowRun.Conclusion)
+		if in.Action != "completed" || status == "" {
+			return Event{}, false, nil
+		}
+		out.Type = PipelineEvent
+		out.Action = in.Action
+		out.Status = status
+		out.Title = in.WorkflowRun.Name
+		out.URL = in.WorkflowRun.HTMLURL
+		out.Branch = in.WorkflowRun.HeadBranch
+		if in.WorkflowRun.HeadCommit != nil {
+			out.Body = in.WorkflowRun.HeadCommit.Message
+		}
+	case in.Ref != "" && in.Pusher != nil:
+		if in.Deleted {
+			return Event{}, false, nil
+		}
+		out.Type = PushEvent
+		out.Branch, out.Tag = splitRef(in.Ref)
+		out.URL = in.Compare
+		out.Author = in.Pusher.Name
+		for _, c := range in.Commits {
+			out.Commits = append(out.Commits, Commit(c))
+		}
+	default:
+		return Event{}, false, nil
+	}
+
+	if out.URL == "" {
+		out.URL = in.Repository.HTMLURL
+	}
+	return out, true, nil
+}
+
+type (
+	gitlabProject struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		WebURL            string `json:"web_url"`
+	}
+	gitlabPayload struct {
+		ObjectKind   string        `json:"object_kind"`
+		Ref          string        `json:"ref"`
+		After        string        `json:"after"`
+		UserUsername string        `json:"user_username"`
+		Project      gitlabProject `json:"project"`
+		Commits      []struct {
+			ID      string `json:"id"`
+			Message string `json:"message"`
+			URL     string `json:"url"`
+		} `json:"commits"`
+		User *struct {
+			Username string `json:"username"`
+		} `json:"user"`
+		ObjectAttributes *struct {
+			ID           int    `json:"id"`
+			IID          int    `json:"iid"`
+			Title        string `json:"title"`
+			Description  string `json:"description"`
+			URL          string `json:"url"`
+			Action       string `json:"action"`
+			TargetBranch string `json:"target_branch"`
+			Ref          string `json:"ref"`
+			Tag          bool   `json:"tag"`
+			Status       string `json:"status"`
+			Name         string `json:"name"`
+		} `json:"object_attributes"`
+		Commit *struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+
+		// release events
+		Action      string `json:"action"`
+		Name        string `json:"name"`
+		Tag         string `json:"tag"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+	}
+)
+
+// gitlabZeroSHA is sent as the 'after' commit when a branch or tag is deleted.
+const gitlabZeroSHA = "0000000000000000000000000000000000000000"
+
+func parseGitLabEvent(kind string, payload []byte) (Event, bool, error) {
+	var in gitlabPayload
+	if err := json.Unmarshal(payload, &in); err != nil {
+		return Event{}, false, fmt.Errorf("while unmarshaling GitLab payload: %w", err)
+	}
+
+	out := Event{
+		Provider:   GitLabProvider,
+		Repository: in.Project.PathWithNamespace,
+		URL:        in.Project.WebURL,
+	}
+	if in.User != nil {
+		out.Author = in.User.Username
+	}
+
+	attrs := in.ObjectAttributes
+	switch kind {
+	case "push", "tag_push":
+		if in.After == gitlabZeroSHA {
+			return Event{}, false, nil
+		}
+		out.Type = PushEvent
+		out.Author = in.UserUsername
+		out.Branch, out.Tag = splitRef(in.Ref)
+		for _, c := range in.Commits {
+			out.Commits = append(out.Commits, Commit(c))
+		}
+	case "merge_request":
+		if attrs == nil {
+			return Event{}, false, nil
+		}
+		action, ok := normalizePullRequestAction(attrs.Action, attrs.Action == "merge")
+		if !ok {
+			return Event{}, false, nil
+		}
+		out.Type = PullRequestEvent
+		out.Action = action
+		out.Number = attrs.IID
+		out.Title = attrs.Title
+		out.Body = attrs.Description
+		out.URL = attrs.URL
+		out.Branch = attrs.TargetBranch
+	case "release":
+		if in.Action != "create" {
+			return Event{}, false, nil
+		}
+		out.Type = ReleaseEvent
+		out.Action = "published"
+		out.Tag = in.Tag
+		out.Title = in.Name
+		out.Body = in.Description
+		out.URL = in.URL
+	case "pipeline":
+		if attrs == nil {
+			return Event{}, false, nil
+		}
+		status := normalizePipelineStatus(attrs.Status)
+		if status == "" {
+			return Event{}, false, nil
+		}
+		out.Type = PipelineEvent
+		out.Action = "completed"
+		out.Status = status
+		out.Title = attrs.Name
+		if attrs.Tag {
+			out.Tag = attrs.Ref
+		} else {
+			out.Branch = attrs.Ref
+		}
+		out.URL = fmt.Sprintf("%s/-/pipelines/%d", in.Project.WebURL, attrs.ID)
+		if in.Commit != nil {
+			out.Body = in.Commit.Message
+		}
+	default:
+		return Event{}, false, nil
+	}
+
+	return out, true, nil
+}
+
+// normalizePullRequestAction returns opened, reopened, closed or merged. Other actions, e.g. labeling, are not reported.
+func normalizePullRequestAction(action string, merged bool) (string, bool) {
+	switch action {
+	case "opened", "open":
+		return "opened", true
+	case "reopened", "reopen":
+		return "reopened", true
+	case "closed", "close", "merge":
+		if merged {
+			return "merged", true
+		}
+		return "closed", true
+	}
+	return "", false
+}
+
+// normalizePipelineStatus returns succeeded, failed or canceled. It returns an empty string for pipelines which are still running or were skipped.
+func normalizePipelineStatus(status string) string {
+	switch status {
+	case "success":
+		return "succeeded"
+	case "failure", "failed", "timed_out", "startup_failure":
+		return "failed"
+	case "cancelled", "canceled":
+		return "canceled"
+	}
+	return ""
+}
+
+func splitRef(ref string) (branch, tag string) {
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		return strings.TrimPrefix(ref, "refs/heads/"), ""
+	case strings.HasPrefix(ref, "refs/tags/"):
+		return "", strings.TrimPrefix(ref, "refs/tags/")
+	}
+	return ref, ""
+}
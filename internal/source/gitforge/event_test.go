@@ -0,0 +1,119 @@
+package gitforge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expEvent Event
+		expOK    bool
+	}{
+		{
+			name: "GitHub push",
+			payload: `{
+			  "ref": "refs/heads/main",
+			  "compare": "https://github.com/org/api/compare/a...b",
+			  "pusher": {"name": "jane"},
+			  "sender": {"login": "jane"},
+			  "repository": {"full_name": "org/api", "html_url": "https://github.com/org/api"},
+			  "commits": [{"id": "1234567890", "message": "Fix bug\n\nbotkube.io/deployment: prod/api", "url": "https://github.com/org/api/commit/1234567890"}]
+			}`,
+			expEvent: Event{
+				Provider:   GitHubProvider,
+				Type:       PushEvent,
+				Repository: "org/api",
+				Branch:     "main",
+				Author:     "jane",
+				URL:        "https://github.com/org/api/compare/a...b",
+				Commits: []Commit{
+					{ID: "1234567890", Message: "Fix bug\n\nbotkube.io/deployment: prod/api", URL: "https://github.com/org/api/commit/1234567890"},
+				},
+			},
+			expOK: true,
+		},
+		{
+			name: "GitHub merged pull request",
+			payload: `{
+			  "action": "closed",
+			  "sender": {"login": "jane"},
+			  "repository": {"full_name": "org/api"},
+			  "pull_request": {"number": 12, "title": "Add feature", "html_url": "https://github.com/org/api/pull/12", "merged": true, "base": {"ref": "main"}}
+			}`,
+			expEvent: Event{
+				Provider:   GitHubProvider,
+				Type:       PullRequestEvent,
+				Action:     "merged",
+				Repository: "org/api",
+				Branch:     "main",
+				Author:     "jane",
+				Title:      "Add feature",
+				URL:        "https://github.com/org/api/pull/12",
+				Number:     12,
+			},
+			expOK: true,
+		},
+		{
+			name: "GitHub labeled pull request",
+			payload: `{
+			  "action": "labeled",
+			  "repository": {"full_name": "org/api"},
+			  "pull_request": {"number": 12}
+			}`,
+			expOK: false,
+		},
+		{
+			name:    "GitHub ping",
+			payload: `{"zen": "Keep it logically awesome.", "hook_id": 1, "repository": {"full_name": "org/api"}}`,
+			expOK:   false,
+		},
+		{
+			name: "GitLab failed pipeline",
+			payload: `{
+			  "object_kind": "pipeline",
+			  "user": {"username": "john"},
+			  "project": {"path_with_namespace": "group/api", "web_url": "https://gitlab.com/group/api"},
+			  "object_attributes": {"id": 42, "ref": "main", "status": "failed", "name": "Build"},
+			  "commit": {"message": "Update deps"}
+			}`,
+			expEvent: Event{
+				Provider:   GitLabProvider,
+				Type:       PipelineEvent,
+				Action:     "completed",
+				Repository: "group/api",
+				Branch:     "main",
+				Author:     "john",
+				Title:      "Build",
+				Body:       "Update deps",
+				URL:        "https://gitlab.com/group/api/-/pipelines/42",
+				Status:     "failed",
+			},
+			expOK: true,
+		},
+		{
+			name: "GitLab running pipeline",
+			payload: `{
+			  "object_kind": "pipeline",
+			  "project": {"path_with_namespace": "group/api"},
+			  "object_attributes": {"id": 42, "ref": "main", "status": "running"}
+			}`,
+			expOK: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			event, ok, err := ParseEvent([]byte(tc.payload))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expOK, ok)
+			assert.Equal(t, tc.expEvent, event)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package gitforge
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	multierrx "github.com/kubeshop/botkube/pkg/multierror"
+)
+
+const (
+	// maxCommits limits the number of commits listed for a single push.
+	maxCommits = 5
+	// maxRolloutButtons limits the number of buttons for Deployments referenced in a single event.
+	maxRolloutButtons = 5
+)
+
+// unsafeCommandCharsRegex matches characters which are replaced in event fields rendered into button commands.
+var unsafeCommandCharsRegex = regexp.MustCompile(`[^a-zA-Z0-9._/:@+=-]+`)
+
+var emojiForType = map[EventType]string{
+	PushEvent:        "⬆️",
+	PullRequestEvent: "🔀",
+	ReleaseEvent:     "🏷️",
+}
+
+var emojiForPipelineStatus = map[string]string{
+	"succeeded": "✅",
+	"failed":    "❌",
+	"canceled":  "⚪",
+}
+
+// MessageBuilder builds messages for Git forge events.
+type MessageBuilder struct {
+	isInteractivitySupported bool
+	cfg                      Config
+}
+
+// NewMessageBuilder returns a new MessageBuilder instance.
+func NewMessageBuilder(isInteractivitySupported bool, cfg Config) *MessageBuilder {
+	return &MessageBuilder{
+		isInteractivitySupported: isInteractivitySupported,
+		cfg:                      cfg,
+	}
+}
+
+// FromEvent returns a message for a given event. Errors are returned for invalid button templates, together with the message without those buttons.
+func (m *MessageBuilder) FromEvent(event Event) (api.Message, error) {
+	section := api.Section{
+		Base: api.Base{
+			Header:      m.header(event),
+			Description: firstLine(event.Title),
+		},
+	}
+
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Repository", event.Repository)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Branch", event.Branch)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Tag", event.Tag)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Author", event.Author)
+
+	var commits []string
+	for idx, c := range event.Commits {
+		if idx >= maxCommits {
+			commits = append(commits, fmt.Sprintf("and %d more", len(event.Commits)-maxCommits))
+			break
+		}
+		commits = append(commits, fmt.Sprintf("%s %s", shortSHA(c.ID), firstLine(c.Message)))
+	}
+	if len(commits) > 0 {
+		section.BulletLists = append(section.BulletLists, api.BulletList{
+			Title: "Commits",
+			Items: commits,
+		})
+	}
+
+	msg := api.Message{
+		Sections: []api.Section{section},
+	}
+
+	if !m.isInteractivitySupported {
+		msg.Type = api.NonInteractiveSingleSection
+		return msg, nil
+	}
+
+	btns, err := m.buttons(event)
+	if len(btns) > 0 {
+		msg.Sections = append(msg.Sections, api.Section{
+			Buttons: btns,
+		})
+	}
+	return msg, err
+}
+
+func (m *MessageBuilder) header(event Event) string {
+	switch event.Type {
+	case PushEvent:
+		if event.Tag != "" {
+			return fmt.Sprintf("%s %s pushed tag %s to %s", emojiForType[event.Type], event.Author, event.Tag, event.Repository)
+		}
+		return fmt.Sprintf("%s %s pushed %d commit(s) to %s@%s", emojiForType[event.Type], event.Author, len(event.Commits), event.Repository, event.Branch)
+	case PullRequestEvent:
+		kind := "Pull request"
+		if event.Provider == GitLabProvider {
+			kind = "Merge request"
+		}
+		return fmt.Sprintf("%s %s #%d %s in %s", emojiForType[event.Type], kind, event.Number, event.Action, event.Repository)
+	case ReleaseEvent:
+		return fmt.Sprintf("%s Release %s published in %s", emojiForType[event.Type], event.Tag, event.Repository)
+	case PipelineEvent:
+		ref := event.Branch
+		if ref == "" {
+			ref = event.Tag
+		}
+		return fmt.Sprintf("%s Pipeline %q %s in %s@%s", emojiForPipelineStatus[event.Status], event.Title, event.Status, event.Repository, ref)
+	}
+	return fmt.Sprintf("%s %s event in %s", event.Provider, event.Type, event.Repository)
+}
+
+func (m *MessageBuilder) buttons(event Event) (api.Buttons, error) {
+	btnBuilder := api.NewMessageButtonBuilder()
+
+	var btns api.Buttons
+	if event.URL != "" {
+		btns = append(btns, btnBuilder.ForURL(fmt.Sprintf("Open in %s", event.Provider), event.URL))
+	}
+
+	for _, deploy := range m.referencedDeployments(event) {
+		cmd := fmt.Sprintf("kubectl rollout status deployment/%s --watch=false", deploy.name)
+		if deploy.namespace != "" {
+			cmd = fmt.Sprintf("%s -n %s", cmd, deploy.namespace)
+		}
+		btns = append(btns, btnBuilder.ForCommandWithoutDesc(fmt.Sprintf("Rollout status %s", deploy.name), cmd, api.ButtonStylePrimary))
+	}
+
+	issues := multierrx.New()
+	for idx, btn := range m.cfg.Buttons {
+		if len(btn.Events) > 0 && !slices.Contains(btn.Events, event.Type) {
+			continue
+		}
+
+		cmd, err := renderCommand(btn, event)
+		if err != nil {
+			issues = multierrx.Append(issues, fmt.Errorf("invalid buttons[%d].commandTpl: %s", idx, err))
+			continue
+		}
+		btns = append(btns, btnBuilder.ForCommandWithoutDesc(btn.DisplayName, cmd))
+	}
+
+	return btns, issues.ErrorOrNil()
+}
+
+type deploymentRef struct {
+	namespace string
+	name      string
+}
+
+func (r deploymentRef) isValid() bool {
+	if r.namespace != "" && len(validation.IsDNS1123Label(r.namespace)) > 0 {
+		return false
+	}
+	return len(validation.IsDNS1123Subdomain(r.name)) == 0
+}
+
+// referencedDeployments returns Deployments referenced with the configured annotation, e.g. 'botkube.io/deployment: prod/api, prod/worker'.
+func (m *MessageBuilder) referencedDeployments(event Event) []deploymentRef {
+	if m.cfg.DeploymentAnnotation == "" {
+		return nil
+	}
+
+	texts := []string{event.Body}
+	for _, c := range event.Commits {
+		texts = append(texts, c.Message)
+	}
+
+	annotationRegex := regexp.MustCompile(fmt.Sprintf(`(?m)^\s*%s\s*[:=]\s*(.+?)\s*$`, regexp.QuoteMeta(m.cfg.DeploymentAnnotation)))
+
+	var (
+		out  []deploymentRef
+		seen = map[deploymentRef]struct{}{}
+	)
+	for _, text := range texts {
+		for _, match := range annotationRegex.FindAllStringSubmatch(text, -1) {
+			for _, item := range strings.Split(match[1], ",") {
+				item = strings.TrimSpace(item)
+				if item == "" {
+					continue
+				}
+
+				var ref deploymentRef
+				if ns, name, found := strings.Cut(item, "/"); found {
+					ref = deploymentRef{namespace: ns, name: name}
+				} else {
+					ref = deploymentRef{name: item}
+				}
+				// the values come from the Git forge users and are rendered into kubectl commands, so only valid names are accepted
+				if !ref.isValid() {
+					continue
+				}
+				if _, found := seen[ref]; found || len(out) >= maxRolloutButtons {
+					continue
+				}
+				seen[ref] = struct{}{}
+				out = append(out, ref)
+			}
+		}
+	}
+	return out
+}
+
+// renderCommand renders the button command for a given event. The event fields are provided by the Git forge users,
+// e.g. pull request title, so they are sanitized to not inject additional arguments, flags or filters into the command.
+func renderCommand(btn Button, event Event) (string, error) {
+	tmpl, err := template.New(btn.DisplayName).Funcs(sprig.FuncMap()).Parse(btn.CommandTpl)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, sanitizedForCommand(event)); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// sanitizedForCommand returns a copy of a given event with characters other than letters, digits and `._/:@+=-`
+// replaced with '-' in all text fields.
+func sanitizedForCommand(event Event) Event {
+	out := event
+	for _, field := range []*string{&out.Action, &out.Repository, &out.Branch, &out.Tag, &out.Author, &out.Title, &out.Body, &out.URL, &out.Status} {
+		*field = sanitizeCommandValue(*field)
+	}
+
+	out.Commits = make([]Commit, 0, len(event.Commits))
+	for _, c := range event.Commits {
+		out.Commits = append(out.Commits, Commit{
+			ID:      sanitizeCommandValue(c.ID),
+			Message: sanitizeCommandValue(c.Message),
+			URL:     sanitizeCommandValue(c.URL),
+		})
+	}
+	return out
+}
+
+func sanitizeCommandValue(in string) string {
+	return strings.Trim(unsafeCommandCharsRegex.ReplaceAllString(in, "-"), "-")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func firstLine(in string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(in), "\n")
+	return strings.TrimSpace(line)
+}
+
+func appendTextFieldIfNotEmpty(fields api.TextFields, title, value string) api.TextFields {
+	if value == "" {
+		return fields
+	}
+	return append(fields, api.TextField{
+		Key:   title,
+		Value: value,
+	})
+}
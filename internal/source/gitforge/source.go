@@ -0,0 +1,107 @@
+package gitforge
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	pkgConfig "github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+var _ source.Source = (*Source)(nil)
+
+var (
+	//go:embed config_schema.json
+	configJSONSchema string
+
+	//go:embed webhook_schema.json
+	webhookJSONSchema string
+)
+
+const (
+	// PluginName is the name of the Git forge Botkube plugin.
+	PluginName = "git-forge"
+
+	description = "Get notifications about GitHub and GitLab pushes, pull requests, releases and pipelines sent via the Botkube incoming webhook."
+)
+
+// Source Git forge source plugin data structure.
+type Source struct {
+	pluginVersion string
+	log           logrus.FieldLogger
+
+	source.StreamUnimplemented
+}
+
+// NewSource returns a new instance of Source.
+func NewSource(version string) *Source {
+	return &Source{
+		pluginVersion: version,
+		log:           loggerx.New(pkgConfig.Logger{}),
+	}
+}
+
+// Metadata returns metadata of Git forge source.
+func (s *Source) Metadata(_ context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     s.pluginVersion,
+		Description: description,
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+		ExternalRequest: api.ExternalRequestMetadata{
+			Payload: api.ExternalRequestPayload{
+				JSONSchema: api.JSONSchema{
+					Value: webhookJSONSchema,
+				},
+			},
+		},
+	}, nil
+}
+
+// HandleExternalRequest converts GitHub and GitLab webhook payloads into messages.
+// Signatures are verified by the incoming webhook server, see the `plugins.incomingWebhook.auth` settings.
+// If a given event is not supported or filtered out, an empty event is returned.
+func (s *Source) HandleExternalRequest(_ context.Context, in source.ExternalRequestInput) (source.ExternalRequestOutput, error) {
+	cfg, err := MergeConfigs([]*source.Config{in.Config})
+	if err != nil {
+		return source.ExternalRequestOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	event, ok, err := ParseEvent(in.Payload)
+	if err != nil {
+		return source.ExternalRequestOutput{}, err
+	}
+	if !ok {
+		return source.ExternalRequestOutput{}, nil
+	}
+
+	matched, err := cfg.Matches(event)
+	if err != nil {
+		return source.ExternalRequestOutput{}, err
+	}
+	if !matched {
+		return source.ExternalRequestOutput{}, nil
+	}
+
+	msg, err := NewMessageBuilder(in.Context.IsInteractivitySupported, cfg).FromEvent(event)
+	if err != nil {
+		s.log.Errorf("Failed to render buttons for %q event in %q source. Those buttons will be omitted. Issues:\n%s", event.Type, in.Context.SourceName, err)
+	}
+
+	return source.ExternalRequestOutput{
+		Event: source.Event{
+			Message:   msg,
+			RawObject: event,
+			AnalyticsLabels: map[string]interface{}{
+				"provider": string(event.Provider),
+				"type":     string(event.Type),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,180 @@
+package gitforge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+const releasePayload = `{
+  "action": "published",
+  "sender": {"login": "jane"},
+  "repository": {"full_name": "org/api", "html_url": "https://github.com/org/api"},
+  "release": {
+    "tag_name": "v1.2.0",
+    "name": "v1.2.0",
+    "body": "Changes:\n- faster API\n\nbotkube.io/deployment: prod/api, prod/worker",
+    "html_url": "https://github.com/org/api/releases/tag/v1.2.0",
+    "target_commitish": "main"
+  }
+}`
+
+func TestHandleExternalRequest(t *testing.T) {
+	// given
+	in := source.ExternalRequestInput{
+		Payload: []byte(releasePayload),
+		Config: &source.Config{
+			RawYAML: []byte(heredoc.Doc(`
+				buttons:
+				  - displayName: "Get pods"
+				    commandTpl: "kubectl get pods -l app={{ .Repository | base }}"
+				    events: ["release"]
+				  - displayName: "Pushed only"
+				    commandTpl: "kubectl get pods"
+				    events: ["push"]`)),
+		},
+		Context: source.ExternalRequestInputContext{
+			CommonSourceContext: source.CommonSourceContext{
+				IsInteractivitySupported: true,
+			},
+		},
+	}
+
+	expMsg := api.Message{
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "🏷️ Release v1.2.0 published in org/api",
+					Description: "v1.2.0",
+				},
+				TextFields: api.TextFields{
+					{Key: "Repository", Value: "org/api"},
+					{Key: "Branch", Value: "main"},
+					{Key: "Tag", Value: "v1.2.0"},
+					{Key: "Author", Value: "jane"},
+				},
+			},
+			{
+				Buttons: api.Buttons{
+					{
+						Name: "Open in GitHub",
+						URL:  "https://github.com/org/api/releases/tag/v1.2.0",
+					},
+					{
+						Name:    "Rollout status api",
+						Command: "{{BotName}} kubectl rollout status deployment/api --watch=false -n prod",
+						Style:   api.ButtonStylePrimary,
+					},
+					{
+						Name:    "Rollout status worker",
+						Command: "{{BotName}} kubectl rollout status deployment/worker --watch=false -n prod",
+						Style:   api.ButtonStylePrimary,
+					},
+					{
+						Name:    "Get pods",
+						Command: "{{BotName}} kubectl get pods -l app=api",
+					},
+				},
+			},
+		},
+	}
+
+	// when
+	out, err := NewSource("dev").HandleExternalRequest(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expMsg, out.Event.Message)
+
+	event, ok := out.Event.RawObject.(Event)
+	require.True(t, ok)
+	assert.Equal(t, ReleaseEvent, event.Type)
+}
+
+func TestHandleExternalRequestSanitizesCommands(t *testing.T) {
+	// given
+	payload := strings.NewReplacer(
+		`"name": "v1.2.0"`, `"name": "v1.2.0; kubectl delete ns prod | grep x"`,
+		`prod/api, prod/worker`, `prod/api --all-namespaces, Prod/api, prod/worker;rm, kube system/api, prod/web`,
+	).Replace(releasePayload)
+	in := source.ExternalRequestInput{
+		Payload: []byte(payload),
+		Config: &source.Config{
+			RawYAML: []byte(heredoc.Doc(`
+				buttons:
+				  - displayName: "Describe"
+				    commandTpl: "kubectl describe deploy {{ .Title }}"`)),
+		},
+		Context: source.ExternalRequestInputContext{
+			CommonSourceContext: source.CommonSourceContext{
+				IsInteractivitySupported: true,
+			},
+		},
+	}
+
+	// when
+	out, err := NewSource("dev").HandleExternalRequest(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, out.Event.Message.Sections, 2)
+	var cmds []string
+	for _, btn := range out.Event.Message.Sections[1].Buttons {
+		if btn.Command != "" {
+			cmds = append(cmds, btn.Command)
+		}
+	}
+	assert.Equal(t, []string{
+		"{{BotName}} kubectl rollout status deployment/web --watch=false -n prod",
+		"{{BotName}} kubectl describe deploy v1.2.0-kubectl-delete-ns-prod-grep-x",
+	}, cmds)
+}
+
+func TestHandleExternalRequestFilteredOut(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  string
+	}{
+		{
+			name: "Not matching event type",
+			cfg:  `events: ["push", "pipeline"]`,
+		},
+		{
+			name: "Not matching repository",
+			cfg: heredoc.Doc(`
+				repositories:
+				  include: ["org/web"]`),
+		},
+		{
+			name: "Excluded branch",
+			cfg: heredoc.Doc(`
+				branches:
+				  include: [".*"]
+				  exclude: ["main"]`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			in := source.ExternalRequestInput{
+				Payload: []byte(releasePayload),
+				Config:  &source.Config{RawYAML: []byte(tc.cfg)},
+			}
+
+			// when
+			out, err := NewSource("dev").HandleExternalRequest(context.Background(), in)
+
+			// then
+			require.NoError(t, err)
+			assert.True(t, out.Event.Message.IsEmpty())
+			assert.Nil(t, out.Event.RawObject)
+		})
+	}
+}
@@ -3,12 +3,16 @@ package source
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/httpx"
@@ -16,8 +20,11 @@ import (
 )
 
 const (
-	sourceNameVarName         = "sourceName"
-	incomingWebhookPathPrefix = "sources/v1"
+	sourceNameVarName          = "sourceName"
+	requestIDVarName           = "requestID"
+	incomingWebhookPathPrefix  = "sources/v1"
+	maxConcurrentAsyncRequests = 50
+	asyncRetryAfterSeconds     = "5"
 )
 
 // IncomingWebhookData holds information about incoming webhook.
@@ -30,86 +37,329 @@ func (w IncomingWebhookData) FullURLForSource(sourceName string) string {
 }
 
 // NewIncomingWebhookServer creates a new HTTP server for incoming webhooks.
-func NewIncomingWebhookServer(log logrus.FieldLogger, cfg *config.Config, dispatcher *Dispatcher, startedSources map[string]StartedSources) *httpx.Server {
-	addr := fmt.Sprintf(":%d", cfg.Plugins.IncomingWebhook.Port)
-	router := incomingWebhookRouter(log, cfg, dispatcher, startedSources)
+func NewIncomingWebhookServer(ctx context.Context, log logrus.FieldLogger, cfg *config.Config, dispatcher *Dispatcher, startedSources map[string]StartedSources, k8sCli kubernetes.Interface) (*httpx.Server, error) {
+	webhookCfg := cfg.Plugins.IncomingWebhook
+	addr := fmt.Sprintf(":%d", webhookCfg.Port)
+	auth := newWebhookAuthenticator(log, webhookCfg.Auth, newSecretCache(k8sCli, cfg.Settings.SystemConfigMap.Namespace))
+	validator := newPayloadValidator(ctx, log, dispatcher.manager, startedSources)
+	router := incomingWebhookRouter(ctx, log, cfg, dispatcher, startedSources, auth, validator)
 
 	log.Infof("Starting server on %q...", addr)
-	return httpx.NewServer(log, addr, router)
+	if !webhookCfg.TLS.Enabled {
+		return httpx.NewServer(log, addr, router), nil
+	}
+
+	tlsCfg, err := incomingWebhookTLSConfig(webhookCfg.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("while preparing TLS configuration: %w", err)
+	}
+	return httpx.NewTLSServer(log, addr, router, tlsCfg, webhookCfg.TLS.CertFile, webhookCfg.TLS.KeyFile), nil
 }
 
-func incomingWebhookRouter(log logrus.FieldLogger, cfg *config.Config, dispatcher *Dispatcher, startedSources map[string]StartedSources) *mux.Router {
+func incomingWebhookRouter(ctx context.Context, log logrus.FieldLogger, cfg *config.Config, dispatcher externalRequestDispatcher, startedSources map[string]StartedSources, auth *webhookAuthenticator, validator *payloadValidator) *mux.Router {
+	h := &incomingWebhookHandler{
+		ctx:            ctx,
+		log:            log,
+		cfg:            cfg,
+		dispatcher:     dispatcher,
+		startedSources: startedSources,
+		auth:           auth,
+		validator:      validator,
+		requests:       newRequestTracker(maxTrackedWebhookRequests),
+		asyncSlots:     make(chan struct{}, maxConcurrentAsyncRequests),
+	}
+
 	router := mux.NewRouter()
 	pathPrefix := fmt.Sprintf("/%s/", incomingWebhookPathPrefix)
+	router.Path(fmt.Sprintf("%srequests/{%s}", pathPrefix, requestIDVarName)).Methods(http.MethodGet).HandlerFunc(h.handleRequestStatus)
+	router.Path(fmt.Sprintf("%s{%s}/batch", pathPrefix, sourceNameVarName)).Methods(http.MethodPost).HandlerFunc(h.handleBatch)
 	router.PathPrefix(pathPrefix).Methods(http.MethodPost).Handler(
-		http.StripPrefix(
-			pathPrefix,
-			http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
-				if request == nil || request.URL == nil || request.URL.Path == "" {
-					writeJSONError(log, writer, "Source name in path is required", http.StatusBadRequest)
-					return
-				}
-
-				sourceName := request.URL.Path
-				logger := log.WithFields(logrus.Fields{
-					"sourceName": sourceName,
-				})
-				logger.Debugf("Handling incoming webhook request...")
-
-				sourcePlugins, ok := startedSources[sourceName]
-				if !ok || len(sourcePlugins) == 0 {
-					writeJSONError(log, writer, fmt.Sprintf("source %q not found", sourceName), http.StatusNotFound)
-					return
-				}
-
-				payload, err := io.ReadAll(request.Body)
-				if err != nil {
-					writeJSONError(log, writer, fmt.Sprintf("while reading request body: %s", err.Error()), http.StatusInternalServerError)
-					return
-				}
-				defer request.Body.Close()
-
-				multiErr := multierror.New()
-				for _, src := range sourcePlugins {
-					logger.WithFields(logrus.Fields{
-						"pluginName":               src.PluginName,
-						"isInteractivitySupported": src.IsInteractivitySupported,
-					}).Debug("Dispatching message...")
-
-					err := dispatcher.DispatchExternalRequest(ExternalRequestDispatch{
-						PluginDispatch: PluginDispatch{
-							ctx:                      context.Background(),
-							sourceName:               sourceName,
-							sourceDisplayName:        src.SourceDisplayName,
-							pluginName:               src.PluginName,
-							pluginConfig:             src.PluginConfig,
-							isInteractivitySupported: src.IsInteractivitySupported,
-							cfg:                      cfg,
-							pluginContext:            config.PluginContext{},
-							incomingWebhook: IncomingWebhookData{
-								inClusterBaseURL: cfg.Plugins.IncomingWebhook.InClusterBaseURL,
-							},
-						},
-						payload: payload,
-					})
-					if err != nil {
-						multiErr = multierror.Append(multiErr, err)
-					}
-				}
-
-				if multiErr.ErrorOrNil() != nil {
-					wrappedErr := fmt.Errorf("while dispatching external request: %w", multiErr)
-					writeJSONError(log, writer, wrappedErr.Error(), http.StatusInternalServerError)
-					return
-				}
-
-				writeJSONSuccess(log, writer)
-			}),
-		),
+		http.StripPrefix(pathPrefix, http.HandlerFunc(h.handleSingle)),
 	)
 	return router
 }
 
+// externalRequestDispatcher dispatches incoming webhook payloads to source plugins.
+type externalRequestDispatcher interface {
+	DispatchExternalRequest(dispatch ExternalRequestDispatch) error
+}
+
+type incomingWebhookHandler struct {
+	// ctx is used for asynchronous requests, so they are not canceled when the HTTP request finishes.
+	ctx            context.Context
+	log            logrus.FieldLogger
+	cfg            *config.Config
+	dispatcher     externalRequestDispatcher
+	startedSources map[string]StartedSources
+	auth           *webhookAuthenticator
+	validator      *payloadValidator
+	requests       *requestTracker
+	asyncSlots     chan struct{}
+}
+
+func (h *incomingWebhookHandler) handleSingle(writer http.ResponseWriter, request *http.Request) {
+	if request == nil || request.URL == nil || request.URL.Path == "" {
+		writeJSONError(h.log, writer, "Source name in path is required", http.StatusBadRequest)
+		return
+	}
+
+	sourceName := request.URL.Path
+	body, sourcePlugins, ok := h.prepareRequest(writer, request, sourceName)
+	if !ok {
+		return
+	}
+
+	if !h.validatePayload(request.Context(), writer, sourceName, sourcePlugins, body, "") {
+		return
+	}
+
+	payloads := []json.RawMessage{body}
+	if isAsyncRequest(request) {
+		h.dispatchAsync(writer, sourceName, sourcePlugins, payloads)
+		return
+	}
+
+	if err := h.dispatchPayload(context.Background(), sourceName, sourcePlugins, body); err != nil {
+		writeJSONError(h.log, writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONSuccess(h.log, writer)
+}
+
+func (h *incomingWebhookHandler) handleBatch(writer http.ResponseWriter, request *http.Request) {
+	sourceName := mux.Vars(request)[sourceNameVarName]
+	body, sourcePlugins, ok := h.prepareRequest(writer, request, sourceName)
+	if !ok {
+		return
+	}
+
+	var payloads []json.RawMessage
+	if err := json.Unmarshal(body, &payloads); err != nil {
+		h.log.WithField("sourceName", sourceName).Debugf("while unmarshaling batch request: %s", err.Error())
+		writeJSONError(h.log, writer, "Batch request body must be a JSON array of payloads", http.StatusBadRequest)
+		return
+	}
+	if len(payloads) == 0 {
+		writeJSONError(h.log, writer, "Batch request must contain at least one payload", http.StatusBadRequest)
+		return
+	}
+
+	for idx, payload := range payloads {
+		if !h.validatePayload(request.Context(), writer, sourceName, sourcePlugins, payload, fmt.Sprintf("[%d]", idx)) {
+			return
+		}
+	}
+
+	if isAsyncRequest(request) {
+		h.dispatchAsync(writer, sourceName, sourcePlugins, payloads)
+		return
+	}
+
+	items := h.dispatchPayloads(context.Background(), sourceName, sourcePlugins, payloads)
+	writeJSONBatchResult(h.log, writer, items)
+}
+
+func (h *incomingWebhookHandler) handleRequestStatus(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)[requestIDVarName]
+	req, found := h.requests.Get(id)
+	if !found {
+		writeJSONError(h.log, writer, fmt.Sprintf("request %q not found", id), http.StatusNotFound)
+		return
+	}
+
+	// a status request authenticates in the same way as the request it refers to, except for the body signature
+	err := h.auth.AuthenticateStatusRequest(request.Context(), req.SourceName, request)
+	if err != nil && !h.handleAuthError(writer, request, req.SourceName, err) {
+		return
+	}
+
+	writeJSON(h.log, writer, req, http.StatusOK)
+}
+
+// prepareRequest reads the request body, authenticates the request and returns started plugins for a given source.
+// If false is returned, the response was already written.
+func (h *incomingWebhookHandler) prepareRequest(writer http.ResponseWriter, request *http.Request, sourceName string) ([]byte, StartedSources, bool) {
+	h.log.WithField("sourceName", sourceName).Debugf("Handling incoming webhook request...")
+
+	// the body is read before authentication, so its size is limited
+	if maxBodySize := h.cfg.Plugins.IncomingWebhook.MaxBodySize; maxBodySize > 0 {
+		request.Body = http.MaxBytesReader(writer, request.Body, maxBodySize)
+	}
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeJSONError(h.log, writer, fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+			return nil, nil, false
+		}
+		writeJSONError(h.log, writer, fmt.Sprintf("while reading request body: %s", err.Error()), http.StatusInternalServerError)
+		return nil, nil, false
+	}
+	defer request.Body.Close()
+
+	// authenticate before revealing whether a given source exists
+	if !h.authenticate(writer, request, sourceName, body) {
+		return nil, nil, false
+	}
+
+	sourcePlugins, ok := h.startedSources[sourceName]
+	if !ok || len(sourcePlugins) == 0 {
+		writeJSONError(h.log, writer, fmt.Sprintf("source %q not found", sourceName), http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	return body, sourcePlugins, true
+}
+
+func (h *incomingWebhookHandler) authenticate(writer http.ResponseWriter, request *http.Request, sourceName string, body []byte) bool {
+	err := h.auth.Authenticate(request.Context(), sourceName, request, body)
+	if err == nil {
+		return true
+	}
+	return h.handleAuthError(writer, request, sourceName, err)
+}
+
+// handleAuthError writes the response for a given authentication error and always returns false.
+func (h *incomingWebhookHandler) handleAuthError(writer http.ResponseWriter, request *http.Request, sourceName string, err error) bool {
+	logger := h.log.WithField("sourceName", sourceName)
+
+	var authErr *webhookAuthError
+	if !errors.As(err, &authErr) {
+		logger.Errorf("while authenticating request: %s", err.Error())
+		writeJSONError(h.log, writer, "while authenticating request", http.StatusInternalServerError)
+		return false
+	}
+	logger.WithField("remoteAddr", request.RemoteAddr).Warnf("Rejected incoming webhook request: %s", authErr.Error())
+	writeJSONError(h.log, writer, http.StatusText(authErr.code), authErr.code)
+	return false
+}
+
+// validatePayload validates a given payload against JSON schemas of all source plugins.
+// If false is returned, the response was already written.
+func (h *incomingWebhookHandler) validatePayload(ctx context.Context, writer http.ResponseWriter, sourceName string, sourcePlugins StartedSources, payload []byte, fieldPrefix string) bool {
+	for _, src := range sourcePlugins {
+		var validationErr *payloadValidationError
+		if err := h.validator.Validate(ctx, src.PluginName, payload); !errors.As(err, &validationErr) {
+			continue
+		}
+
+		h.log.WithFields(logrus.Fields{
+			"sourceName": sourceName,
+			"pluginName": src.PluginName,
+		}).Debugf("Rejected invalid payload: %s", validationErr.Error())
+
+		if fieldPrefix != "" {
+			for idx := range validationErr.fields {
+				validationErr.fields[idx].Field = fmt.Sprintf("%s.%s", fieldPrefix, validationErr.fields[idx].Field)
+			}
+		}
+		writeJSONValidationError(h.log, writer, validationErr)
+		return false
+	}
+	return true
+}
+
+// dispatchAsync dispatches payloads in background and responds with the request ID, which can be used to check the request status.
+func (h *incomingWebhookHandler) dispatchAsync(writer http.ResponseWriter, sourceName string, sourcePlugins StartedSources, payloads []json.RawMessage) {
+	select {
+	case h.asyncSlots <- struct{}{}:
+	default:
+		writer.Header().Set("Retry-After", asyncRetryAfterSeconds)
+		writeJSONError(h.log, writer, "Too many asynchronous requests in progress", http.StatusTooManyRequests)
+		return
+	}
+
+	id := h.requests.Start(sourceName)
+	go func() {
+		defer func() { <-h.asyncSlots }()
+		items := h.dispatchPayloads(h.ctx, sourceName, sourcePlugins, payloads)
+		h.requests.Finish(id, items)
+	}()
+
+	response := struct {
+		RequestID string `json:"requestID"`
+		StatusURL string `json:"statusURL"`
+	}{
+		RequestID: id,
+		StatusURL: fmt.Sprintf("/%s/requests/%s", incomingWebhookPathPrefix, id),
+	}
+	writeJSON(h.log, writer, response, http.StatusAccepted)
+}
+
+func (h *incomingWebhookHandler) dispatchPayloads(ctx context.Context, sourceName string, sourcePlugins StartedSources, payloads []json.RawMessage) []WebhookItemResult {
+	items := make([]WebhookItemResult, 0, len(payloads))
+	for idx, payload := range payloads {
+		item := WebhookItemResult{Index: idx, Success: true}
+		if err := h.dispatchPayload(ctx, sourceName, sourcePlugins, payload); err != nil {
+			item.Success = false
+			item.Error = err.Error()
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (h *incomingWebhookHandler) dispatchPayload(ctx context.Context, sourceName string, sourcePlugins StartedSources, payload []byte) error {
+	multiErr := multierror.New()
+	for _, src := range sourcePlugins {
+		h.log.WithFields(logrus.Fields{
+			"sourceName":               sourceName,
+			"pluginName":               src.PluginName,
+			"isInteractivitySupported": src.IsInteractivitySupported,
+		}).Debug("Dispatching message...")
+
+		err := h.dispatcher.DispatchExternalRequest(ExternalRequestDispatch{
+			PluginDispatch: PluginDispatch{
+				ctx:                      ctx,
+				sourceName:               sourceName,
+				sourceDisplayName:        src.SourceDisplayName,
+				pluginName:               src.PluginName,
+				pluginConfig:             src.PluginConfig,
+				isInteractivitySupported: src.IsInteractivitySupported,
+				recordHistory:            recordsHistory(src, sourcePlugins),
+				cfg:                      h.cfg,
+				pluginContext:            config.PluginContext{},
+				incomingWebhook: IncomingWebhookData{
+					inClusterBaseURL: h.cfg.Plugins.IncomingWebhook.InClusterBaseURL,
+				},
+			},
+			payload: payload,
+		})
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	if multiErr.ErrorOrNil() != nil {
+		return fmt.Errorf("while dispatching external request: %w", multiErr)
+	}
+	return nil
+}
+
+// recordsHistory returns true if events from a given started source should be recorded in the history.
+// The same payload is dispatched to all started sources, so the non-interactive one is preferred to record it once.
+func recordsHistory(src StartedSource, sourcePlugins StartedSources) bool {
+	if !src.IsInteractivitySupported {
+		return true
+	}
+	_, hasNonInteractive := sourcePlugins[false]
+	return !hasNonInteractive
+}
+
+// isAsyncRequest returns true if the sender asked to not wait for the dispatch, either with the `async=true` query parameter or the `Prefer: respond-async` header.
+func isAsyncRequest(request *http.Request) bool {
+	if async, _ := strconv.ParseBool(request.URL.Query().Get("async")); async {
+		return true
+	}
+	for _, pref := range strings.Split(request.Header.Get("Prefer"), ",") {
+		if strings.EqualFold(strings.TrimSpace(pref), "respond-async") {
+			return true
+		}
+	}
+	return false
+}
+
 func writeJSONError(log logrus.FieldLogger, w http.ResponseWriter, errMsg string, code int) {
 	response := struct {
 		Error string `json:"error"`
@@ -126,6 +376,24 @@ func writeJSONError(log logrus.FieldLogger, w http.ResponseWriter, errMsg string
 	}
 }
 
+func writeJSONValidationError(log logrus.FieldLogger, w http.ResponseWriter, validationErr *payloadValidationError) {
+	response := struct {
+		Error  string              `json:"error"`
+		Fields []PayloadFieldError `json:"fields"`
+	}{
+		Error:  validationErr.Error(),
+		Fields: validationErr.fields,
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusBadRequest)
+	err := json.NewEncoder(w).Encode(&response)
+	if err != nil {
+		log.Errorf("while writing validation error response: %s", err.Error())
+	}
+}
+
 func writeJSONSuccess(log logrus.FieldLogger, w http.ResponseWriter) {
 	response := struct {
 		Success bool `json:"success"`
@@ -141,3 +409,33 @@ func writeJSONSuccess(log logrus.FieldLogger, w http.ResponseWriter) {
 		log.Errorf("while writing success response: %s", err.Error())
 	}
 }
+
+func writeJSONBatchResult(log logrus.FieldLogger, w http.ResponseWriter, items []WebhookItemResult) {
+	response := struct {
+		Success bool                `json:"success"`
+		Items   []WebhookItemResult `json:"items"`
+	}{
+		Success: true,
+		Items:   items,
+	}
+
+	code := http.StatusOK
+	for _, item := range items {
+		if !item.Success {
+			response.Success = false
+			code = http.StatusInternalServerError
+			break
+		}
+	}
+	writeJSON(log, w, response, code)
+}
+
+func writeJSON(log logrus.FieldLogger, w http.ResponseWriter, response any, code int) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		log.Errorf("while writing response: %s", err.Error())
+	}
+}
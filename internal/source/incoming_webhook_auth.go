@@ -0,0 +1,333 @@
+package source
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // sha1 is still used by some webhook providers
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	secretCacheTTL                = time.Minute
+	defaultSignatureAlgorithm     = "sha256"
+	defaultStripeSigningTolerance = 5 * time.Minute
+	stripeTimestampKey            = "t"
+	stripeSignatureKey            = "v1"
+)
+
+// webhookAuthError describes a rejected incoming webhook request.
+type webhookAuthError struct {
+	code int
+	msg  string
+}
+
+func (e *webhookAuthError) Error() string {
+	return e.msg
+}
+
+func unauthorized(format string, args ...any) *webhookAuthError {
+	return &webhookAuthError{code: http.StatusUnauthorized, msg: fmt.Sprintf(format, args...)}
+}
+
+func forbidden(format string, args ...any) *webhookAuthError {
+	return &webhookAuthError{code: http.StatusForbidden, msg: fmt.Sprintf(format, args...)}
+}
+
+// webhookAuthenticator authenticates incoming webhook requests based on per-source configuration.
+type webhookAuthenticator struct {
+	log     logrus.FieldLogger
+	cfg     map[string]config.IncomingWebhookAuth
+	secrets *secretCache
+	nowFn   func() time.Time
+}
+
+func newWebhookAuthenticator(log logrus.FieldLogger, cfg map[string]config.IncomingWebhookAuth, secrets *secretCache) *webhookAuthenticator {
+	return &webhookAuthenticator{
+		log:     log,
+		cfg:     cfg,
+		secrets: secrets,
+		nowFn:   time.Now,
+	}
+}
+
+// Authenticate returns webhookAuthError if a given request is not allowed for a given source.
+// Requests for sources without authentication configuration are always allowed.
+func (a *webhookAuthenticator) Authenticate(ctx context.Context, sourceName string, req *http.Request, payload []byte) error {
+	if err := a.AuthenticateStatusRequest(ctx, sourceName, req); err != nil {
+		return err
+	}
+
+	auth := a.cfg[sourceName]
+	if auth.Signature != nil {
+		if err := a.verifySignature(ctx, *auth.Signature, req, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AuthenticateStatusRequest authenticates a request without a signed body, such as the asynchronous request status check.
+// The signature verification is skipped, as there is no payload to verify.
+func (a *webhookAuthenticator) AuthenticateStatusRequest(ctx context.Context, sourceName string, req *http.Request) error {
+	auth, found := a.cfg[sourceName]
+	if !found {
+		return nil
+	}
+
+	if auth.ClientCert != nil {
+		if err := a.verifyClientCert(auth.ClientCert, req); err != nil {
+			return err
+		}
+	}
+
+	if len(auth.BearerTokens) > 0 {
+		if err := a.verifyBearerToken(ctx, auth.BearerTokens, req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *webhookAuthenticator) verifyClientCert(cfg *config.IncomingWebhookClientCert, req *http.Request) error {
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 {
+		return unauthorized("verified client certificate is required")
+	}
+
+	if len(cfg.AllowedNames) == 0 {
+		return nil
+	}
+
+	cert := req.TLS.VerifiedChains[0][0]
+	names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+	for _, name := range names {
+		if slices.Contains(cfg.AllowedNames, name) {
+			return nil
+		}
+	}
+	return forbidden("client certificate %q is not allowed", cert.Subject.CommonName)
+}
+
+func (a *webhookAuthenticator) verifyBearerToken(ctx context.Context, refs []config.SecretKeyRef, req *http.Request) error {
+	scheme, token, found := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return unauthorized("bearer token is required")
+	}
+
+	for _, ref := range refs {
+		expected, err := a.secrets.Get(ctx, ref)
+		if err != nil {
+			a.log.Errorf("while getting bearer token: %s", err.Error())
+			continue
+		}
+		if subtle.ConstantTimeCompare(expected, []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return forbidden("invalid bearer token")
+}
+
+func (a *webhookAuthenticator) verifySignature(ctx context.Context, cfg config.IncomingWebhookSignature, req *http.Request, payload []byte) error {
+	headerValue := req.Header.Get(cfg.Header)
+	if headerValue == "" {
+		return unauthorized("signature header %q is required", cfg.Header)
+	}
+
+	hashFn, err := hashFnForAlgorithm(cfg.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	secret, err := a.secrets.Get(ctx, cfg.Secret)
+	if err != nil {
+		return fmt.Errorf("while getting signature secret: %w", err)
+	}
+
+	if cfg.Style == config.GitLabIncomingWebhookSignatureStyle {
+		if subtle.ConstantTimeCompare([]byte(headerValue), secret) != 1 {
+			return forbidden("invalid signature")
+		}
+		return nil
+	}
+
+	var (
+		signedPayload = payload
+		signatures    []string
+	)
+	switch cfg.Style {
+	case config.StripeIncomingWebhookSignatureStyle:
+		timestamp, sigs, err := parseStripeSignatureHeader(headerValue)
+		if err != nil {
+			return unauthorized("invalid signature header: %s", err.Error())
+		}
+		tolerance := cfg.Tolerance
+		if tolerance <= 0 {
+			tolerance = defaultStripeSigningTolerance
+		}
+		if age := a.nowFn().Sub(time.Unix(timestamp, 0)); age > tolerance || age < -tolerance {
+			return forbidden("signature timestamp is outside of the tolerance window")
+		}
+		signedPayload = append([]byte(fmt.Sprintf("%d.", timestamp)), payload...)
+		signatures = sigs
+	default:
+		signatures = []string{strings.TrimPrefix(headerValue, cfg.Prefix)}
+	}
+
+	mac := hmac.New(hashFn, secret)
+	mac.Write(signedPayload)
+	expected := mac.Sum(nil)
+
+	for _, sig := range signatures {
+		got, err := hex.DecodeString(sig)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(expected, got) {
+			return nil
+		}
+	}
+	return forbidden("invalid signature")
+}
+
+func hashFnForAlgorithm(algorithm string) (func() hash.Hash, error) {
+	switch algorithm {
+	case "", defaultSignatureAlgorithm:
+		return sha256.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm %q", algorithm)
+	}
+}
+
+// parseStripeSignatureHeader parses header in the `t=<timestamp>,v1=<signature>,v1=<signature>` format.
+func parseStripeSignatureHeader(in string) (int64, []string, error) {
+	var (
+		timestamp  int64
+		signatures []string
+	)
+	for _, item := range strings.Split(in, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case stripeTimestampKey:
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, nil, fmt.Errorf("while parsing timestamp: %w", err)
+			}
+			timestamp = ts
+		case stripeSignatureKey:
+			signatures = append(signatures, value)
+		}
+	}
+
+	if timestamp == 0 {
+		return 0, nil, fmt.Errorf("timestamp is missing")
+	}
+	if len(signatures) == 0 {
+		return 0, nil, fmt.Errorf("signature is missing")
+	}
+	return timestamp, signatures, nil
+}
+
+// secretCache reads values from K8s Secrets and caches them for a short time, so rotated secrets are picked up without restart.
+type secretCache struct {
+	k8sCli           kubernetes.Interface
+	defaultNamespace string
+
+	mu      sync.Mutex
+	entries map[config.SecretKeyRef]secretCacheEntry
+}
+
+type secretCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func newSecretCache(k8sCli kubernetes.Interface, defaultNamespace string) *secretCache {
+	return &secretCache{
+		k8sCli:           k8sCli,
+		defaultNamespace: defaultNamespace,
+		entries:          map[config.SecretKeyRef]secretCacheEntry{},
+	}
+}
+
+// Get returns a value of a given Secret key.
+func (c *secretCache) Get(ctx context.Context, ref config.SecretKeyRef) ([]byte, error) {
+	if ref.Namespace == "" {
+		ref.Namespace = c.defaultNamespace
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[ref]
+	if found && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	secret, err := c.k8sCli.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("while getting Secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	value, found := secret.Data[ref.Key]
+	if !found {
+		return nil, fmt.Errorf("key %q not found in Secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+	}
+
+	value = []byte(strings.TrimSpace(string(value)))
+	c.entries[ref] = secretCacheEntry{
+		value:     value,
+		expiresAt: time.Now().Add(secretCacheTTL),
+	}
+	return value, nil
+}
+
+// incomingWebhookTLSConfig returns TLS configuration for the incoming webhook server.
+// If the client CA is configured, client certificates are verified when provided. Sources decide whether they are required.
+func incomingWebhookTLSConfig(cfg config.IncomingWebhookTLS) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if cfg.ClientCAFile == "" {
+		return tlsCfg, nil
+	}
+
+	caCert, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("while reading client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no valid certificates found in %q", cfg.ClientCAFile)
+	}
+
+	tlsCfg.ClientCAs = pool
+	tlsCfg.ClientAuth = tls.VerifyClientCertIfGiven
+	return tlsCfg, nil
+}
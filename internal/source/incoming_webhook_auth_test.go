@@ -0,0 +1,199 @@
+package source
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestWebhookAuthenticator(t *testing.T) {
+	// given
+	const (
+		payload   = `{"status":"firing"}`
+		token     = "my-token"
+		hmacKey   = "hmac-secret"
+		timestamp = int64(1696500000)
+	)
+	now := time.Unix(timestamp, 0).Add(time.Minute)
+
+	k8sCli := fake.NewSimpleClientset(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook-auth", Namespace: "botkube"},
+		Data: map[string][]byte{
+			"token": []byte(token + "\n"),
+			"hmac":  []byte(hmacKey),
+		},
+	})
+	secretRef := func(key string) config.SecretKeyRef {
+		return config.SecretKeyRef{Name: "webhook-auth", Key: key}
+	}
+
+	auth := newWebhookAuthenticator(loggerx.NewNoop(), map[string]config.IncomingWebhookAuth{
+		"bearer": {
+			BearerTokens: []config.SecretKeyRef{secretRef("token")},
+		},
+		"github": {
+			Signature: &config.IncomingWebhookSignature{
+				Style:  config.GitHubIncomingWebhookSignatureStyle,
+				Header: "X-Hub-Signature-256",
+				Prefix: "sha256=",
+				Secret: secretRef("hmac"),
+			},
+		},
+		"stripe": {
+			Signature: &config.IncomingWebhookSignature{
+				Style:  config.StripeIncomingWebhookSignatureStyle,
+				Header: "Stripe-Signature",
+				Secret: secretRef("hmac"),
+			},
+		},
+		"gitlab": {
+			Signature: &config.IncomingWebhookSignature{
+				Style:  config.GitLabIncomingWebhookSignatureStyle,
+				Header: "X-Gitlab-Token",
+				Secret: secretRef("token"),
+			},
+		},
+		"mtls": {
+			ClientCert: &config.IncomingWebhookClientCert{AllowedNames: []string{"alertmanager"}},
+		},
+	}, newSecretCache(k8sCli, "botkube"))
+	auth.nowFn = func() time.Time { return now }
+
+	tests := []struct {
+		name       string
+		sourceName string
+		headers    map[string]string
+		tlsState   *tls.ConnectionState
+		expCode    int
+	}{
+		{
+			name:       "No auth configured",
+			sourceName: "other",
+		},
+		{
+			name:       "Valid bearer token",
+			sourceName: "bearer",
+			headers:    map[string]string{"Authorization": "Bearer " + token},
+		},
+		{
+			name:       "Missing bearer token",
+			sourceName: "bearer",
+			expCode:    http.StatusUnauthorized,
+		},
+		{
+			name:       "Invalid bearer token",
+			sourceName: "bearer",
+			headers:    map[string]string{"Authorization": "Bearer other"},
+			expCode:    http.StatusForbidden,
+		},
+		{
+			name:       "Valid GitHub signature",
+			sourceName: "github",
+			headers:    map[string]string{"X-Hub-Signature-256": "sha256=" + sign(hmacKey, payload)},
+		},
+		{
+			name:       "Invalid GitHub signature",
+			sourceName: "github",
+			headers:    map[string]string{"X-Hub-Signature-256": "sha256=" + sign("other", payload)},
+			expCode:    http.StatusForbidden,
+		},
+		{
+			name:       "Missing GitHub signature",
+			sourceName: "github",
+			expCode:    http.StatusUnauthorized,
+		},
+		{
+			name:       "Valid Stripe signature",
+			sourceName: "stripe",
+			headers:    map[string]string{"Stripe-Signature": fmt.Sprintf("t=%d,v1=%s", timestamp, sign(hmacKey, fmt.Sprintf("%d.%s", timestamp, payload)))},
+		},
+		{
+			name:       "Expired Stripe signature",
+			sourceName: "stripe",
+			headers:    map[string]string{"Stripe-Signature": fmt.Sprintf("t=%d,v1=%s", timestamp-3600, sign(hmacKey, fmt.Sprintf("%d.%s", timestamp-3600, payload)))},
+			expCode:    http.StatusForbidden,
+		},
+		{
+			name:       "Valid GitLab token",
+			sourceName: "gitlab",
+			headers:    map[string]string{"X-Gitlab-Token": token},
+		},
+		{
+			name:       "Invalid GitLab token",
+			sourceName: "gitlab",
+			headers:    map[string]string{"X-Gitlab-Token": "other"},
+			expCode:    http.StatusForbidden,
+		},
+		{
+			name:       "Allowed client certificate",
+			sourceName: "mtls",
+			tlsState:   clientCertState("alertmanager"),
+		},
+		{
+			name:       "Not allowed client certificate",
+			sourceName: "mtls",
+			tlsState:   clientCertState("unknown"),
+			expCode:    http.StatusForbidden,
+		},
+		{
+			name:       "Missing client certificate",
+			sourceName: "mtls",
+			expCode:    http.StatusUnauthorized,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/sources/v1/"+tc.sourceName, strings.NewReader(payload))
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			req.TLS = tc.tlsState
+
+			// when
+			err := auth.Authenticate(context.Background(), tc.sourceName, req, []byte(payload))
+
+			// then
+			if tc.expCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var authErr *webhookAuthError
+			require.True(t, errors.As(err, &authErr))
+			assert.Equal(t, tc.expCode, authErr.code)
+		})
+	}
+}
+
+func sign(key, payload string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func clientCertState(commonName string) *tls.ConnectionState {
+	return &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{
+			{{Subject: pkix.Name{CommonName: commonName}}},
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package source
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const maxTrackedWebhookRequests = 1000
+
+// WebhookRequestStatus defines the processing status of an asynchronous incoming webhook request.
+type WebhookRequestStatus string
+
+const (
+	// WebhookRequestPending means that the request is still being processed.
+	WebhookRequestPending WebhookRequestStatus = "pending"
+	// WebhookRequestSucceeded means that all payloads were dispatched successfully.
+	WebhookRequestSucceeded WebhookRequestStatus = "succeeded"
+	// WebhookRequestFailed means that at least one payload failed to be dispatched.
+	WebhookRequestFailed WebhookRequestStatus = "failed"
+)
+
+// WebhookRequest holds the processing state of an asynchronous incoming webhook request.
+type WebhookRequest struct {
+	ID         string               `json:"id"`
+	SourceName string               `json:"sourceName"`
+	Status     WebhookRequestStatus `json:"status"`
+	Items      []WebhookItemResult  `json:"items,omitempty"`
+	CreatedAt  time.Time            `json:"createdAt"`
+	FinishedAt *time.Time           `json:"finishedAt,omitempty"`
+}
+
+// WebhookItemResult holds the dispatch result of a single payload.
+type WebhookItemResult struct {
+	Index   int    `json:"index"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// requestTracker keeps the state of the most recent asynchronous incoming webhook requests.
+type requestTracker struct {
+	mu      sync.RWMutex
+	max     int
+	entries map[string]*WebhookRequest
+	order   []string
+	nowFn   func() time.Time
+}
+
+func newRequestTracker(max int) *requestTracker {
+	return &requestTracker{
+		max:     max,
+		entries: map[string]*WebhookRequest{},
+		nowFn:   time.Now,
+	}
+}
+
+// Start registers a new pending request and returns its ID.
+func (t *requestTracker) Start(sourceName string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := uuid.New().String()
+	t.entries[id] = &WebhookRequest{
+		ID:         id,
+		SourceName: sourceName,
+		Status:     WebhookRequestPending,
+		CreatedAt:  t.nowFn(),
+	}
+	t.order = append(t.order, id)
+
+	// the oldest requests are forgotten first
+	for len(t.order) > t.max {
+		delete(t.entries, t.order[0])
+		t.order = t.order[1:]
+	}
+	return id
+}
+
+// Finish records dispatch results for a given request.
+func (t *requestTracker) Finish(id string, items []WebhookItemResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	req, found := t.entries[id]
+	if !found {
+		return
+	}
+
+	now := t.nowFn()
+	req.Items = items
+	req.FinishedAt = &now
+	req.Status = WebhookRequestSucceeded
+	for _, item := range items {
+		if !item.Success {
+			req.Status = WebhookRequestFailed
+			break
+		}
+	}
+}
+
+// Get returns a copy of a given request.
+func (t *requestTracker) Get(id string) (WebhookRequest, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	req, found := t.entries[id]
+	if !found {
+		return WebhookRequest{}, false
+	}
+	return *req, true
+}
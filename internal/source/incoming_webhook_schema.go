@@ -0,0 +1,153 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+// sourceGetter returns a started source plugin client.
+type sourceGetter interface {
+	GetSource(name string) (source.Source, error)
+}
+
+// PayloadFieldError describes a single payload field which doesn't conform to the JSON schema.
+type PayloadFieldError struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// payloadValidationError is returned when the incoming webhook payload doesn't conform to the plugin's JSON schema.
+type payloadValidationError struct {
+	pluginName string
+	fields     []PayloadFieldError
+}
+
+func (e *payloadValidationError) Error() string {
+	return fmt.Sprintf("payload doesn't conform to the %q plugin JSON schema", e.pluginName)
+}
+
+// payloadValidator validates incoming webhook payloads against JSON schemas declared by source plugins.
+// Schemas are compiled again when a plugin is restarted, e.g. after its binary was updated.
+type payloadValidator struct {
+	log     logrus.FieldLogger
+	sources sourceGetter
+
+	mu sync.Mutex
+	// schemas holds compiled JSON schemas indexed by plugin name.
+	schemas map[string]compiledPayloadSchema
+}
+
+// compiledPayloadSchema holds a schema compiled for a given plugin client. The schema is nil if the plugin doesn't declare it,
+// or it's invalid.
+type compiledPayloadSchema struct {
+	client source.Source
+	schema *gojsonschema.Schema
+}
+
+// newPayloadValidator fetches and compiles external request payload schemas for all started source plugins.
+// Plugins with a missing or an invalid schema are not validated.
+func newPayloadValidator(ctx context.Context, log logrus.FieldLogger, sources sourceGetter, startedSources map[string]StartedSources) *payloadValidator {
+	out := &payloadValidator{
+		log:     log,
+		sources: sources,
+		schemas: map[string]compiledPayloadSchema{},
+	}
+
+	for _, plugins := range startedSources {
+		for _, src := range plugins {
+			out.getSchema(ctx, src.PluginName)
+		}
+	}
+
+	return out
+}
+
+// getSchema returns a compiled schema for a given plugin. The schema is compiled if the plugin client changed since the last call.
+func (v *payloadValidator) getSchema(ctx context.Context, pluginName string) *gojsonschema.Schema {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	logger := v.log.WithField("pluginName", pluginName)
+	compiled, found := v.schemas[pluginName]
+	sourceClient, err := v.sources.GetSource(pluginName)
+	if err != nil {
+		if !found {
+			logger.Warnf("Incoming webhook payloads won't be validated: while getting source client: %s", err.Error())
+			v.schemas[pluginName] = compiledPayloadSchema{}
+		}
+		// the plugin may be restarting, so the previously compiled schema is used
+		return compiled.schema
+	}
+	if found && compiled.client == sourceClient {
+		return compiled.schema
+	}
+
+	schema, err := compilePayloadSchema(ctx, sourceClient)
+	if err != nil {
+		logger.Warnf("Incoming webhook payloads won't be validated: %s", err.Error())
+	} else if schema != nil {
+		logger.Debug("Compiled incoming webhook payload JSON schema")
+	}
+	v.schemas[pluginName] = compiledPayloadSchema{client: sourceClient, schema: schema}
+	return schema
+}
+
+func compilePayloadSchema(ctx context.Context, sourceClient source.Source) (*gojsonschema.Schema, error) {
+	meta, err := sourceClient.Metadata(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while getting plugin metadata: %w", err)
+	}
+
+	jsonSchema := meta.ExternalRequest.Payload.JSONSchema
+	var loader gojsonschema.JSONLoader
+	switch {
+	case jsonSchema.Value != "":
+		loader = gojsonschema.NewStringLoader(jsonSchema.Value)
+	case jsonSchema.RefURL != "":
+		loader = gojsonschema.NewReferenceLoader(jsonSchema.RefURL)
+	default:
+		return nil, nil
+	}
+
+	schema, err := gojsonschema.NewSchema(loader)
+	if err != nil {
+		return nil, fmt.Errorf("while compiling JSON schema: %w", err)
+	}
+	return schema, nil
+}
+
+// Validate returns payloadValidationError if a given payload doesn't conform to the plugin's JSON schema.
+func (v *payloadValidator) Validate(ctx context.Context, pluginName string, payload []byte) error {
+	schema := v.getSchema(ctx, pluginName)
+	if schema == nil {
+		return nil
+	}
+
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(payload))
+	if err != nil {
+		return &payloadValidationError{
+			pluginName: pluginName,
+			fields: []PayloadFieldError{
+				{Field: "(root)", Description: fmt.Sprintf("Invalid JSON: %s", err.Error())},
+			},
+		}
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	validationErr := &payloadValidationError{pluginName: pluginName}
+	for _, resultErr := range result.Errors() {
+		validationErr.fields = append(validationErr.fields, PayloadFieldError{
+			Field:       resultErr.Field(),
+			Description: resultErr.Description(),
+		})
+	}
+	return validationErr
+}
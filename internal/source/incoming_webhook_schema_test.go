@@ -0,0 +1,167 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+const messageSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "message": {
+      "type": "string"
+    }
+  },
+  "required": [
+    "message"
+  ]
+}`
+
+func TestPayloadValidator(t *testing.T) {
+	// given
+	sources := fakeSourceGetter{
+		"botkube/cm-watcher": fakeMetadataSource{schema: messageSchema},
+		"botkube/keptn":      fakeMetadataSource{},
+	}
+	started := map[string]StartedSources{
+		"cm": {false: {PluginName: "botkube/cm-watcher"}},
+		"keptn": {
+			false: {PluginName: "botkube/keptn"},
+			true:  {PluginName: "botkube/keptn"},
+		},
+		"missing": {false: {PluginName: "botkube/missing"}},
+	}
+
+	validator := newPayloadValidator(context.Background(), loggerx.NewNoop(), sources, started)
+
+	tests := []struct {
+		name       string
+		pluginName string
+		payload    string
+		expFields  []PayloadFieldError
+	}{
+		{
+			name:       "Valid payload",
+			pluginName: "botkube/cm-watcher",
+			payload:    `{"message": "hello"}`,
+		},
+		{
+			name:       "Missing required field",
+			pluginName: "botkube/cm-watcher",
+			payload:    `{"msg": 1}`,
+			expFields: []PayloadFieldError{
+				{Field: "(root)", Description: "message is required"},
+			},
+		},
+		{
+			name:       "Invalid field type",
+			pluginName: "botkube/cm-watcher",
+			payload:    `{"message": 1}`,
+			expFields: []PayloadFieldError{
+				{Field: "message", Description: "Invalid type. Expected: string, given: integer"},
+			},
+		},
+		{
+			name:       "Plugin without schema",
+			pluginName: "botkube/keptn",
+			payload:    `not a JSON`,
+		},
+		{
+			name:       "Plugin which failed to return schema",
+			pluginName: "botkube/missing",
+			payload:    `{}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			err := validator.Validate(context.Background(), tc.pluginName, []byte(tc.payload))
+
+			// then
+			if tc.expFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *payloadValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tc.expFields, validationErr.fields)
+		})
+	}
+}
+
+func TestPayloadValidatorInvalidJSON(t *testing.T) {
+	// given
+	sources := fakeSourceGetter{
+		"botkube/cm-watcher": fakeMetadataSource{schema: messageSchema},
+	}
+	started := map[string]StartedSources{
+		"cm": {false: {PluginName: "botkube/cm-watcher"}},
+	}
+	validator := newPayloadValidator(context.Background(), loggerx.NewNoop(), sources, started)
+
+	// when
+	err := validator.Validate(context.Background(), "botkube/cm-watcher", []byte(`{"message":`))
+
+	// then
+	var validationErr *payloadValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.fields, 1)
+	assert.Equal(t, "(root)", validationErr.fields[0].Field)
+	assert.Contains(t, validationErr.fields[0].Description, "Invalid JSON")
+}
+
+func TestPayloadValidatorRecompilesSchemaOfRestartedPlugin(t *testing.T) {
+	// given
+	sources := fakeSourceGetter{
+		"botkube/cm-watcher": fakeMetadataSource{},
+	}
+	started := map[string]StartedSources{
+		"cm": {false: {PluginName: "botkube/cm-watcher"}},
+	}
+	validator := newPayloadValidator(context.Background(), loggerx.NewNoop(), sources, started)
+	require.NoError(t, validator.Validate(context.Background(), "botkube/cm-watcher", []byte(`{}`)))
+
+	// when
+	sources["botkube/cm-watcher"] = fakeMetadataSource{schema: messageSchema}
+	err := validator.Validate(context.Background(), "botkube/cm-watcher", []byte(`{}`))
+
+	// then
+	var validationErr *payloadValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []PayloadFieldError{{Field: "(root)", Description: "message is required"}}, validationErr.fields)
+}
+
+type fakeSourceGetter map[string]source.Source
+
+func (f fakeSourceGetter) GetSource(name string) (source.Source, error) {
+	src, found := f[name]
+	if !found {
+		return nil, fmt.Errorf("source %q not found", name)
+	}
+	return src, nil
+}
+
+type fakeMetadataSource struct {
+	source.Source
+	schema string
+}
+
+func (f fakeMetadataSource) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		ExternalRequest: api.ExternalRequestMetadata{
+			Payload: api.ExternalRequestPayload{
+				JSONSchema: api.JSONSchema{Value: f.schema},
+			},
+		},
+	}, nil
+}
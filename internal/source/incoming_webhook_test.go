@@ -0,0 +1,185 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestIncomingWebhookRouter(t *testing.T) {
+	// given
+	tests := []struct {
+		name      string
+		path      string
+		body      string
+		expCode   int
+		expBody   string
+		expCalled []string
+	}{
+		{
+			name:      "Single payload",
+			path:      "/sources/v1/cm",
+			body:      `{"message": "hello"}`,
+			expCode:   http.StatusOK,
+			expBody:   `{"success":true}`,
+			expCalled: []string{`{"message": "hello"}`},
+		},
+		{
+			name:    "Unknown source",
+			path:    "/sources/v1/other",
+			body:    `{}`,
+			expCode: http.StatusNotFound,
+			expBody: `{"error":"source \"other\" not found"}`,
+		},
+		{
+			name:      "Batch",
+			path:      "/sources/v1/cm/batch",
+			body:      `[{"message": "hello"}, {"message": "fail"}]`,
+			expCode:   http.StatusInternalServerError,
+			expBody:   `{"success":false,"items":[{"index":0,"success":true},{"index":1,"success":false,"error":"while dispatching external request: 1 error occurred:\n\t* dispatch failed"}]}`,
+			expCalled: []string{`{"message": "hello"}`, `{"message": "fail"}`},
+		},
+		{
+			name:    "Batch with invalid payload",
+			path:    "/sources/v1/cm/batch",
+			body:    `[{"message": "hello"}, {"msg": "hello"}]`,
+			expCode: http.StatusBadRequest,
+			expBody: `{"error":"payload doesn't conform to the \"botkube/cm-watcher\" plugin JSON schema","fields":[{"field":"[1].(root)","description":"message is required"}]}`,
+		},
+		{
+			name:    "Batch which is not an array",
+			path:    "/sources/v1/cm/batch",
+			body:    `{"message": "hello"}`,
+			expCode: http.StatusBadRequest,
+			expBody: `{"error":"Batch request body must be a JSON array of payloads"}`,
+		},
+		{
+			name:    "Too large body",
+			path:    "/sources/v1/cm",
+			body:    fmt.Sprintf(`{"message": %q}`, strings.Repeat("a", 64)),
+			expCode: http.StatusRequestEntityTooLarge,
+			expBody: `{"error":"request body exceeds the limit of 64 bytes"}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatcher := &fakeExternalRequestDispatcher{}
+			router := fixIncomingWebhookRouter(t, dispatcher)
+
+			// when
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body)))
+
+			// then
+			assert.Equal(t, tc.expCode, rec.Code)
+			assert.JSONEq(t, tc.expBody, rec.Body.String())
+			assert.Equal(t, tc.expCalled, dispatcher.Payloads())
+		})
+	}
+}
+
+func TestIncomingWebhookRouterAsync(t *testing.T) {
+	// given
+	dispatcher := &fakeExternalRequestDispatcher{}
+	router := fixIncomingWebhookRouter(t, dispatcher)
+
+	// when
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sources/v1/cm/batch?async=true", strings.NewReader(`[{"message": "hello"}, {"message": "fail"}]`)))
+
+	// then
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	var accepted struct {
+		RequestID string `json:"requestID"`
+		StatusURL string `json:"statusURL"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &accepted))
+	assert.NotEmpty(t, accepted.RequestID)
+
+	var status WebhookRequest
+	require.Eventually(t, func() bool {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, accepted.StatusURL, nil))
+		if rec.Code != http.StatusOK {
+			return false
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		return status.Status != WebhookRequestPending
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, WebhookRequestFailed, status.Status)
+	assert.Equal(t, "cm", status.SourceName)
+	require.Len(t, status.Items, 2)
+	assert.True(t, status.Items[0].Success)
+	assert.False(t, status.Items[1].Success)
+
+	// when
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sources/v1/requests/unknown", nil))
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestIsAsyncRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/sources/v1/cm", nil)
+	assert.False(t, isAsyncRequest(req))
+
+	req = httptest.NewRequest(http.MethodPost, "/sources/v1/cm?async=true", nil)
+	assert.True(t, isAsyncRequest(req))
+
+	req = httptest.NewRequest(http.MethodPost, "/sources/v1/cm", nil)
+	req.Header.Set("Prefer", "return=minimal, respond-async")
+	assert.True(t, isAsyncRequest(req))
+}
+
+func fixIncomingWebhookRouter(t *testing.T, dispatcher externalRequestDispatcher) http.Handler {
+	t.Helper()
+
+	started := map[string]StartedSources{
+		"cm": {false: {PluginName: "botkube/cm-watcher"}},
+	}
+	validator := newPayloadValidator(context.Background(), loggerx.NewNoop(), fakeSourceGetter{
+		"botkube/cm-watcher": fakeMetadataSource{schema: messageSchema},
+	}, started)
+	auth := newWebhookAuthenticator(loggerx.NewNoop(), nil, nil)
+
+	cfg := &config.Config{}
+	cfg.Plugins.IncomingWebhook.MaxBodySize = 64
+	return incomingWebhookRouter(context.Background(), loggerx.NewNoop(), cfg, dispatcher, started, auth, validator)
+}
+
+type fakeExternalRequestDispatcher struct {
+	mu       sync.Mutex
+	payloads []string
+}
+
+func (f *fakeExternalRequestDispatcher) DispatchExternalRequest(dispatch ExternalRequestDispatch) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.payloads = append(f.payloads, string(dispatch.payload))
+	if strings.Contains(string(dispatch.payload), "fail") {
+		return errors.New("dispatch failed")
+	}
+	return nil
+}
+
+func (f *fakeExternalRequestDispatcher) Payloads() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.payloads
+}
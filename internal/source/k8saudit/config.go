@@ -0,0 +1,163 @@
+package k8saudit
+
+import (
+	"fmt"
+	"strings"
+
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+// anyValue matches all verbs, API groups or resources.
+const anyValue = "*"
+
+// coreAPIGroup is the name of the core Kubernetes API group.
+const coreAPIGroup = ""
+
+// Config holds the audit source configuration.
+type Config struct {
+	// Stages defines audit event stages which are processed. Other stages are ignored to not report the same request multiple times.
+	Stages []auditv1.Stage `yaml:"stages"`
+	// Rules defines which audit events are reported. An event is reported if it matches at least one rule.
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule defines criteria for audit events.
+type Rule struct {
+	// Name is displayed as the notification header.
+	Name string `yaml:"name"`
+	// Verbs holds request verbs, e.g. 'create' or 'delete'. Empty list or '*' matches all verbs.
+	Verbs []string `yaml:"verbs"`
+	// APIGroups holds API groups of the resources, e.g. 'rbac.authorization.k8s.io'. '' matches the core API group.
+	// Empty list or '*' matches all API groups, so resources with the same name from different groups are matched too.
+	APIGroups []string `yaml:"apiGroups"`
+	// Resources holds resources with optional subresources, e.g. 'namespaces' or 'pods/exec'. Empty list or '*' matches all resources.
+	Resources []string `yaml:"resources"`
+	// Users holds constraints for the authenticated user name. If not specified, all users are matched.
+	Users *config.RegexConstraints `yaml:"users"`
+	// Namespaces holds constraints for the namespace of the requested object. If not specified, all namespaces are matched.
+	Namespaces *config.RegexConstraints `yaml:"namespaces"`
+}
+
+var defaultConfig = Config{
+	Stages: []auditv1.Stage{auditv1.StageResponseComplete},
+	Rules: []Rule{
+		{
+			Name: "Exec into Pod",
+			// kubectl uses 'create' for SPDY and 'get' for WebSocket connections
+			Verbs:     []string{"create", "get"},
+			APIGroups: []string{coreAPIGroup},
+			Resources: []string{"pods/exec", "pods/attach"},
+			Users:     humanUsers(),
+		},
+		{
+			Name:      "Namespace deleted",
+			Verbs:     []string{"delete"},
+			APIGroups: []string{coreAPIGroup},
+			Resources: []string{"namespaces"},
+			Users:     humanUsers(),
+		},
+		{
+			Name:      "RBAC modified",
+			Verbs:     []string{"create", "update", "patch", "delete"},
+			APIGroups: []string{"rbac.authorization.k8s.io"},
+			Resources: []string{"roles", "rolebindings", "clusterroles", "clusterrolebindings"},
+			Users:     humanUsers(),
+		},
+	},
+}
+
+func humanUsers() *config.RegexConstraints {
+	return &config.RegexConstraints{
+		Include: []string{".*"},
+		Exclude: []string{"system:.*"},
+	}
+}
+
+// MergeConfigs merges all input configuration. User-defined rules replace the default ones.
+func MergeConfigs(configs []*source.Config) (Config, error) {
+	var out Config
+	if err := plugin.MergeSourceConfigsWithDefaults(defaultConfig, configs, &out); err != nil {
+		return Config{}, err
+	}
+	return out, nil
+}
+
+// Match returns the first rule matching a given audit event.
+func (c Config) Match(event auditv1.Event) (Rule, bool, error) {
+	if !containsStage(c.Stages, event.Stage) {
+		return Rule{}, false, nil
+	}
+
+	for idx, rule := range c.Rules {
+		matched, err := rule.Matches(event)
+		if err != nil {
+			return Rule{}, false, fmt.Errorf("while matching rules[%d]: %w", idx, err)
+		}
+		if matched {
+			return rule, true, nil
+		}
+	}
+	return Rule{}, false, nil
+}
+
+// Matches returns true if a given audit event matches all rule criteria.
+func (r Rule) Matches(event auditv1.Event) (bool, error) {
+	if !matchesAny(r.Verbs, event.Verb) {
+		return false, nil
+	}
+
+	var resource, apiGroup, namespace string
+	if ref := event.ObjectRef; ref != nil {
+		apiGroup = ref.APIGroup
+		resource = ref.Resource
+		if ref.Subresource != "" {
+			resource = fmt.Sprintf("%s/%s", ref.Resource, ref.Subresource)
+		}
+		namespace = ref.Namespace
+	}
+	if !matchesAny(r.APIGroups, apiGroup) || !matchesAny(r.Resources, resource) {
+		return false, nil
+	}
+
+	matched, err := isAllowed(r.Users, event.User.Username)
+	if err != nil || !matched {
+		return false, err
+	}
+
+	return isAllowed(r.Namespaces, namespace)
+}
+
+func matchesAny(expected []string, value string) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	for _, exp := range expected {
+		if exp == anyValue || strings.EqualFold(exp, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowed(constraints *config.RegexConstraints, value string) (bool, error) {
+	if constraints == nil || !constraints.AreConstraintsDefined() {
+		return true, nil
+	}
+	return constraints.IsAllowed(value)
+}
+
+func containsStage(stages []auditv1.Stage, stage auditv1.Stage) bool {
+	if len(stages) == 0 {
+		return true
+	}
+	for _, s := range stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package k8saudit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"github.com/kubeshop/botkube/pkg/api"
+)
+
+// maxSections limits the number of events rendered in a single message, as a single batch may contain many matched events.
+const maxSections = 10
+
+// MatchedEvent holds an audit event together with the name of the rule it matched.
+type MatchedEvent struct {
+	RuleName string
+	Event    auditv1.Event
+}
+
+// MessageBuilder builds messages for audit events.
+type MessageBuilder struct {
+	clusterName string
+}
+
+// NewMessageBuilder returns a new MessageBuilder instance.
+func NewMessageBuilder(clusterName string) *MessageBuilder {
+	return &MessageBuilder{
+		clusterName: clusterName,
+	}
+}
+
+// FromEvents returns a single message for a given audit events.
+func (m *MessageBuilder) FromEvents(events []MatchedEvent) api.Message {
+	var msg api.Message
+	for idx, matched := range events {
+		ts := matched.Event.StageTimestamp.UTC()
+		if ts.After(msg.Timestamp) {
+			msg.Timestamp = ts
+		}
+
+		if idx >= maxSections {
+			continue
+		}
+		msg.Sections = append(msg.Sections, m.eventSection(matched))
+	}
+
+	if skipped := len(events) - maxSections; skipped > 0 {
+		last := &msg.Sections[len(msg.Sections)-1]
+		last.Context = append(last.Context, api.ContextItem{
+			Text: fmt.Sprintf("%d more matching audit event(s) omitted.", skipped),
+		})
+	}
+
+	if len(msg.Sections) == 1 {
+		msg.Type = api.NonInteractiveSingleSection
+	}
+
+	return msg
+}
+
+func (m *MessageBuilder) eventSection(matched MatchedEvent) api.Section {
+	event := matched.Event
+
+	var resource, apiGroup, object, namespace string
+	if ref := event.ObjectRef; ref != nil {
+		apiGroup = ref.APIGroup
+		resource = ref.Resource
+		if ref.Subresource != "" {
+			resource = fmt.Sprintf("%s/%s", ref.Resource, ref.Subresource)
+		}
+		namespace = ref.Namespace
+		object = ref.Name
+		if namespace != "" && object != "" {
+			object = fmt.Sprintf("%s/%s", namespace, object)
+		}
+	}
+
+	section := api.Section{
+		Base: api.Base{
+			Header:      fmt.Sprintf("🛡️ %s", matched.RuleName),
+			Description: m.summary(event.User.Username, event.Verb, resource, object),
+		},
+	}
+
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "User", event.User.Username)
+	if event.ImpersonatedUser != nil {
+		section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Impersonated user", event.ImpersonatedUser.Username)
+	}
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Verb", event.Verb)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Resource", resource)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "API group", apiGroup)
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Namespace", namespace)
+	if event.ObjectRef != nil {
+		section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Name", event.ObjectRef.Name)
+	}
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Source IPs", strings.Join(event.SourceIPs, ", "))
+	if event.ResponseStatus != nil && event.ResponseStatus.Code != 0 {
+		section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Response code", fmt.Sprint(event.ResponseStatus.Code))
+	}
+	section.TextFields = appendTextFieldIfNotEmpty(section.TextFields, "Cluster", m.clusterName)
+
+	if !event.StageTimestamp.IsZero() {
+		section.Context = append(section.Context, api.ContextItem{
+			Text: fmt.Sprintf("Audit ID %s at %s", event.AuditID, event.StageTimestamp.UTC().Format(time.RFC3339)),
+		})
+	}
+
+	return section
+}
+
+// summary returns a short event description, e.g. "`jane` create pods/exec default/nginx".
+func (m *MessageBuilder) summary(user, verb, resource, object string) string {
+	var parts []string
+	if user != "" {
+		parts = append(parts, fmt.Sprintf("`%s`", user))
+	}
+	for _, item := range []string{verb, resource, object} {
+		if item == "" {
+			continue
+		}
+		parts = append(parts, item)
+	}
+	return strings.Join(parts, " ")
+}
+
+func appendTextFieldIfNotEmpty(fields api.TextFields, title, value string) api.TextFields {
+	if value == "" {
+		return fields
+	}
+	return append(fields, api.TextField{
+		Key:   title,
+		Value: value,
+	})
+}
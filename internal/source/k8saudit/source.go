@@ -0,0 +1,113 @@
+package k8saudit
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+var _ source.Source = (*Source)(nil)
+
+var (
+	//go:embed config_schema.json
+	configJSONSchema string
+
+	//go:embed webhook_schema.json
+	webhookJSONSchema string
+)
+
+const (
+	// PluginName is the name of the Kubernetes audit log Botkube plugin.
+	PluginName = "kubernetes-audit"
+
+	description = "Get notifications about Kubernetes API server requests, such as exec into Pods or RBAC changes, based on audit log webhook batches."
+)
+
+// Source Kubernetes audit log source plugin data structure.
+type Source struct {
+	pluginVersion string
+
+	source.StreamUnimplemented
+}
+
+// NewSource returns a new instance of Source.
+func NewSource(version string) *Source {
+	return &Source{
+		pluginVersion: version,
+	}
+}
+
+// Metadata returns metadata of Kubernetes audit log source.
+func (s *Source) Metadata(_ context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{
+		Version:     s.pluginVersion,
+		Description: description,
+		JSONSchema: api.JSONSchema{
+			Value: configJSONSchema,
+		},
+		ExternalRequest: api.ExternalRequestMetadata{
+			Payload: api.ExternalRequestPayload{
+				JSONSchema: api.JSONSchema{
+					Value: webhookJSONSchema,
+				},
+			},
+		},
+	}, nil
+}
+
+// HandleExternalRequest filters the audit events batch sent by the API server audit webhook backend
+// and returns a single message for all matched events. If no event is matched, an empty event is returned.
+func (s *Source) HandleExternalRequest(_ context.Context, in source.ExternalRequestInput) (source.ExternalRequestOutput, error) {
+	cfg, err := MergeConfigs([]*source.Config{in.Config})
+	if err != nil {
+		return source.ExternalRequestOutput{}, fmt.Errorf("while merging input configs: %w", err)
+	}
+
+	var events auditv1.EventList
+	if err := json.Unmarshal(in.Payload, &events); err != nil {
+		return source.ExternalRequestOutput{}, fmt.Errorf("while unmarshaling payload: %w", err)
+	}
+
+	var matched []MatchedEvent
+	for _, event := range events.Items {
+		rule, ok, err := cfg.Match(event)
+		if err != nil {
+			return source.ExternalRequestOutput{}, err
+		}
+		if !ok {
+			continue
+		}
+		matched = append(matched, MatchedEvent{RuleName: rule.Name, Event: event})
+	}
+
+	if len(matched) == 0 {
+		return source.ExternalRequestOutput{}, nil
+	}
+
+	out := auditv1.EventList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "EventList",
+			APIVersion: auditv1.SchemeGroupVersion.String(),
+		},
+	}
+	for _, m := range matched {
+		out.Items = append(out.Items, m.Event)
+	}
+
+	return source.ExternalRequestOutput{
+		Event: source.Event{
+			Message:   NewMessageBuilder(in.Context.ClusterName).FromEvents(matched),
+			RawObject: out,
+			AnalyticsLabels: map[string]interface{}{
+				"eventsCount": len(matched),
+			},
+		},
+	}, nil
+}
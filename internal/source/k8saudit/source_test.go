@@ -0,0 +1,185 @@
+package k8saudit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+const eventsBatch = `{
+  "kind": "EventList",
+  "apiVersion": "audit.k8s.io/v1",
+  "items": [
+    {
+      "auditID": "a1",
+      "stage": "RequestReceived",
+      "verb": "create",
+      "user": {"username": "jane@example.com"},
+      "objectRef": {"resource": "pods", "subresource": "exec", "namespace": "prod", "name": "nginx"},
+      "stageTimestamp": "2023-10-05T12:00:00.000000Z"
+    },
+    {
+      "auditID": "a1",
+      "stage": "ResponseComplete",
+      "verb": "create",
+      "user": {"username": "jane@example.com"},
+      "sourceIPs": ["10.0.0.1"],
+      "objectRef": {"resource": "pods", "subresource": "exec", "namespace": "prod", "name": "nginx"},
+      "responseStatus": {"code": 101},
+      "stageTimestamp": "2023-10-05T12:00:01.000000Z"
+    },
+    {
+      "auditID": "a2",
+      "stage": "ResponseComplete",
+      "verb": "update",
+      "user": {"username": "system:serviceaccount:kube-system:clusterrole-aggregation-controller"},
+      "objectRef": {"resource": "clusterroles", "apiGroup": "rbac.authorization.k8s.io", "name": "admin"},
+      "stageTimestamp": "2023-10-05T12:00:02.000000Z"
+    },
+    {
+      "auditID": "a4",
+      "stage": "ResponseComplete",
+      "verb": "delete",
+      "user": {"username": "jane@example.com"},
+      "objectRef": {"resource": "roles", "apiGroup": "example.com", "namespace": "prod", "name": "admin"},
+      "stageTimestamp": "2023-10-05T12:00:04.000000Z"
+    },
+    {
+      "auditID": "a3",
+      "stage": "ResponseComplete",
+      "verb": "get",
+      "user": {"username": "jane@example.com"},
+      "objectRef": {"resource": "pods", "namespace": "prod", "name": "nginx"},
+      "stageTimestamp": "2023-10-05T12:00:03.000000Z"
+    }
+  ]
+}`
+
+func TestHandleExternalRequestDefaultRules(t *testing.T) {
+	// given
+	in := source.ExternalRequestInput{
+		Payload: []byte(eventsBatch),
+		Context: source.ExternalRequestInputContext{
+			CommonSourceContext: source.CommonSourceContext{
+				ClusterName: "prod",
+			},
+		},
+	}
+
+	expMsg := api.Message{
+		Type:      api.NonInteractiveSingleSection,
+		Timestamp: time.Date(2023, 10, 5, 12, 0, 1, 0, time.UTC),
+		Sections: []api.Section{
+			{
+				Base: api.Base{
+					Header:      "🛡️ Exec into Pod",
+					Description: "`jane@example.com` create pods/exec prod/nginx",
+				},
+				TextFields: api.TextFields{
+					{Key: "User", Value: "jane@example.com"},
+					{Key: "Verb", Value: "create"},
+					{Key: "Resource", Value: "pods/exec"},
+					{Key: "Namespace", Value: "prod"},
+					{Key: "Name", Value: "nginx"},
+					{Key: "Source IPs", Value: "10.0.0.1"},
+					{Key: "Response code", Value: "101"},
+					{Key: "Cluster", Value: "prod"},
+				},
+				Context: api.ContextItems{
+					{Text: "Audit ID a1 at 2023-10-05T12:00:01Z"},
+				},
+			},
+		},
+	}
+
+	// when
+	out, err := NewSource("dev").HandleExternalRequest(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expMsg, out.Event.Message)
+
+	events, ok := out.Event.RawObject.(auditv1.EventList)
+	require.True(t, ok)
+	require.Len(t, events.Items, 1)
+	assert.EqualValues(t, "a1", events.Items[0].AuditID)
+}
+
+func TestHandleExternalRequestCustomRules(t *testing.T) {
+	// given
+	in := source.ExternalRequestInput{
+		Payload: []byte(eventsBatch),
+		Config: &source.Config{
+			RawYAML: []byte(heredoc.Doc(`
+				rules:
+				  - name: "Pods in prod read"
+				    verbs: ["get"]
+				    resources: ["pods"]
+				    namespaces:
+				      include: ["prod"]`)),
+		},
+	}
+
+	// when
+	out, err := NewSource("dev").HandleExternalRequest(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, out.Event.Message.Sections, 1)
+	assert.Equal(t, "🛡️ Pods in prod read", out.Event.Message.Sections[0].Header)
+}
+
+func TestHandleExternalRequestMatchesAPIGroup(t *testing.T) {
+	// given
+	in := source.ExternalRequestInput{
+		Payload: []byte(eventsBatch),
+		Config: &source.Config{
+			RawYAML: []byte(heredoc.Doc(`
+				rules:
+				  - name: "Custom roles deleted"
+				    verbs: ["delete"]
+				    apiGroups: ["example.com"]
+				    resources: ["roles"]`)),
+		},
+	}
+
+	// when
+	out, err := NewSource("dev").HandleExternalRequest(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, out.Event.Message.Sections, 1)
+	section := out.Event.Message.Sections[0]
+	assert.Equal(t, "🛡️ Custom roles deleted", section.Header)
+	assert.Contains(t, section.TextFields, api.TextField{Key: "API group", Value: "example.com"})
+}
+
+func TestHandleExternalRequestNoMatches(t *testing.T) {
+	// given
+	in := source.ExternalRequestInput{
+		Payload: []byte(eventsBatch),
+		Config: &source.Config{
+			RawYAML: []byte(heredoc.Doc(`
+				rules:
+				  - name: "Namespace deleted"
+				    verbs: ["delete"]
+				    resources: ["namespaces"]`)),
+		},
+	}
+
+	// when
+	out, err := NewSource("dev").HandleExternalRequest(context.Background(), in)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, out.Event.Message.IsEmpty())
+	assert.Nil(t, out.Event.RawObject)
+}
@@ -81,8 +81,9 @@ type Commands struct {
 
 // Recommendations contains configuration for various recommendation insights.
 type Recommendations struct {
-	Ingress IngressRecommendations `yaml:"ingress"`
-	Pod     PodRecommendations     `yaml:"pod"`
+	Ingress  IngressRecommendations  `yaml:"ingress"`
+	Pod      PodRecommendations      `yaml:"pod"`
+	Resource ResourceRecommendations `yaml:"resource"`
 }
 
 // IngressRecommendations contains configuration for ingress recommendations.
@@ -103,6 +104,14 @@ type PodRecommendations struct {
 	LabelsSet *bool `yaml:"labelsSet,omitempty"`
 }
 
+// ResourceRecommendations contains configuration for recommendations which apply to all Kubernetes resources.
+type ResourceRecommendations struct {
+	// DeprecatedAPIVersion notifies about resources created or updated with deprecated or removed API versions.
+	// The `kubectl.kubernetes.io/last-applied-configuration` annotation is checked as well.
+	// It applies only to resources watched by the source, as configured in the Resources list.
+	DeprecatedAPIVersion *bool `yaml:"deprecatedAPIVersion,omitempty"`
+}
+
 // KubernetesEvent contains configuration for Kubernetes events.
 type KubernetesEvent struct {
 	Reason  RegexConstraints             `yaml:"reason"`
@@ -272,6 +281,9 @@ func MergeConfigs(configs []*source.Config) (Config, error) {
 				BackendServiceValid: ptr.FromType(false),
 				TLSSecretValid:      ptr.FromType(false),
 			},
+			Resource: ResourceRecommendations{
+				DeprecatedAPIVersion: ptr.FromType(false),
+			},
 		},
 		Commands: Commands{
 			Verbs:     []string{"api-resources", "api-versions", "cluster-info", "describe", "explain", "get", "logs", "top"},
@@ -58,5 +58,9 @@ func (f *Factory) recommendationsForConfig(cfg config.Recommendations) []Recomme
 		recommendations = append(recommendations, NewIngressTLSSecretValid(f.dynamicCli))
 	}
 
+	if ptr.ToValue(cfg.Resource.DeprecatedAPIVersion) {
+		recommendations = append(recommendations, NewResourceDeprecatedAPIVersion())
+	}
+
 	return recommendations
 }
@@ -9,6 +9,7 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 
 	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
@@ -50,10 +51,10 @@ func (f *IngressTLSSecretValid) Do(ctx context.Context, event event.Event) (Resu
 	var warningMsgs []string
 	errs := multierror.New()
 
-	for _, tls := range ingress.Spec.TLS {
-		exists, err := f.validateSecretExists(ctx, f.dynamicCli, tls.SecretName, ingress.Namespace)
+	for _, secret := range IngressTLSSecretRefs(ingress) {
+		exists, err := f.validateSecretExists(ctx, f.dynamicCli, secret.Name, secret.Namespace)
 		if err != nil {
-			warningMsgs = append(warningMsgs, fmt.Sprintf("TLS secret '%s' does not exist", tls.SecretName))
+			warningMsgs = append(warningMsgs, fmt.Sprintf("TLS secret '%s' does not exist", secret.Name))
 		}
 
 		if err != nil {
@@ -61,13 +62,25 @@ func (f *IngressTLSSecretValid) Do(ctx context.Context, event event.Event) (Resu
 		}
 
 		if !exists {
-			warningMsgs = append(warningMsgs, fmt.Sprintf("TLS secret '%s' referred in Ingress '%s/%s' does not exist.", tls.SecretName, ingress.Namespace, ingress.Name))
+			warningMsgs = append(warningMsgs, fmt.Sprintf("TLS secret '%s' referred in Ingress '%s/%s' does not exist.", secret.Name, ingress.Namespace, ingress.Name))
 		}
 	}
 
 	return Result{Warnings: warningMsgs}, errs.ErrorOrNil()
 }
 
+// IngressTLSSecretRefs returns TLS Secrets referenced by a given Ingress. Secrets are resolved in the Ingress namespace.
+func IngressTLSSecretRefs(ingress networkingv1.Ingress) []types.NamespacedName {
+	var out []types.NamespacedName
+	for _, tls := range ingress.Spec.TLS {
+		out = append(out, types.NamespacedName{
+			Namespace: ingress.Namespace,
+			Name:      tls.SecretName,
+		})
+	}
+	return out
+}
+
 func (f *IngressTLSSecretValid) validateSecretExists(ctx context.Context, dynamicCli dynamic.Interface, name, namespace string) (bool, error) {
 	secretGVR := schema.GroupVersionResource{
 		Version:  "v1",
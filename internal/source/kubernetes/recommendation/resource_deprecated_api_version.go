@@ -0,0 +1,59 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/internal/kubex/deprecation"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/k8sutil"
+)
+
+const resourceDeprecatedAPIVersionName = "ResourceDeprecatedAPIVersion"
+
+// ResourceDeprecatedAPIVersion adds warnings if a resource uses deprecated or removed API version.
+type ResourceDeprecatedAPIVersion struct{}
+
+// NewResourceDeprecatedAPIVersion creates a new ResourceDeprecatedAPIVersion instance.
+func NewResourceDeprecatedAPIVersion() *ResourceDeprecatedAPIVersion {
+	return &ResourceDeprecatedAPIVersion{}
+}
+
+// Do executes the recommendation checks.
+func (f *ResourceDeprecatedAPIVersion) Do(_ context.Context, event event.Event) (Result, error) {
+	if (event.Type != config.CreateEvent && event.Type != config.UpdateEvent) || k8sutil.GetObjectTypeMetaData(event.Object).Kind == "Event" {
+		return Result{}, nil
+	}
+
+	unstrObj, ok := event.Object.(*unstructured.Unstructured)
+	if !ok {
+		return Result{}, fmt.Errorf("cannot convert %T into type %T", event.Object, unstrObj)
+	}
+
+	identifier := unstrObj.GetName()
+	if ns := unstrObj.GetNamespace(); ns != "" {
+		identifier = fmt.Sprintf("%s/%s", ns, identifier)
+	}
+
+	var warnings []string
+	for _, finding := range deprecation.Check(unstrObj) {
+		switch finding.Origin {
+		case deprecation.LastAppliedOrigin:
+			warnings = append(warnings, fmt.Sprintf("%s '%s' was applied with deprecated API version. %s", finding.API.Kind, identifier, finding.API.Description()))
+		default:
+			warnings = append(warnings, fmt.Sprintf("%s '%s' uses deprecated API version. %s", finding.API.Kind, identifier, finding.API.Description()))
+		}
+	}
+
+	return Result{
+		Warnings: warnings,
+	}, nil
+}
+
+// Name returns the recommendation name.
+func (f *ResourceDeprecatedAPIVersion) Name() string {
+	return resourceDeprecatedAPIVersionName
+}
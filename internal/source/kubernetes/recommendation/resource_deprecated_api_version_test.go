@@ -0,0 +1,88 @@
+package recommendation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubeshop/botkube/internal/source/kubernetes/config"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/event"
+	"github.com/kubeshop/botkube/internal/source/kubernetes/recommendation"
+)
+
+func TestResourceDeprecatedAPIVersion_Do(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiVersion  string
+		lastApplied string
+		eventType   config.EventType
+		expected    recommendation.Result
+	}{
+		{
+			name:        "Applied with deprecated API version",
+			apiVersion:  "autoscaling/v2",
+			lastApplied: `{"apiVersion":"autoscaling/v2beta2","kind":"HorizontalPodAutoscaler"}`,
+			eventType:   config.UpdateEvent,
+			expected: recommendation.Result{
+				Warnings: []string{
+					"HorizontalPodAutoscaler 'foo/api' was applied with deprecated API version. autoscaling/v2beta2 HorizontalPodAutoscaler is deprecated since v1.23 and removed in v1.26. Use autoscaling/v2 instead.",
+				},
+			},
+		},
+		{
+			name:       "Watched with deprecated API version",
+			apiVersion: "autoscaling/v2beta1",
+			eventType:  config.CreateEvent,
+			expected: recommendation.Result{
+				Warnings: []string{
+					"HorizontalPodAutoscaler 'foo/api' uses deprecated API version. autoscaling/v2beta1 HorizontalPodAutoscaler is deprecated since v1.22 and removed in v1.25. Use autoscaling/v2 instead.",
+				},
+			},
+		},
+		{
+			name:        "Up-to-date API version",
+			apiVersion:  "autoscaling/v2",
+			lastApplied: `{"apiVersion":"autoscaling/v2","kind":"HorizontalPodAutoscaler"}`,
+			eventType:   config.CreateEvent,
+			expected:    recommendation.Result{},
+		},
+		{
+			name:        "Ignored event type",
+			apiVersion:  "autoscaling/v2",
+			lastApplied: `{"apiVersion":"autoscaling/v2beta2","kind":"HorizontalPodAutoscaler"}`,
+			eventType:   config.DeleteEvent,
+			expected:    recommendation.Result{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			recomm := recommendation.NewResourceDeprecatedAPIVersion()
+
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(tc.apiVersion)
+			obj.SetKind("HorizontalPodAutoscaler")
+			obj.SetName("api")
+			obj.SetNamespace("foo")
+			if tc.lastApplied != "" {
+				obj.SetAnnotations(map[string]string{
+					"kubectl.kubernetes.io/last-applied-configuration": tc.lastApplied,
+				})
+			}
+
+			event, err := event.New(metav1.ObjectMeta{Name: "api", Namespace: "foo"}, obj, tc.eventType, "autoscaling/v2/horizontalpodautoscalers")
+			require.NoError(t, err)
+
+			// when
+			actual, err := recomm.Do(context.Background(), event)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
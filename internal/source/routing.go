@@ -0,0 +1,454 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/conversation"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+var (
+	severityFieldPaths  = []string{"level", "severity"}
+	namespaceFieldPaths = []string{"namespace", "metadata.namespace", "objectRef.namespace"}
+	kindFieldPaths      = []string{"kind", "objectRef.resource"}
+)
+
+// NotifierKey returns a key which identifies a given notifier within all communication groups.
+func NotifierKey(commGroupName string, platform config.CommPlatformIntegration) string {
+	return fmt.Sprintf("%s-%s", commGroupName, platform)
+}
+
+// Route contains targets of all routing rules which matched a given event within a single communication group.
+type Route struct {
+	Rules    []string
+	Channels []string
+	Sinks    []config.CommPlatformIntegration
+}
+
+// RoutingInput contains event details used to evaluate routing rules.
+type RoutingInput struct {
+	SourceName string
+	PluginName string
+	Event      any
+}
+
+// Router evaluates routing rules defined in communication groups.
+type Router struct {
+	log          logrus.FieldLogger
+	commGroups   []string
+	rules        map[string][]config.RoutingRule
+	notifiers    map[string]string
+	fieldRegexes map[string]*regexp.Regexp
+	ordering     map[string]*orderingGroups
+}
+
+// NewRouter returns a new Router instance.
+func NewRouter(log logrus.FieldLogger, communications map[string]config.Communications) (*Router, error) {
+	r := &Router{
+		log:          log,
+		rules:        map[string][]config.RoutingRule{},
+		notifiers:    map[string]string{},
+		fieldRegexes: map[string]*regexp.Regexp{},
+		ordering:     map[string]*orderingGroups{},
+	}
+
+	for _, name := range maputil.SortKeys(communications) {
+		comm := communications[name]
+		for _, platform := range enabledPlatforms(comm) {
+			r.notifiers[NotifierKey(name, platform)] = name
+		}
+		r.ordering[name] = newOrderingGroups(comm)
+
+		if len(comm.Routing) == 0 {
+			continue
+		}
+		r.commGroups = append(r.commGroups, name)
+		r.rules[name] = comm.Routing
+
+		for _, rule := range comm.Routing {
+			for _, expr := range rule.Match.Fields {
+				if _, ok := r.fieldRegexes[expr]; ok {
+					continue
+				}
+				// field values must fully match the expression
+				re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", expr))
+				if err != nil {
+					return nil, fmt.Errorf("while compiling regex %q for routing rule %q: %w", expr, rule.Name, err)
+				}
+				r.fieldRegexes[expr] = re
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// CommGroupForNotifier returns a communication group name for a given notifier key.
+func (r *Router) CommGroupForNotifier(key string) string {
+	if r == nil {
+		return ""
+	}
+	return r.notifiers[key]
+}
+
+// OrderingKey returns a key for a bot message sent from given sources, or routed to given channels.
+// Messages which can be posted to the same channel have the same key, so they are delivered in order.
+func (r *Router) OrderingKey(notifierKey string, sources, channels []string) string {
+	if r == nil {
+		return ""
+	}
+	groups, found := r.ordering[r.notifiers[notifierKey]]
+	if !found {
+		return ""
+	}
+
+	switch {
+	case len(channels) > 0:
+		return groups.key(channelOrderingNode(channels[0]))
+	case len(sources) > 0:
+		return groups.key(sourceOrderingNode(sources[0]))
+	default:
+		return ""
+	}
+}
+
+// Resolve evaluates routing rules for a given event. It returns routes for communication groups
+// where at least one rule matched. Groups that are not present in the output use source bindings.
+func (r *Router) Resolve(in RoutingInput) map[string]Route {
+	if r == nil || len(r.commGroups) == 0 {
+		return nil
+	}
+
+	event := toGenericMap(in.Event)
+	out := map[string]Route{}
+	for _, group := range r.commGroups {
+		var (
+			route   Route
+			matched bool
+		)
+		for _, rule := range r.rules[group] {
+			ok, err := r.matches(rule.Match, in, event)
+			if err != nil {
+				r.log.Errorf("while evaluating routing rule %q: %s", rule.Name, err.Error())
+				continue
+			}
+			if !ok {
+				continue
+			}
+
+			matched = true
+			route.Rules = append(route.Rules, rule.Name)
+			for _, channel := range rule.Channels {
+				channel, _ = conversation.NormalizeChannelIdentifier(channel)
+				route.Channels = appendIfMissing(route.Channels, channel)
+			}
+			for _, sink := range rule.Sinks {
+				route.Sinks = appendIfMissing(route.Sinks, sink)
+			}
+
+			if !rule.Continue {
+				break
+			}
+		}
+
+		if !matched {
+			continue
+		}
+		r.log.WithFields(logrus.Fields{
+			"commGroup": group,
+			"rules":     route.Rules,
+			"source":    in.SourceName,
+		}).Debug("Event matched routing rules")
+		out[group] = route
+	}
+
+	return out
+}
+
+func (r *Router) matches(match config.RoutingMatch, in RoutingInput, event map[string]any) (bool, error) {
+	if len(match.Sources) > 0 && !slices.Contains(match.Sources, in.SourceName) {
+		return false, nil
+	}
+	if len(match.Plugins) > 0 && !slices.Contains(match.Plugins, in.PluginName) {
+		return false, nil
+	}
+
+	if len(match.Severity) > 0 {
+		severity, _ := firstFieldValue(event, severityFieldPaths)
+		if !containsFold(match.Severity, severity) {
+			return false, nil
+		}
+	}
+
+	if match.Namespaces.AreConstraintsDefined() {
+		ns, _ := firstFieldValue(event, namespaceFieldPaths)
+		allowed, err := match.Namespaces.IsAllowed(ns)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	if match.Kinds.AreConstraintsDefined() {
+		kind, _ := firstFieldValue(event, kindFieldPaths)
+		allowed, err := match.Kinds.IsAllowed(kind)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	for _, path := range maputil.SortKeys(match.Fields) {
+		value, found := fieldValue(event, path)
+		if !found {
+			return false, nil
+		}
+		re, ok := r.fieldRegexes[match.Fields[path]]
+		if !ok {
+			return false, fmt.Errorf("regex for field %q was not compiled", path)
+		}
+		if !re.MatchString(value) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// toGenericMap converts a given event to a generic map, so its fields can be accessed by paths.
+func toGenericMap(in any) map[string]any {
+	if out, ok := in.(map[string]any); ok {
+		return out
+	}
+
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return nil
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+func firstFieldValue(event map[string]any, paths []string) (string, bool) {
+	for _, path := range paths {
+		if value, found := fieldValue(event, path); found {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// fieldValue returns a value for a given dot-separated path. Keys are matched case-insensitively,
+// so both `namespace` and `Namespace` are supported. Numeric segments are used as slice indexes.
+func fieldValue(event map[string]any, path string) (string, bool) {
+	var current any = event
+	for _, segment := range strings.Split(path, ".") {
+		switch obj := current.(type) {
+		case map[string]any:
+			val, found := obj[segment]
+			if !found {
+				for key, v := range obj {
+					if strings.EqualFold(key, segment) {
+						val, found = v, true
+						break
+					}
+				}
+			}
+			if !found {
+				return "", false
+			}
+			current = val
+		case []any:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(obj) {
+				return "", false
+			}
+			current = obj[idx]
+		default:
+			return "", false
+		}
+	}
+
+	switch val := current.(type) {
+	case nil:
+		return "", false
+	case string:
+		return val, true
+	case map[string]any, []any:
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return "", false
+		}
+		return string(raw), true
+	default:
+		return fmt.Sprint(val), true
+	}
+}
+
+func enabledPlatforms(comm config.Communications) []config.CommPlatformIntegration {
+	var out []config.CommPlatformIntegration
+	if comm.SocketSlack.Enabled {
+		out = append(out, config.SocketSlackCommPlatformIntegration)
+	}
+	if comm.CloudSlack.Enabled {
+		out = append(out, config.CloudSlackCommPlatformIntegration)
+	}
+	if comm.Mattermost.Enabled {
+		out = append(out, config.MattermostCommPlatformIntegration)
+	}
+	if comm.CloudTeams.Enabled {
+		out = append(out, config.CloudTeamsCommPlatformIntegration)
+	}
+	if comm.Discord.Enabled {
+		out = append(out, config.DiscordCommPlatformIntegration)
+	}
+	if comm.Webhook.Enabled {
+		out = append(out, config.WebhookCommPlatformIntegration)
+	}
+	if comm.Elasticsearch.Enabled {
+		out = append(out, config.ElasticsearchCommPlatformIntegration)
+	}
+	if comm.PagerDuty.Enabled {
+		out = append(out, config.PagerDutyCommPlatformIntegration)
+	}
+	return out
+}
+
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendIfMissing[T comparable](items []T, item T) []T {
+	if slices.Contains(items, item) {
+		return items
+	}
+	return append(items, item)
+}
+
+// orderingGroups joins sources with channels they can be posted to, using source bindings and routing rules.
+// Sources and channels which end up in the same group share the message ordering.
+// Groups are built once, so they are safe for concurrent reads.
+type orderingGroups struct {
+	parent map[string]string
+}
+
+func newOrderingGroups(comm config.Communications) *orderingGroups {
+	g := &orderingGroups{parent: map[string]string{}}
+
+	for channel, sources := range channelSourceBindings(comm) {
+		channelNode := channelOrderingNode(channel)
+		g.find(channelNode)
+		for _, src := range sources {
+			g.union(channelNode, sourceOrderingNode(src))
+		}
+	}
+
+	// rules with the continue flag merge their targets, so the channels of all rules end up in a single route
+	var continuedChannels []string
+	for _, rule := range comm.Routing {
+		if len(rule.Channels) == 0 {
+			continue
+		}
+		first := channelOrderingNode(rule.Channels[0])
+		g.find(first)
+		for _, channel := range rule.Channels[1:] {
+			g.union(first, channelOrderingNode(channel))
+		}
+		for _, src := range rule.Match.Sources {
+			g.union(first, sourceOrderingNode(src))
+		}
+		if rule.Continue || len(continuedChannels) > 0 {
+			continuedChannels = append(continuedChannels, rule.Channels[0])
+		}
+	}
+	for _, channel := range continuedChannels {
+		g.union(channelOrderingNode(continuedChannels[0]), channelOrderingNode(channel))
+	}
+
+	// flatten groups, so lookups don't modify the map
+	for node := range g.parent {
+		g.find(node)
+	}
+	return g
+}
+
+// key returns the group key for a given node. Unknown nodes form their own groups.
+func (g *orderingGroups) key(node string) string {
+	if root, found := g.parent[node]; found {
+		return root
+	}
+	return node
+}
+
+// find returns the representative node of a group for a given node.
+func (g *orderingGroups) find(node string) string {
+	parent, found := g.parent[node]
+	if !found {
+		g.parent[node] = node
+		return node
+	}
+	if parent == node {
+		return node
+	}
+	root := g.find(parent)
+	g.parent[node] = root
+	return root
+}
+
+func (g *orderingGroups) union(a, b string) {
+	rootA, rootB := g.find(a), g.find(b)
+	if rootA == rootB {
+		return
+	}
+	// keep the representative deterministic, so the keys are stable across agent restarts
+	if rootB < rootA {
+		rootA, rootB = rootB, rootA
+	}
+	g.parent[rootB] = rootA
+}
+
+func sourceOrderingNode(name string) string {
+	return "source:" + name
+}
+
+func channelOrderingNode(name string) string {
+	name, _ = conversation.NormalizeChannelIdentifier(name)
+	return "channel:" + name
+}
+
+// channelSourceBindings returns source bindings for all channels of bot platforms in a given communication group.
+func channelSourceBindings(comm config.Communications) map[string][]string {
+	out := map[string][]string{}
+	for _, ch := range comm.SocketSlack.Channels {
+		out[ch.Identifier()] = append(out[ch.Identifier()], ch.Bindings.Sources...)
+	}
+	for _, ch := range comm.CloudSlack.Channels {
+		out[ch.Identifier()] = append(out[ch.Identifier()], ch.Bindings.Sources...)
+	}
+	for _, ch := range comm.Mattermost.Channels {
+		out[ch.Identifier()] = append(out[ch.Identifier()], ch.Bindings.Sources...)
+	}
+	for _, ch := range comm.Discord.Channels {
+		out[ch.Identifier()] = append(out[ch.Identifier()], ch.Bindings.Sources...)
+	}
+	for _, team := range comm.CloudTeams.Teams {
+		for _, ch := range team.Channels {
+			out[ch.Identifier()] = append(out[ch.Identifier()], ch.Bindings.Sources...)
+		}
+	}
+	return out
+}
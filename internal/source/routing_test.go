@@ -0,0 +1,248 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/delivery"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestRouterResolve(t *testing.T) {
+	// given
+	comms := map[string]config.Communications{
+		"default-group": {
+			SocketSlack: config.SocketSlack{
+				Enabled: true,
+			},
+			Elasticsearch: config.Elasticsearch{
+				Enabled: true,
+			},
+			Routing: []config.RoutingRule{
+				{
+					Name: "prod-critical",
+					Match: config.RoutingMatch{
+						Severity: []string{"critical", "error"},
+						Namespaces: config.RegexConstraints{
+							Include: []string{"prod-.*"},
+						},
+					},
+					Channels: []string{"#incidents"},
+					Sinks:    []config.CommPlatformIntegration{config.ElasticsearchCommPlatformIntegration},
+				},
+				{
+					Name: "team-payments",
+					Match: config.RoutingMatch{
+						Plugins: []string{"botkube/kubernetes"},
+						Fields: map[string]string{
+							"metadata.labels.team": "payments",
+						},
+					},
+					Channels: []string{"payments"},
+					Continue: true,
+				},
+				{
+					Name:     "everything-else",
+					Channels: []string{"k8s-noise"},
+				},
+			},
+		},
+		"no-routing": {
+			SocketSlack: config.SocketSlack{
+				Enabled: true,
+			},
+		},
+	}
+
+	router, err := NewRouter(loggerx.NewNoop(), comms)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		event    any
+		expRoute Route
+	}{
+		{
+			name: "Critical production event",
+			event: map[string]any{
+				"Level":     "critical",
+				"Namespace": "prod-eu",
+				"Kind":      "Pod",
+			},
+			expRoute: Route{
+				Rules:    []string{"prod-critical"},
+				Channels: []string{"incidents"},
+				Sinks:    []config.CommPlatformIntegration{config.ElasticsearchCommPlatformIntegration},
+			},
+		},
+		{
+			name: "Event matching a rule with continue",
+			event: map[string]any{
+				"level": "info",
+				"metadata": map[string]any{
+					"namespace": "prod-eu",
+					"labels": map[string]any{
+						"team": "payments",
+					},
+				},
+			},
+			expRoute: Route{
+				Rules:    []string{"team-payments", "everything-else"},
+				Channels: []string{"payments", "k8s-noise"},
+			},
+		},
+		{
+			name: "Non-production error event",
+			event: struct {
+				Level     string
+				Namespace string
+			}{
+				Level:     "error",
+				Namespace: "dev",
+			},
+			expRoute: Route{
+				Rules:    []string{"everything-else"},
+				Channels: []string{"k8s-noise"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			routes := router.Resolve(RoutingInput{
+				SourceName: "k8s-events",
+				PluginName: "botkube/kubernetes",
+				Event:      tc.event,
+			})
+
+			// then
+			require.Len(t, routes, 1)
+			assert.Equal(t, tc.expRoute, routes["default-group"])
+		})
+	}
+}
+
+func TestRouterCommGroupForNotifier(t *testing.T) {
+	// given
+	comms := map[string]config.Communications{
+		"prod-group": {
+			Discord: config.Discord{
+				Enabled: true,
+			},
+			PagerDuty: config.PagerDuty{
+				Enabled: true,
+			},
+		},
+	}
+
+	// when
+	router, err := NewRouter(loggerx.NewNoop(), comms)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, "prod-group", router.CommGroupForNotifier(NotifierKey("prod-group", config.DiscordCommPlatformIntegration)))
+	assert.Equal(t, "prod-group", router.CommGroupForNotifier(NotifierKey("prod-group", config.PagerDutyCommPlatformIntegration)))
+	assert.Empty(t, router.CommGroupForNotifier(NotifierKey("prod-group", config.SocketSlackCommPlatformIntegration)))
+	assert.Nil(t, router.Resolve(RoutingInput{SourceName: "k8s-events"}))
+}
+
+func TestRouterOrderingKey(t *testing.T) {
+	// given
+	comms := map[string]config.Communications{
+		"default-group": {
+			SocketSlack: config.SocketSlack{
+				Enabled: true,
+				Channels: config.IdentifiableMap[config.ChannelBindingsByName]{
+					"alerts": {Name: "alerts", Bindings: config.BotBindings{Sources: []string{"k8s-err-events", "prometheus"}}},
+					"dev":    {Name: "dev", Bindings: config.BotBindings{Sources: []string{"k8s-create-events"}}},
+					"keptn":  {Name: "keptn", Bindings: config.BotBindings{Sources: []string{"keptn"}}},
+				},
+			},
+			Routing: []config.RoutingRule{
+				{Name: "critical", Match: config.RoutingMatch{Sources: []string{"argo"}}, Channels: []string{"#on-call"}},
+				{Name: "all-to-audit", Channels: []string{"audit", "keptn"}},
+			},
+		},
+	}
+	router, err := NewRouter(loggerx.NewNoop(), comms)
+	require.NoError(t, err)
+	notifierKey := NotifierKey("default-group", config.SocketSlackCommPlatformIntegration)
+
+	// when
+	errEventsKey := router.OrderingKey(notifierKey, []string{"k8s-err-events"}, nil)
+	prometheusKey := router.OrderingKey(notifierKey, []string{"prometheus"}, nil)
+	createEventsKey := router.OrderingKey(notifierKey, []string{"k8s-create-events"}, nil)
+	keptnKey := router.OrderingKey(notifierKey, []string{"keptn"}, nil)
+	auditKey := router.OrderingKey(notifierKey, []string{"k8s-err-events"}, []string{"audit", "keptn"})
+	argoKey := router.OrderingKey(notifierKey, []string{"argo"}, nil)
+	onCallKey := router.OrderingKey(notifierKey, []string{"argo"}, []string{"on-call"})
+
+	// then sources posted to the same channel share the key
+	assert.Equal(t, errEventsKey, prometheusKey)
+	assert.NotEqual(t, errEventsKey, createEventsKey)
+	// routing rule channels share the key with sources bound to them
+	assert.Equal(t, keptnKey, auditKey)
+	assert.NotEqual(t, errEventsKey, keptnKey)
+	// routing rule sources share the key with rule channels
+	assert.Equal(t, argoKey, onCallKey)
+}
+
+func TestDispatcherRouteItems(t *testing.T) {
+	// given
+	comms := map[string]config.Communications{
+		"default-group": {
+			SocketSlack: config.SocketSlack{Enabled: true},
+			Webhook:     config.Webhook{Enabled: true},
+		},
+	}
+	router, err := NewRouter(loggerx.NewNoop(), comms)
+	require.NoError(t, err)
+	d := &Dispatcher{router: router}
+
+	botQueue := delivery.NewQueue(loggerx.NewNoop(), NotifierKey("default-group", config.SocketSlackCommPlatformIntegration), config.Delivery{}, nil, nil, nil)
+	sinkQueue := delivery.NewQueue(loggerx.NewNoop(), NotifierKey("default-group", config.WebhookCommPlatformIntegration), config.Delivery{}, nil, nil, nil)
+
+	tests := []struct {
+		name       string
+		route      Route
+		expBotItem delivery.Item
+		expSink    bool
+		expRouted  bool
+	}{
+		{
+			name:       "Sinks only rule falls back to source bindings for bots",
+			route:      Route{Rules: []string{"to-webhook"}, Sinks: []config.CommPlatformIntegration{config.WebhookCommPlatformIntegration}},
+			expBotItem: delivery.Item{Sources: []string{"k8s-events"}, OrderingKey: "source:k8s-events"},
+			expSink:    true,
+			expRouted:  true,
+		},
+		{
+			name:       "Channels only rule falls back to source bindings for sinks",
+			route:      Route{Rules: []string{"to-incidents"}, Channels: []string{"incidents"}},
+			expBotItem: delivery.Item{Sources: []string{"k8s-events"}, Routed: true, Channels: []string{"incidents"}, OrderingKey: "channel:incidents"},
+			expSink:    true,
+		},
+		{
+			name:       "Rule targeting other sinks",
+			route:      Route{Rules: []string{"to-es"}, Sinks: []config.CommPlatformIntegration{config.ElasticsearchCommPlatformIntegration}},
+			expBotItem: delivery.Item{Sources: []string{"k8s-events"}, OrderingKey: "source:k8s-events"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			routes := map[string]Route{"default-group": tc.route}
+
+			// when
+			botItem := d.routeBotItem(botQueue, routes, delivery.Item{Sources: []string{"k8s-events"}})
+			sinkItem, sink := d.routeSinkItem(sinkQueue, routes, delivery.Item{Sources: []string{"k8s-events"}})
+
+			// then
+			assert.Equal(t, tc.expBotItem, botItem)
+			assert.Equal(t, tc.expSink, sink)
+			assert.Equal(t, tc.expRouted, sinkItem.Routed)
+		})
+	}
+}
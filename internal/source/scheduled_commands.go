@@ -0,0 +1,245 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/cronx"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+const (
+	// ScheduledCommandsPluginName is the name reported for events produced by scheduled commands, e.g. in routing rules and event history.
+	ScheduledCommandsPluginName = "botkube/scheduled-commands"
+
+	scheduledCommandUnknownValue = "n/a"
+)
+
+// ExecutorFactory facilitates creation of execute.Executor instances.
+type ExecutorFactory interface {
+	NewDefault(cfg execute.NewDefaultInput) execute.Executor
+}
+
+// builtInEventDispatcher dispatches events produced by built-in sources.
+type builtInEventDispatcher interface {
+	DispatchBuiltInEvent(ctx context.Context, sourceName, sourceDisplayName, pluginName string, event source.Event)
+}
+
+// ScheduledCommandResult is the raw object of events produced by scheduled commands. It's sent to sinks and used in routing rules.
+type ScheduledCommandResult struct {
+	SourceName string    `json:"sourceName"`
+	Name       string    `json:"name"`
+	Command    string    `json:"command"`
+	Output     string    `json:"output"`
+	ExecutedAt time.Time `json:"executedAt"`
+}
+
+// ScheduledCommandRunner executes Botkube commands on a cron schedule and sends their results to channels bound to a given source.
+type ScheduledCommandRunner struct {
+	log             logrus.FieldLogger
+	sources         map[string]config.Sources
+	executorFactory ExecutorFactory
+	dispatcher      builtInEventDispatcher
+	nowFn           func() time.Time
+
+	mu          sync.Mutex
+	lastOutputs map[string][32]byte
+}
+
+type scheduledCommand struct {
+	sourceName        string
+	sourceDisplayName string
+	name              string
+	cfg               config.ScheduledCommand
+	schedule          cronx.Schedule
+}
+
+// NewScheduledCommandRunner returns a new ScheduledCommandRunner instance.
+func NewScheduledCommandRunner(log logrus.FieldLogger, sources map[string]config.Sources, executorFactory ExecutorFactory, dispatcher builtInEventDispatcher) *ScheduledCommandRunner {
+	return &ScheduledCommandRunner{
+		log:             log,
+		sources:         sources,
+		executorFactory: executorFactory,
+		dispatcher:      dispatcher,
+		nowFn:           time.Now,
+		lastOutputs:     map[string][32]byte{},
+	}
+}
+
+// Run schedules all enabled commands and blocks until the context is canceled.
+func (r *ScheduledCommandRunner) Run(ctx context.Context) error {
+	commands, err := r.enabledCommands()
+	if err != nil {
+		return err
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for _, cmd := range commands {
+		wg.Add(1)
+		go func(cmd scheduledCommand) {
+			defer wg.Done()
+			r.runOnSchedule(ctx, cmd)
+		}(cmd)
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func (r *ScheduledCommandRunner) enabledCommands() ([]scheduledCommand, error) {
+	var out []scheduledCommand
+	for _, sourceName := range maputil.SortKeys(r.sources) {
+		src := r.sources[sourceName]
+		for _, name := range maputil.SortKeys(src.ScheduledCommands) {
+			cmdCfg := src.ScheduledCommands[name]
+			if !cmdCfg.Enabled {
+				continue
+			}
+
+			var loc *time.Location
+			if cmdCfg.Timezone != "" {
+				var err error
+				loc, err = time.LoadLocation(cmdCfg.Timezone)
+				if err != nil {
+					return nil, fmt.Errorf("while loading time zone of %q command in %q source: %w", name, sourceName, err)
+				}
+			}
+
+			schedule, err := cronx.ParseInLocation(cmdCfg.Schedule, loc)
+			if err != nil {
+				return nil, fmt.Errorf("while parsing schedule of %q command in %q source: %w", name, sourceName, err)
+			}
+
+			out = append(out, scheduledCommand{
+				sourceName:        sourceName,
+				sourceDisplayName: src.DisplayName,
+				name:              name,
+				cfg:               cmdCfg,
+				schedule:          schedule,
+			})
+		}
+	}
+	return out, nil
+}
+
+func (r *ScheduledCommandRunner) runOnSchedule(ctx context.Context, cmd scheduledCommand) {
+	log := r.log.WithFields(logrus.Fields{
+		"sourceName": cmd.sourceName,
+		"name":       cmd.name,
+		"schedule":   cmd.cfg.Schedule,
+	})
+
+	for {
+		now := r.nowFn()
+		next := cmd.schedule.Next(now)
+		if next.IsZero() {
+			log.Warn("Schedule has no future activation times. Stopping...")
+			return
+		}
+
+		log.WithField("next", next).Debug("Waiting for the next scheduled command execution...")
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		r.execute(ctx, log, cmd)
+	}
+}
+
+func (r *ScheduledCommandRunner) execute(ctx context.Context, log logrus.FieldLogger, cmd scheduledCommand) {
+	log.Info("Executing scheduled command...")
+
+	userName := fmt.Sprintf("Schedule %q", cmd.name)
+	executor := r.executorFactory.NewDefault(execute.NewDefaultInput{
+		Conversation: execute.Conversation{
+			IsKnown:          true,
+			ExecutorBindings: cmd.cfg.ExecutorBindings,
+			CommandOrigin:    command.AutomationOrigin,
+			Alias:            scheduledCommandUnknownValue,
+			ID:               scheduledCommandUnknownValue,
+		},
+		CommGroupName:   scheduledCommandUnknownValue,
+		Platform:        scheduledCommandUnknownValue,
+		NotifierHandler: &scheduledCommandNotifierHandler{},
+		Message:         strings.TrimSpace(strings.TrimPrefix(cmd.cfg.Command, api.MessageBotNamePlaceholder)),
+		User: execute.UserInput{
+			Mention:     userName,
+			DisplayName: userName,
+		},
+	})
+	result := executor.Execute(ctx)
+
+	if cmd.cfg.OnlyIfNonEmpty && execute.IsEmptyResponse(result.Message) {
+		log.Debug("Scheduled command returned no output. Skipping...")
+		return
+	}
+
+	if cmd.cfg.OnlyIfChanged && !r.outputChanged(cmd, result.Message) {
+		log.Debug("Scheduled command output didn't change. Skipping...")
+		return
+	}
+
+	msg := result.Message
+	if result.Description != "" {
+		msg.BaseBody.Plaintext = strings.TrimSpace(fmt.Sprintf("%s\n%s", result.Description, msg.BaseBody.Plaintext))
+	}
+
+	r.dispatcher.DispatchBuiltInEvent(ctx, cmd.sourceName, cmd.sourceDisplayName, ScheduledCommandsPluginName, source.Event{
+		Message: msg,
+		RawObject: ScheduledCommandResult{
+			SourceName: cmd.sourceName,
+			Name:       cmd.name,
+			Command:    cmd.cfg.Command,
+			Output:     strings.TrimSpace(fmt.Sprintf("%s\n%s", result.Message.BaseBody.Plaintext, result.Message.BaseBody.CodeBlock)),
+			ExecutedAt: r.nowFn(),
+		},
+	})
+}
+
+// outputChanged returns true if a given output differs from the previous one. The first output is always reported as changed.
+func (r *ScheduledCommandRunner) outputChanged(cmd scheduledCommand, msg api.Message) bool {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		// shouldn't happen, but better to post the same output than skip a changed one
+		return true
+	}
+	sum := sha256.Sum256(raw)
+
+	key := fmt.Sprintf("%s/%s", cmd.sourceName, cmd.name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prev, found := r.lastOutputs[key]
+	r.lastOutputs[key] = sum
+	return !found || prev != sum
+}
+
+type scheduledCommandNotifierHandler struct{}
+
+func (n *scheduledCommandNotifierHandler) NotificationsEnabled(_ string) bool {
+	return false
+}
+
+func (n *scheduledCommandNotifierHandler) SetNotificationsEnabled(_ string, _ bool) error {
+	return errors.New("setting notification from scheduled command is not supported. Use Botkube commands on a specific channel to set notifications")
+}
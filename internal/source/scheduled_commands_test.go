@@ -0,0 +1,115 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestScheduledCommandRunnerExecute(t *testing.T) {
+	// given
+	tests := []struct {
+		name        string
+		cfg         config.ScheduledCommand
+		outputs     []string
+		expMessages []string
+	}{
+		{
+			name:        "Always post",
+			cfg:         config.ScheduledCommand{},
+			outputs:     []string{"pod-a", "pod-a", ""},
+			expMessages: []string{"pod-a", "pod-a", ""},
+		},
+		{
+			name:        "Only if changed",
+			cfg:         config.ScheduledCommand{OnlyIfChanged: true},
+			outputs:     []string{"pod-a", "pod-a", "pod-b", "pod-a"},
+			expMessages: []string{"pod-a", "pod-b", "pod-a"},
+		},
+		{
+			name:        "Only if non-empty",
+			cfg:         config.ScheduledCommand{OnlyIfNonEmpty: true},
+			outputs:     []string{"", "pod-a", ""},
+			expMessages: []string{"pod-a"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.cfg.Enabled = true
+			tc.cfg.Schedule = "@daily"
+			tc.cfg.Command = "kubectl get pods"
+
+			factory := &fakeExecutorFactory{}
+			dispatcher := &fakeBuiltInEventDispatcher{}
+			runner := NewScheduledCommandRunner(loggerx.NewNoop(), map[string]config.Sources{
+				"daily-report": {
+					DisplayName: "Daily report",
+					ScheduledCommands: map[string]config.ScheduledCommand{
+						"pods": tc.cfg,
+					},
+				},
+			}, factory, dispatcher)
+
+			commands, err := runner.enabledCommands()
+			require.NoError(t, err)
+			require.Len(t, commands, 1)
+
+			// when
+			for _, out := range tc.outputs {
+				factory.output = out
+				runner.execute(context.Background(), loggerx.NewNoop(), commands[0])
+			}
+
+			// then
+			var gotMessages []string
+			for _, event := range dispatcher.events {
+				result, ok := event.RawObject.(ScheduledCommandResult)
+				require.True(t, ok)
+				assert.Equal(t, "daily-report", result.SourceName)
+				assert.Equal(t, "pods", result.Name)
+				gotMessages = append(gotMessages, result.Output)
+			}
+			assert.Equal(t, tc.expMessages, gotMessages)
+			assert.Equal(t, "kubectl get pods", factory.lastInput.Message)
+		})
+	}
+}
+
+type fakeExecutorFactory struct {
+	output    string
+	lastInput execute.NewDefaultInput
+}
+
+func (f *fakeExecutorFactory) NewDefault(in execute.NewDefaultInput) execute.Executor {
+	f.lastInput = in
+	return fakeExecutor{output: f.output}
+}
+
+type fakeExecutor struct {
+	output string
+}
+
+func (f fakeExecutor) Execute(context.Context) interactive.CoreMessage {
+	return interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{CodeBlock: f.output},
+		},
+	}
+}
+
+type fakeBuiltInEventDispatcher struct {
+	events []source.Event
+}
+
+func (f *fakeBuiltInEventDispatcher) DispatchBuiltInEvent(_ context.Context, _, _, _ string, event source.Event) {
+	f.events = append(f.events, event)
+}
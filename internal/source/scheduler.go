@@ -9,6 +9,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/api/transformer"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/maputil"
 )
@@ -32,6 +33,25 @@ type PluginDispatch struct {
 	cfg                      *config.Config
 	pluginContext            config.PluginContext
 	incomingWebhook          IncomingWebhookData
+	// builtIn is set for sources running in the agent process. They are started once, so their events are sent to all notifiers.
+	builtIn bool
+	// forwardToSinks is set for interactive streams of sources which are bound to sinks, but not to any non-interactive platform,
+	// if the source opted in to reuse the interactive stream. In such case, events are converted to plaintext and sent to sinks
+	// instead of starting a dedicated non-interactive stream.
+	forwardToSinks bool
+	// transformers holds transformer plugins called for each event before it is dispatched, in the given order.
+	transformers []TransformerDispatch
+	// recordHistory is set for a single stream of a given source, so its events are recorded in the history
+	// and automated actions are executed only once.
+	recordHistory bool
+}
+
+// TransformerDispatch holds information about transformer plugin bound to a given source.
+type TransformerDispatch struct {
+	transformerName string
+	pluginName      string
+	pluginConfig    *transformer.Config
+	onError         config.TransformerErrorPolicy
 }
 
 // ExternalRequestDispatch is a wrapper for PluginDispatch that holds the payload for external request.
@@ -168,6 +188,7 @@ func (d *Scheduler) schedule(pluginFilter string) error {
 }
 
 func (d *Scheduler) generateConfigs(ctx context.Context) error {
+	var sinkSources []string
 	for _, commGroupCfg := range d.cfg.Communications {
 		if commGroupCfg.CloudSlack.Enabled {
 			for _, channel := range commGroupCfg.CloudSlack.Channels {
@@ -212,23 +233,17 @@ func (d *Scheduler) generateConfigs(ctx context.Context) error {
 		}
 
 		if commGroupCfg.Webhook.Enabled {
-			if err := d.generateSourceConfigs(ctx, false, commGroupCfg.Webhook.Bindings.Sources); err != nil {
-				return err
-			}
+			sinkSources = append(sinkSources, commGroupCfg.Webhook.Bindings.Sources...)
 		}
 
 		if commGroupCfg.Elasticsearch.Enabled {
 			for _, index := range commGroupCfg.Elasticsearch.Indices {
-				if err := d.generateSourceConfigs(ctx, false, index.Bindings.Sources); err != nil {
-					return err
-				}
+				sinkSources = append(sinkSources, index.Bindings.Sources...)
 			}
 		}
 
 		if commGroupCfg.PagerDuty.Enabled {
-			if err := d.generateSourceConfigs(ctx, false, commGroupCfg.PagerDuty.Bindings.Sources); err != nil {
-				return err
-			}
+			sinkSources = append(sinkSources, commGroupCfg.PagerDuty.Bindings.Sources...)
 		}
 	}
 
@@ -242,9 +257,30 @@ func (d *Scheduler) generateConfigs(ctx context.Context) error {
 		}
 	}
 
+	// Sinks are scheduled at the end, as they can reuse streams started for interactive platforms if configured
+	for _, sourceName := range sinkSources {
+		if err := d.generateSinkPluginConfig(ctx, sourceName); err != nil {
+			return err
+		}
+	}
+
+	d.markHistoryRecordingStreams()
 	return nil
 }
 
+// markHistoryRecordingStreams selects a single stream for each source which records events in the history and executes automated actions.
+// A source can be streamed twice, for interactive and non-interactive platforms, and each stream produces the same events.
+// The non-interactive stream is preferred, as it exists for sources bound to sinks.
+func (d *Scheduler) markHistoryRecordingStreams() {
+	for key, dispatches := range d.dispatchConfig {
+		for pluginName, dispatch := range dispatches {
+			_, hasNonInteractive := d.dispatchConfig[dispatchConfigKey(dispatch.sourceName, false)]
+			dispatch.recordHistory = !dispatch.isInteractivitySupported || !hasNonInteractive
+			d.dispatchConfig[key][pluginName] = dispatch
+		}
+	}
+}
+
 func (d *Scheduler) generateSourceConfigs(ctx context.Context, isInteractivitySupported bool, boundSources []string) error {
 	for _, boundSource := range boundSources {
 		err := d.generatePluginConfig(ctx, isInteractivitySupported, boundSource)
@@ -255,12 +291,32 @@ func (d *Scheduler) generateSourceConfigs(ctx context.Context, isInteractivitySu
 	return nil
 }
 
+// generateSinkPluginConfig schedules a non-interactive stream for a given source, unless the source is already streamed.
+// If only the interactive stream is started and the source opted in to reuse it, its events are converted to plaintext
+// and forwarded to sinks instead.
+func (d *Scheduler) generateSinkPluginConfig(ctx context.Context, sourceName string) error {
+	if _, found := d.dispatchConfig[dispatchConfigKey(sourceName, false)]; found {
+		return nil
+	}
+
+	interactiveCfg, found := d.dispatchConfig[dispatchConfigKey(sourceName, true)]
+	if !found || !d.cfg.Sources[sourceName].Sinks.ReuseInteractiveStream {
+		return d.generatePluginConfig(ctx, false, sourceName)
+	}
+
+	for pluginName, dispatch := range interactiveCfg {
+		dispatch.forwardToSinks = true
+		interactiveCfg[pluginName] = dispatch
+	}
+	return nil
+}
+
 func (d *Scheduler) generatePluginConfig(ctx context.Context, isInteractivitySupported bool, sourceName string) error {
 	// As not all of our platforms supports interactivity, we need to schedule the same source twice. For example:
 	//  - k8s-all-events_interactive/true
 	//  - k8s-all-events_interactive/false
 	// As a result, each Stream method will know if it can produce an interactive message or not.
-	key := fmt.Sprintf("%s_interactive/%v", sourceName, isInteractivitySupported)
+	key := dispatchConfigKey(sourceName, isInteractivitySupported)
 
 	srcConfig, exists := d.cfg.Sources[sourceName]
 	if !exists {
@@ -279,6 +335,10 @@ func (d *Scheduler) generatePluginConfig(ctx context.Context, isInteractivitySup
 		pluginConfig := &source.Config{
 			RawYAML: rawYAML,
 		}
+		transformers, err := d.transformersForSource(srcConfig)
+		if err != nil {
+			return fmt.Errorf("while generating transformers config for source %s: %w", sourceName, err)
+		}
 		config := PluginDispatch{
 			ctx:                      ctx,
 			pluginName:               pluginName,
@@ -291,6 +351,7 @@ func (d *Scheduler) generatePluginConfig(ctx context.Context, isInteractivitySup
 			incomingWebhook: IncomingWebhookData{
 				inClusterBaseURL: d.cfg.Plugins.IncomingWebhook.InClusterBaseURL,
 			},
+			transformers: transformers,
 		}
 
 		d.dispatchConfig[key] = map[string]PluginDispatch{
@@ -311,6 +372,40 @@ func (d *Scheduler) generatePluginConfig(ctx context.Context, isInteractivitySup
 	return nil
 }
 
+func (d *Scheduler) transformersForSource(srcConfig config.Sources) ([]TransformerDispatch, error) {
+	var out []TransformerDispatch
+	for _, name := range srcConfig.Transformers {
+		transformerCfg, exists := d.cfg.Transformers[name]
+		if !exists {
+			return nil, fmt.Errorf("transformer %q not found", name)
+		}
+
+		for _, pluginName := range maputil.SortKeys(transformerCfg.Plugins) {
+			pluginCfg := transformerCfg.Plugins[pluginName]
+			if !pluginCfg.Enabled {
+				continue
+			}
+			rawYAML, err := yaml.Marshal(pluginCfg.Config)
+			if err != nil {
+				return nil, fmt.Errorf("while marshaling config for %s from transformer %s: %w", pluginName, name, err)
+			}
+			out = append(out, TransformerDispatch{
+				transformerName: name,
+				pluginName:      pluginName,
+				pluginConfig: &transformer.Config{
+					RawYAML: rawYAML,
+				},
+				onError: transformerCfg.OnError,
+			})
+		}
+	}
+	return out, nil
+}
+
+func dispatchConfigKey(sourceName string, isInteractivitySupported bool) string {
+	return fmt.Sprintf("%s_interactive/%v", sourceName, isInteractivitySupported)
+}
+
 func (d *Scheduler) StartedSourcePlugins() map[string]StartedSources {
 	return d.startedSourcePlugins
 }
@@ -109,3 +109,86 @@ type fakeDispatcherFunc func(ctx context.Context, isInteractivitySupported bool,
 func (f fakeDispatcherFunc) Dispatch(dispatch PluginDispatch) error {
 	return f(dispatch.ctx, dispatch.isInteractivitySupported, dispatch.pluginName, dispatch.pluginConfig, []string{dispatch.sourceName})
 }
+
+func TestSchedulerSinkStreams(t *testing.T) {
+	// given
+	files := config.YAMLFiles{
+		readTestdataFile(t, "config.yaml"),
+	}
+	givenCfg, _, err := config.LoadWithDefaults(files)
+	require.NoError(t, err)
+
+	type startedStream struct {
+		interactive    bool
+		forwardToSinks bool
+	}
+	expStreams := map[string][]startedStream{
+		"argocd": {
+			{interactive: true, forwardToSinks: true},
+		},
+		"flux": {
+			{interactive: false, forwardToSinks: false},
+			{interactive: true, forwardToSinks: false},
+		},
+		"keptn": {
+			{interactive: false, forwardToSinks: false},
+			{interactive: true, forwardToSinks: false},
+		},
+		"prometheus": {
+			{interactive: false, forwardToSinks: false},
+		},
+	}
+
+	gotStreams := map[string][]startedStream{}
+	dispatcher := fakeDispatcherFn(func(dispatch PluginDispatch) error {
+		gotStreams[dispatch.sourceName] = append(gotStreams[dispatch.sourceName], startedStream{
+			interactive:    dispatch.isInteractivitySupported,
+			forwardToSinks: dispatch.forwardToSinks,
+		})
+		return nil
+	})
+
+	// when
+	scheduler := NewScheduler(context.Background(), loggerx.NewNoop(), givenCfg, dispatcher, make(chan string))
+	err = scheduler.Start(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expStreams, gotStreams)
+}
+
+func TestSchedulerRecordsHistoryInSingleStream(t *testing.T) {
+	// given
+	files := config.YAMLFiles{
+		readTestdataFile(t, "config.yaml"),
+	}
+	givenCfg, _, err := config.LoadWithDefaults(files)
+	require.NoError(t, err)
+
+	recordingStreams := map[string][]bool{}
+	dispatcher := fakeDispatcherFn(func(dispatch PluginDispatch) error {
+		if dispatch.recordHistory {
+			recordingStreams[dispatch.sourceName] = append(recordingStreams[dispatch.sourceName], dispatch.isInteractivitySupported)
+		}
+		return nil
+	})
+
+	// when
+	scheduler := NewScheduler(context.Background(), loggerx.NewNoop(), givenCfg, dispatcher, make(chan string))
+	err = scheduler.Start(context.Background())
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]bool{
+		"argocd":     {true},
+		"flux":       {false},
+		"keptn":      {false},
+		"prometheus": {false},
+	}, recordingStreams)
+}
+
+type fakeDispatcherFn func(dispatch PluginDispatch) error
+
+func (f fakeDispatcherFn) Dispatch(dispatch PluginDispatch) error {
+	return f(dispatch)
+}
@@ -0,0 +1,149 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	defaultStreamRestartInitialBackoff = time.Second
+	defaultStreamRestartMaxBackoff     = time.Minute
+	// defaultStreamHealthyPeriod defines how long a stream must run without closing to reset its restart count.
+	defaultStreamHealthyPeriod = 10 * time.Minute
+)
+
+var errStreamClosed = errors.New("stream was closed")
+
+// streamOpenFn opens a new source stream.
+type streamOpenFn func(ctx context.Context) (chan source.Event, error)
+
+// streamHandleFn handles a single event received from a source stream.
+type streamHandleFn func(ctx context.Context, event source.Event)
+
+// streamSupervisor reads events from a source stream and re-opens it with backoff when it's closed.
+// Restarts are counted per stream, and the plugin restart policy is applied once the threshold is reached.
+// The count is reset once the stream runs for the healthy period, so occasional restarts don't deactivate it eventually.
+type streamSupervisor struct {
+	log        logrus.FieldLogger
+	sourceName string
+	// streamKey identifies the stream, as a single source may run separate streams for interactive and non-interactive platforms.
+	streamKey string
+	policy    config.PluginRestartPolicy
+	stats     *plugin.HealthStats
+	open      streamOpenFn
+	handle    streamHandleFn
+
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	healthyPeriod  time.Duration
+	fatalFn        func(format string, args ...any)
+}
+
+func newStreamSupervisor(log logrus.FieldLogger, sourceName, streamKey string, policy config.PluginRestartPolicy, stats *plugin.HealthStats, open streamOpenFn, handle streamHandleFn) *streamSupervisor {
+	if stats == nil {
+		stats = plugin.NewHealthStats(policy.Threshold)
+	}
+	return &streamSupervisor{
+		log:            log,
+		sourceName:     sourceName,
+		streamKey:      streamKey,
+		policy:         policy,
+		stats:          stats,
+		open:           open,
+		handle:         handle,
+		initialBackoff: defaultStreamRestartInitialBackoff,
+		maxBackoff:     defaultStreamRestartMaxBackoff,
+		healthyPeriod:  defaultStreamHealthyPeriod,
+		fatalFn:        log.Fatalf,
+	}
+}
+
+// Run consumes a given stream until the context is canceled or the stream is deactivated.
+func (s *streamSupervisor) Run(ctx context.Context, events chan source.Event) {
+	backoff := s.initialBackoff
+	for {
+		s.stats.ReportStreamRunning(s.sourceName, s.streamKey)
+		openedAt := time.Now()
+		if !s.consume(ctx, events) {
+			return
+		}
+
+		if time.Since(openedAt) > s.maxBackoff {
+			// the stream was healthy for a while, so start from scratch
+			backoff = s.initialBackoff
+		}
+		if time.Since(openedAt) > s.healthyPeriod {
+			s.stats.ResetStreamRestartCount(s.streamKey)
+		}
+
+		var reason error = errStreamClosed
+		for {
+			s.log.WithError(reason).Error("Stream closed")
+			if !s.shouldRestart() {
+				s.stats.ReportStreamDeactivated(s.sourceName, s.streamKey)
+				s.log.Warnf("Stream for source %q has been restarted too many times. Deactivating...", s.sourceName)
+				return
+			}
+			s.stats.ReportStreamRestarting(s.sourceName, s.streamKey, reason)
+
+			s.log.Infof("Restarting stream in %s, attempt %d/%d...", backoff, s.stats.GetStreamRestartCount(s.streamKey), s.policy.Threshold)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			backoff = min(backoff*2, s.maxBackoff)
+
+			var err error
+			events, err = s.open(ctx)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			reason = err
+		}
+	}
+}
+
+// consume returns true if the stream was closed, and false if the context was canceled.
+func (s *streamSupervisor) consume(ctx context.Context, events chan source.Event) bool {
+	for {
+		select {
+		case msg, ok := <-events:
+			if !ok {
+				return ctx.Err() == nil
+			}
+			s.log.WithField("message", msg).Debug("Dispatching received message...")
+			s.handle(ctx, msg)
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+func (s *streamSupervisor) shouldRestart() bool {
+	restarts := s.stats.GetStreamRestartCount(s.streamKey)
+
+	switch s.policy.Type.ToLower() {
+	case config.KeepAgentRunningWhenThresholdReached.ToLower():
+		return restarts < s.policy.Threshold
+	case config.RestartAgentWhenThresholdReached.ToLower():
+		if restarts >= s.policy.Threshold {
+			s.fatalFn("Stream for source %q has been restarted %d times and selected restartPolicy is %q. Exiting...", s.sourceName, restarts, s.policy.Type)
+			return false
+		}
+		return true
+	default:
+		s.log.Errorf("Unknown restart policy %q.", s.policy.Type)
+		return false
+	}
+}
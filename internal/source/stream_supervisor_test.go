@@ -0,0 +1,165 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const testStreamKey = "k8s-err/kubernetes/interactive/false"
+
+func TestStreamSupervisorRestartsClosedStream(t *testing.T) {
+	// given
+	stats := plugin.NewHealthStats(3)
+	policy := config.PluginRestartPolicy{Type: config.KeepAgentRunningWhenThresholdReached, Threshold: 3}
+
+	var (
+		mu       sync.Mutex
+		received []string
+		opened   int
+	)
+	open := func(context.Context) (chan source.Event, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		opened++
+		return fixClosedStream(fmt.Sprintf("event from stream %d", opened+1)), nil
+	}
+	handle := func(_ context.Context, event source.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event.Message.BaseBody.Plaintext)
+	}
+
+	supervisor := newStreamSupervisor(loggerx.NewNoop(), "k8s-err", testStreamKey, policy, stats, open, handle)
+	supervisor.initialBackoff = time.Millisecond
+	supervisor.maxBackoff = 5 * time.Millisecond
+
+	// when
+	supervisor.Run(context.Background(), fixClosedStream("event from stream 1"))
+
+	// then
+	assert.Equal(t, []string{
+		"event from stream 1",
+		"event from stream 2",
+		"event from stream 3",
+		"event from stream 4",
+	}, received)
+
+	status, restarts, threshold, _, lastErr := stats.GetStreamStats("k8s-err")
+	assert.Equal(t, "Deactivated", status)
+	assert.Equal(t, 3, restarts)
+	assert.Equal(t, 3, threshold)
+	assert.Equal(t, errStreamClosed.Error(), lastErr)
+}
+
+func TestStreamSupervisorReportsOpenErrors(t *testing.T) {
+	// given
+	stats := plugin.NewHealthStats(2)
+	policy := config.PluginRestartPolicy{Type: config.RestartAgentWhenThresholdReached, Threshold: 2}
+
+	open := func(context.Context) (chan source.Event, error) {
+		return nil, errors.New("plugin is not running")
+	}
+
+	supervisor := newStreamSupervisor(loggerx.NewNoop(), "k8s-err", testStreamKey, policy, stats, open, func(context.Context, source.Event) {})
+	supervisor.initialBackoff = time.Millisecond
+	var fatalMsg string
+	supervisor.fatalFn = func(format string, args ...any) {
+		fatalMsg = fmt.Sprintf(format, args...)
+	}
+
+	// when
+	supervisor.Run(context.Background(), fixClosedStream())
+
+	// then
+	assert.Equal(t, `Stream for source "k8s-err" has been restarted 2 times and selected restartPolicy is "RestartAgent". Exiting...`, fatalMsg)
+	_, restarts, _, _, lastErr := stats.GetStreamStats("k8s-err")
+	assert.Equal(t, 2, restarts)
+	assert.Equal(t, "plugin is not running", lastErr)
+}
+
+func TestStreamSupervisorResetsRestartCountAfterHealthyPeriod(t *testing.T) {
+	// given
+	stats := plugin.NewHealthStats(2)
+	policy := config.PluginRestartPolicy{Type: config.KeepAgentRunningWhenThresholdReached, Threshold: 2}
+	const healthyPeriod = 20 * time.Millisecond
+
+	var opened int
+	open := func(context.Context) (chan source.Event, error) {
+		opened++
+		if opened != 2 {
+			return fixClosedStream(), nil
+		}
+
+		// the 2nd re-opened stream runs longer than the healthy period
+		out := make(chan source.Event)
+		go func() {
+			time.Sleep(2 * healthyPeriod)
+			close(out)
+		}()
+		return out, nil
+	}
+
+	supervisor := newStreamSupervisor(loggerx.NewNoop(), "k8s-err", testStreamKey, policy, stats, open, func(context.Context, source.Event) {})
+	supervisor.initialBackoff = time.Millisecond
+	supervisor.maxBackoff = time.Millisecond
+	supervisor.healthyPeriod = healthyPeriod
+
+	// when
+	supervisor.Run(context.Background(), fixClosedStream())
+
+	// then
+	assert.Equal(t, 4, opened)
+	status, restarts, _, _, _ := stats.GetStreamStats("k8s-err")
+	assert.Equal(t, "Deactivated", status)
+	assert.Equal(t, 2, restarts)
+}
+
+func TestStreamSupervisorStopsOnContextCancel(t *testing.T) {
+	// given
+	stats := plugin.NewHealthStats(3)
+	policy := config.PluginRestartPolicy{Type: config.KeepAgentRunningWhenThresholdReached, Threshold: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	supervisor := newStreamSupervisor(loggerx.NewNoop(), "k8s-err", testStreamKey, policy, stats, nil, func(context.Context, source.Event) {})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		supervisor.Run(ctx, make(chan source.Event))
+	}()
+
+	// when
+	cancel()
+
+	// then
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "supervisor didn't stop after context cancellation")
+	}
+	status, restarts, _, _, _ := stats.GetStreamStats("k8s-err")
+	assert.Equal(t, "Running", status)
+	assert.Zero(t, restarts)
+}
+
+func fixClosedStream(msgs ...string) chan source.Event {
+	out := make(chan source.Event, len(msgs))
+	for _, msg := range msgs {
+		out <- source.Event{Message: api.NewPlaintextMessage(msg, false)}
+	}
+	close(out)
+	return out
+}
@@ -0,0 +1,54 @@
+package source
+
+import (
+	"sync"
+
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+const maxEventThreads = 1000
+
+// eventThreads remembers references of delivered event messages, so automated action results can be posted in their threads.
+// The oldest entries are forgotten first.
+type eventThreads struct {
+	mu    sync.Mutex
+	max   int
+	refs  map[string][]notifier.MessageRef
+	order []string
+}
+
+func newEventThreads() *eventThreads {
+	return &eventThreads{
+		max:  maxEventThreads,
+		refs: map[string][]notifier.MessageRef{},
+	}
+}
+
+// Get returns references of messages delivered for a given item.
+func (t *eventThreads) Get(itemID string) ([]notifier.MessageRef, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	refs, found := t.refs[itemID]
+	return refs, found
+}
+
+// Add stores references of messages delivered for a given item. A retried item may be delivered in parts,
+// so the references are added to the already stored ones.
+func (t *eventThreads) Add(itemID string, refs []notifier.MessageRef) {
+	if itemID == "" || len(refs) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, found := t.refs[itemID]; !found {
+		t.order = append(t.order, itemID)
+	}
+	t.refs[itemID] = append(t.refs[itemID], refs...)
+
+	for len(t.order) > t.max {
+		delete(t.refs, t.order[0])
+		t.order = t.order[1:]
+	}
+}
@@ -0,0 +1,188 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/delivery"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/notifier"
+)
+
+func TestDeliverBotMessageInEventThreads(t *testing.T) {
+	// given
+	bot := &fakeThreadedBot{
+		refs: []notifier.MessageRef{
+			{Channel: "alerts", ID: "1700000000.000100"},
+			{Channel: "team", ID: "1700000000.000200"},
+		},
+	}
+	d := &Dispatcher{log: loggerx.NewNoop()}
+	deliver := d.deliverBotMessageFn(bot)
+
+	// when
+	err := deliver(context.Background(), &delivery.Item{ID: "event-1", Message: fixPlaintextMsg("event"), Sources: []string{"k8s"}})
+	require.NoError(t, err)
+	err = deliver(context.Background(), &delivery.Item{ID: "action-1", Message: fixPlaintextMsg("action"), Sources: []string{"k8s"}, ThreadOf: "event-1"})
+	require.NoError(t, err)
+	err = deliver(context.Background(), &delivery.Item{ID: "action-2", Message: fixPlaintextMsg("orphan"), Sources: []string{"k8s"}, ThreadOf: "unknown"})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []string{"event", "orphan"}, bot.sent)
+	assert.Equal(t, []fakeReply{
+		{channel: "alerts", parentID: "1700000000.000100", text: "action"},
+		{channel: "team", parentID: "1700000000.000200", text: "action"},
+	}, bot.replies)
+}
+
+func TestDeliverBotMessageInRoutedEventThreads(t *testing.T) {
+	// given
+	bot := &fakeThreadedBot{}
+	d := &Dispatcher{log: loggerx.NewNoop()}
+	deliver := d.deliverBotMessageFn(bot)
+
+	// when
+	err := deliver(context.Background(), &delivery.Item{ID: "event-1", Message: fixPlaintextMsg("event"), Routed: true, Channels: []string{"oncall"}})
+	require.NoError(t, err)
+	err = deliver(context.Background(), &delivery.Item{ID: "action-1", Message: fixPlaintextMsg("action"), Routed: true, Channels: []string{"oncall"}, ThreadOf: "event-1"})
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, []string{"event"}, bot.sent)
+	assert.Equal(t, []fakeReply{
+		{channel: "oncall", parentID: "msg-in-oncall", text: "action"},
+	}, bot.replies)
+}
+
+func TestDeliverBotMessageRetriesOnlyFailedChannels(t *testing.T) {
+	// given
+	bot := &fakeFlakyBot{
+		channels: []string{"alerts", "team", "removed"},
+		errs: map[string]error{
+			"team":    errors.New("rate limited"),
+			"removed": notifier.NewPermanentError(errors.New("channel not found")),
+		},
+	}
+	d := &Dispatcher{log: loggerx.NewNoop()}
+	deliver := d.deliverBotMessageFn(bot)
+	item := &delivery.Item{ID: "event-1", Message: fixPlaintextMsg("event"), Sources: []string{"k8s"}}
+
+	// when
+	firstErr := deliver(context.Background(), item)
+	secondErr := deliver(context.Background(), item)
+
+	// then
+	require.Error(t, firstErr)
+	assert.False(t, notifier.IsPermanentError(firstErr))
+	assert.Equal(t, []string{"team"}, item.RetryChannels)
+	require.NoError(t, secondErr)
+	assert.Equal(t, []string{"alerts", "team"}, bot.delivered)
+}
+
+func TestDeliverBotMessageStopsRetriesOnPermanentErrors(t *testing.T) {
+	// given
+	bot := &fakeFlakyBot{
+		channels: []string{"alerts", "removed"},
+		errs: map[string]error{
+			"removed": notifier.NewPermanentError(errors.New("channel not found")),
+		},
+	}
+	d := &Dispatcher{log: loggerx.NewNoop()}
+	deliver := d.deliverBotMessageFn(bot)
+
+	// when
+	err := deliver(context.Background(), &delivery.Item{ID: "event-1", Message: fixPlaintextMsg("event"), Routed: true, Channels: bot.channels})
+
+	// then
+	require.Error(t, err)
+	assert.True(t, notifier.IsPermanentError(err))
+	assert.Equal(t, []string{"alerts"}, bot.delivered)
+}
+
+func fixPlaintextMsg(text string) *interactive.CoreMessage {
+	return &interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{Plaintext: text},
+		},
+	}
+}
+
+type fakeReply struct {
+	channel  string
+	parentID string
+	text     string
+}
+
+type fakeThreadedBot struct {
+	refs    []notifier.MessageRef
+	sent    []string
+	replies []fakeReply
+}
+
+func (f *fakeThreadedBot) SendMessageToAll(context.Context, interactive.CoreMessage) error {
+	return nil
+}
+
+func (f *fakeThreadedBot) SendMessage(_ context.Context, msg interactive.CoreMessage, _ []string) ([]notifier.MessageRef, error) {
+	f.sent = append(f.sent, msg.BaseBody.Plaintext)
+	return f.refs, nil
+}
+
+// SendMessageToChannels records replies. Top-level messages are recorded as sent and referenced by the channel name.
+func (f *fakeThreadedBot) SendMessageToChannels(_ context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	if msg.ParentActivityID == "" {
+		f.sent = append(f.sent, msg.BaseBody.Plaintext)
+		var refs []notifier.MessageRef
+		for _, ch := range channels {
+			refs = append(refs, notifier.MessageRef{Channel: ch, ID: "msg-in-" + ch})
+		}
+		return refs, nil
+	}
+
+	for _, ch := range channels {
+		f.replies = append(f.replies, fakeReply{channel: ch, parentID: msg.ParentActivityID, text: msg.BaseBody.Plaintext})
+	}
+	return nil, nil
+}
+
+func (f *fakeThreadedBot) IntegrationName() config.CommPlatformIntegration {
+	return config.SocketSlackCommPlatformIntegration
+}
+
+func (f *fakeThreadedBot) Type() config.IntegrationType {
+	return config.BotIntegrationType
+}
+
+// fakeFlakyBot fails to send messages to channels with configured errors only once.
+type fakeFlakyBot struct {
+	fakeThreadedBot
+	channels  []string
+	errs      map[string]error
+	delivered []string
+}
+
+func (f *fakeFlakyBot) SendMessage(ctx context.Context, msg interactive.CoreMessage, _ []string) ([]notifier.MessageRef, error) {
+	return f.SendMessageToChannels(ctx, msg, f.channels)
+}
+
+func (f *fakeFlakyBot) SendMessageToChannels(_ context.Context, _ interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	errs := multierror.New()
+	for _, ch := range channels {
+		if err, found := f.errs[ch]; found {
+			delete(f.errs, ch)
+			errs = multierror.Append(errs, notifier.NewChannelError(ch, err))
+			continue
+		}
+		f.delivered = append(f.delivered, ch)
+	}
+	return nil, errs.ErrorOrNil()
+}
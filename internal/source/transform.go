@@ -0,0 +1,67 @@
+package source
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/api/transformer"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// TransformerProvider provides clients for enabled transformer plugins.
+type TransformerProvider interface {
+	GetTransformer(name string) (transformer.Transformer, error)
+}
+
+// transformEvent passes a given event through all transformers bound to the dispatched source.
+// It returns false if the event was dropped by one of them.
+// If a transformer fails or is not running, the event is dropped as well, unless the transformer is configured
+// with the Passthrough error policy. In such case, the event is passed to the next transformer as it was.
+func (d *Dispatcher) transformEvent(ctx context.Context, event source.Event, dispatch PluginDispatch) (source.Event, bool) {
+	for _, item := range dispatch.transformers {
+		log := d.log.WithFields(logrus.Fields{
+			"sourceName":      dispatch.sourceName,
+			"transformerName": item.transformerName,
+			"pluginName":      item.pluginName,
+		})
+
+		cli, err := d.transformerProvider.GetTransformer(item.pluginName)
+		if err != nil {
+			if item.onError == config.PassthroughTransformerErrorPolicy {
+				log.WithError(err).Error("Failed to get transformer client. Passing the event through...")
+				continue
+			}
+			log.WithError(err).Error("Failed to get transformer client. Dropping the event...")
+			return source.Event{}, false
+		}
+
+		out, err := cli.Transform(ctx, transformer.TransformInput{
+			Configs: []*transformer.Config{item.pluginConfig},
+			Event:   event,
+			Context: transformer.TransformInputContext{
+				IsInteractivitySupported: dispatch.isInteractivitySupported,
+				ClusterName:              d.clusterName,
+				SourceName:               dispatch.sourceName,
+				SourcePluginName:         dispatch.pluginName,
+			},
+		})
+		if err != nil {
+			if item.onError == config.PassthroughTransformerErrorPolicy {
+				log.WithError(err).Error("Failed to transform event. Passing the event through...")
+				continue
+			}
+			log.WithError(err).Error("Failed to transform event. Dropping the event...")
+			return source.Event{}, false
+		}
+
+		if out.Drop {
+			log.Debug("Event dropped by transformer")
+			return source.Event{}, false
+		}
+		event = out.Event
+	}
+
+	return event, true
+}
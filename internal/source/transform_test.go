@@ -0,0 +1,160 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/api/transformer"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestDispatcherTransformEvent(t *testing.T) {
+	// given
+	provider := fakeTransformerProvider{
+		"botkube/enrich": fakeTransformerFunc(func(in transformer.TransformInput) (transformer.TransformOutput, error) {
+			raw := in.Event.RawObject.(map[string]any)
+			raw["cluster"] = in.Context.ClusterName
+			raw["source"] = fmt.Sprintf("%s/%s", in.Context.SourceName, in.Context.SourcePluginName)
+			return transformer.TransformOutput{Event: in.Event}, nil
+		}),
+		"botkube/redact": fakeTransformerFunc(func(in transformer.TransformInput) (transformer.TransformOutput, error) {
+			raw := in.Event.RawObject.(map[string]any)
+			if _, found := raw["password"]; found {
+				raw["password"] = "***"
+			}
+			in.Event.Message = api.NewPlaintextMessage(string(in.Configs[0].RawYAML), false)
+			return transformer.TransformOutput{Event: in.Event}, nil
+		}),
+		"botkube/drop-all": fakeTransformerFunc(func(transformer.TransformInput) (transformer.TransformOutput, error) {
+			return transformer.TransformOutput{Drop: true}, nil
+		}),
+		"botkube/broken": fakeTransformerFunc(func(transformer.TransformInput) (transformer.TransformOutput, error) {
+			return transformer.TransformOutput{}, errors.New("boom")
+		}),
+	}
+	d := &Dispatcher{
+		log:                 loggerx.NewNoop(),
+		transformerProvider: provider,
+		clusterName:         "labs",
+	}
+
+	tests := []struct {
+		name         string
+		transformers []string
+		onError      config.TransformerErrorPolicy
+
+		expEvent   source.Event
+		expDropped bool
+	}{
+		{
+			name:         "Call transformers in order",
+			transformers: []string{"botkube/enrich", "botkube/redact"},
+			expEvent: source.Event{
+				Message: api.NewPlaintextMessage("config of botkube/redact", false),
+				RawObject: map[string]any{
+					"password": "***",
+					"cluster":  "labs",
+					"source":   "k8s-events/botkube/kubernetes",
+				},
+			},
+		},
+		{
+			name:         "Drop event if transformer failed",
+			transformers: []string{"botkube/broken", "botkube/enrich"},
+			expDropped:   true,
+		},
+		{
+			name:         "Drop event if transformer is not running",
+			transformers: []string{"botkube/not-found"},
+			expDropped:   true,
+		},
+		{
+			name:         "Skip transformer which failed with passthrough policy",
+			transformers: []string{"botkube/broken", "botkube/enrich"},
+			onError:      config.PassthroughTransformerErrorPolicy,
+			expEvent: source.Event{
+				Message: api.NewPlaintextMessage("original", false),
+				RawObject: map[string]any{
+					"password": "secret",
+					"cluster":  "labs",
+					"source":   "k8s-events/botkube/kubernetes",
+				},
+			},
+		},
+		{
+			name:         "Skip transformer which is not running with passthrough policy",
+			transformers: []string{"botkube/not-found"},
+			onError:      config.PassthroughTransformerErrorPolicy,
+			expEvent: source.Event{
+				Message: api.NewPlaintextMessage("original", false),
+				RawObject: map[string]any{
+					"password": "secret",
+				},
+			},
+		},
+		{
+			name:         "Drop event",
+			transformers: []string{"botkube/drop-all", "botkube/enrich"},
+			expDropped:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatch := PluginDispatch{
+				sourceName: "k8s-events",
+				pluginName: "botkube/kubernetes",
+			}
+			for _, name := range tc.transformers {
+				dispatch.transformers = append(dispatch.transformers, TransformerDispatch{
+					transformerName: name,
+					pluginName:      name,
+					pluginConfig:    &transformer.Config{RawYAML: []byte("config of " + name)},
+					onError:         tc.onError,
+				})
+			}
+			event := source.Event{
+				Message: api.NewPlaintextMessage("original", false),
+				RawObject: map[string]any{
+					"password": "secret",
+				},
+			}
+
+			// when
+			out, ok := d.transformEvent(context.Background(), event, dispatch)
+
+			// then
+			assert.Equal(t, tc.expDropped, !ok)
+			if tc.expDropped {
+				return
+			}
+			assert.Equal(t, tc.expEvent, out)
+		})
+	}
+}
+
+type fakeTransformerProvider map[string]transformer.Transformer
+
+func (f fakeTransformerProvider) GetTransformer(name string) (transformer.Transformer, error) {
+	cli, found := f[name]
+	if !found {
+		return nil, fmt.Errorf("client for transformer plugin %q not found", name)
+	}
+	return cli, nil
+}
+
+type fakeTransformerFunc func(in transformer.TransformInput) (transformer.TransformOutput, error)
+
+func (f fakeTransformerFunc) Transform(_ context.Context, in transformer.TransformInput) (transformer.TransformOutput, error) {
+	return f(in)
+}
+
+func (f fakeTransformerFunc) Metadata(context.Context) (api.MetadataOutput, error) {
+	return api.MetadataOutput{}, nil
+}
@@ -0,0 +1,156 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+// Decision describes whether a given action execution is allowed.
+type Decision string
+
+const (
+	// Allowed means that the action can be executed.
+	Allowed Decision = "Allowed"
+	// CoolingDown means that the action was recently executed for the same key.
+	CoolingDown Decision = "CoolingDown"
+	// LimitReached means that the action exceeded its execution limit and it was disabled.
+	LimitReached Decision = "LimitReached"
+	// Disabled means that the action was already disabled as it previously exceeded its execution limit.
+	Disabled Decision = "Disabled"
+)
+
+// Tracker tracks automated action executions to enforce cooldowns and execution limits.
+type Tracker struct {
+	now func() time.Time
+
+	mu      sync.RWMutex
+	actions map[string]*actionState
+}
+
+type actionState struct {
+	executions     int
+	lastExecutedAt time.Time
+	limitReached   bool
+
+	// lastExecutionByKey holds the last execution time for each cooldown key.
+	lastExecutionByKey map[string]time.Time
+	// recentExecutions holds execution times within the limit window.
+	recentExecutions []time.Time
+}
+
+// NewTracker returns a new Tracker instance.
+func NewTracker() *Tracker {
+	return &Tracker{
+		now:     time.Now,
+		actions: map[string]*actionState{},
+	}
+}
+
+// Acquire checks whether a given action can be executed for a given cooldown key. If so, the execution is recorded.
+// Once the execution limit is exceeded, LimitReached is returned and all subsequent calls return Disabled.
+func (t *Tracker) Acquire(name, key string, cooldown config.ActionCooldown, limit config.ActionLimit) Decision {
+	if t == nil {
+		return Allowed
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, found := t.actions[name]
+	if !found {
+		state = &actionState{
+			lastExecutionByKey: map[string]time.Time{},
+		}
+		t.actions[name] = state
+	}
+
+	if state.limitReached {
+		return Disabled
+	}
+
+	now := t.now()
+	if cooldown.Period > 0 {
+		for k, executedAt := range state.lastExecutionByKey {
+			if now.Sub(executedAt) >= cooldown.Period {
+				delete(state.lastExecutionByKey, k)
+			}
+		}
+		if _, coolingDown := state.lastExecutionByKey[key]; coolingDown {
+			return CoolingDown
+		}
+	}
+
+	if limit.MaxExecutions > 0 {
+		recent := state.recentExecutions[:0]
+		for _, executedAt := range state.recentExecutions {
+			if now.Sub(executedAt) < limit.Window {
+				recent = append(recent, executedAt)
+			}
+		}
+		state.recentExecutions = recent
+
+		if len(state.recentExecutions) >= limit.MaxExecutions {
+			state.limitReached = true
+			state.recentExecutions = nil
+			return LimitReached
+		}
+		state.recentExecutions = append(state.recentExecutions, now)
+	}
+
+	if cooldown.Period > 0 {
+		state.lastExecutionByKey[key] = now
+	}
+	state.executions++
+	state.lastExecutedAt = now
+	return Allowed
+}
+
+// IsLimitReached returns true if a given action was disabled because it exceeded its execution limit.
+func (t *Tracker) IsLimitReached(name string) bool {
+	if t == nil {
+		return false
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	state, found := t.actions[name]
+	return found && state.limitReached
+}
+
+// GetStats returns execution statistics for a given action.
+func (t *Tracker) GetStats(name string) (executions int, lastExecutedAt time.Time, limitReached bool) {
+	if t == nil {
+		return 0, time.Time{}, false
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	state, found := t.actions[name]
+	if !found {
+		return 0, time.Time{}, false
+	}
+	return state.executions, state.lastExecutedAt, state.limitReached
+}
+
+// Reset clears the execution limit and cooldowns of a given action, so it can be executed again once re-enabled.
+// Execution statistics are preserved.
+func (t *Tracker) Reset(name string) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, found := t.actions[name]
+	if !found {
+		return
+	}
+	state.limitReached = false
+	state.recentExecutions = nil
+	state.lastExecutionByKey = map[string]time.Time{}
+}
@@ -0,0 +1,93 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestTrackerCooldown(t *testing.T) {
+	// given
+	now := time.Now()
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+	cooldown := config.ActionCooldown{Period: time.Minute}
+
+	// when
+	first := tracker.Acquire("restart", "prod/api", cooldown, config.ActionLimit{})
+	sameKey := tracker.Acquire("restart", "prod/api", cooldown, config.ActionLimit{})
+	otherKey := tracker.Acquire("restart", "prod/web", cooldown, config.ActionLimit{})
+	otherAction := tracker.Acquire("describe", "prod/api", cooldown, config.ActionLimit{})
+
+	now = now.Add(time.Minute)
+	afterCooldown := tracker.Acquire("restart", "prod/api", cooldown, config.ActionLimit{})
+
+	// then
+	assert.Equal(t, Allowed, first)
+	assert.Equal(t, CoolingDown, sameKey)
+	assert.Equal(t, Allowed, otherKey)
+	assert.Equal(t, Allowed, otherAction)
+	assert.Equal(t, Allowed, afterCooldown)
+
+	executions, lastExecutedAt, limitReached := tracker.GetStats("restart")
+	assert.Equal(t, 3, executions)
+	assert.Equal(t, now, lastExecutedAt)
+	assert.False(t, limitReached)
+}
+
+func TestTrackerLimit(t *testing.T) {
+	// given
+	now := time.Now()
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+	limit := config.ActionLimit{MaxExecutions: 2, Window: time.Hour}
+
+	// when
+	var decisions []Decision
+	for i := 0; i < 2; i++ {
+		decisions = append(decisions, tracker.Acquire("restart", "", config.ActionCooldown{}, limit))
+		now = now.Add(40 * time.Minute)
+	}
+	// the first execution is already outside the window
+	decisions = append(decisions, tracker.Acquire("restart", "", config.ActionCooldown{}, limit))
+	decisions = append(decisions, tracker.Acquire("restart", "", config.ActionCooldown{}, limit))
+	decisions = append(decisions, tracker.Acquire("restart", "", config.ActionCooldown{}, limit))
+
+	// then
+	assert.Equal(t, []Decision{Allowed, Allowed, Allowed, LimitReached, Disabled}, decisions)
+	assert.True(t, tracker.IsLimitReached("restart"))
+	assert.False(t, tracker.IsLimitReached("describe"))
+
+	executions, _, limitReached := tracker.GetStats("restart")
+	assert.Equal(t, 3, executions)
+	assert.True(t, limitReached)
+}
+
+func TestTrackerReset(t *testing.T) {
+	// given
+	now := time.Now()
+	tracker := NewTracker()
+	tracker.now = func() time.Time { return now }
+	cooldown := config.ActionCooldown{Period: time.Hour}
+	limit := config.ActionLimit{MaxExecutions: 1, Window: time.Hour}
+
+	assert.Equal(t, Allowed, tracker.Acquire("restart", "prod/api", cooldown, limit))
+	assert.Equal(t, LimitReached, tracker.Acquire("restart", "prod/web", cooldown, limit))
+
+	// when
+	tracker.Reset("restart")
+	tracker.Reset("describe")
+
+	// then
+	assert.False(t, tracker.IsLimitReached("restart"))
+	assert.Equal(t, Allowed, tracker.Acquire("restart", "prod/api", cooldown, limit))
+	assert.Equal(t, CoolingDown, tracker.Acquire("restart", "prod/api", cooldown, limit))
+	assert.Equal(t, LimitReached, tracker.Acquire("restart", "prod/web", cooldown, limit))
+
+	executions, lastExecutedAt, _ := tracker.GetStats("restart")
+	assert.Equal(t, 2, executions)
+	assert.Equal(t, now, lastExecutedAt)
+}
@@ -0,0 +1,206 @@
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/multierror"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
+)
+
+// Preview describes an action rendered for a given event without executing it.
+type Preview struct {
+	Name             string
+	DisplayName      string
+	Enabled          bool
+	SourceBindings   []string
+	ExecutorBindings []string
+
+	// SourceName is the name of the source which emitted the event. It's empty if the event source is unknown.
+	SourceName string
+	// SourceMatched is true if the event source is unknown or it's bound to the action.
+	SourceMatched bool
+
+	// Condition is empty if the action doesn't define it.
+	Condition    string
+	ConditionMet bool
+
+	// Command is the rendered command. It's empty for multi-step actions, as their steps are rendered during execution.
+	Command     string
+	Steps       []config.ActionStep
+	CooldownKey string
+}
+
+// Previewer renders actions for given events without executing them.
+type Previewer struct {
+	cfg config.Actions
+}
+
+// NewPreviewer returns a new Previewer instance.
+func NewPreviewer(cfg config.Actions) *Previewer {
+	return &Previewer{cfg: cfg}
+}
+
+// Render renders a given action for a given event. All templates are rendered even if the condition isn't met.
+// The returned error contains all template errors, while the returned preview contains everything that was rendered successfully.
+func (p *Previewer) Render(name string, event any, sourceName string) (Preview, error) {
+	action, found := p.cfg[name]
+	if !found {
+		return Preview{}, fmt.Errorf("action %q not found", name)
+	}
+
+	preview := Preview{
+		Name:             name,
+		DisplayName:      action.DisplayName,
+		Enabled:          action.Enabled,
+		SourceBindings:   action.Bindings.Sources,
+		ExecutorBindings: action.Bindings.Executors,
+		SourceName:       sourceName,
+		SourceMatched:    sourceName == "" || sliceutil.Intersect(action.Bindings.Sources, []string{sourceName}),
+		Condition:        strings.TrimSpace(action.Condition),
+		Steps:            action.Steps,
+	}
+
+	data := renderingData{
+		Event: event,
+	}
+	errs := multierror.New()
+
+	met, err := evaluateActionCondition(action, data)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	preview.ConditionMet = met
+
+	if len(action.Steps) == 0 {
+		cmd, err := renderActionCommand(action, data)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		preview.Command = strings.TrimSpace(cmd)
+	}
+
+	preview.CooldownKey, err = renderCooldownKey(action, data)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return preview, errs.ErrorOrNil()
+}
+
+// RenderMessage renders a given action for a given event and returns the result as a message.
+// Template errors are reported in the message. An error is returned only if the action doesn't exist.
+func (p *Previewer) RenderMessage(name string, event any, sourceName string) (interactive.CoreMessage, error) {
+	preview, err := p.Render(name, event, sourceName)
+	if preview.Name == "" {
+		return interactive.CoreMessage{}, err
+	}
+	return PreviewMessage(preview, err), nil
+}
+
+// PreviewMessage returns a message describing a given preview and its rendering error.
+func PreviewMessage(preview Preview, renderErr error) interactive.CoreMessage {
+	sections := []api.Section{
+		previewBindingsSection(preview),
+		previewConditionSection(preview),
+		previewCommandSection(preview),
+	}
+	if preview.CooldownKey != "" {
+		sections = append(sections, api.Section{
+			Base: api.Base{
+				Header: "Cooldown key",
+				Body:   api.Body{CodeBlock: preview.CooldownKey},
+			},
+		})
+	}
+	if renderErr != nil {
+		sections = append(sections, api.Section{
+			Base: api.Base{
+				Header: "Errors",
+				Body:   api.Body{CodeBlock: strings.TrimSpace(renderErr.Error())},
+			},
+		})
+	}
+
+	status := "Nothing was executed."
+	if !preview.Enabled {
+		status = "Nothing was executed. The action is disabled, so it won't run for real events."
+	}
+	sections[len(sections)-1].Context = append(sections[len(sections)-1].Context, api.ContextItem{Text: status})
+
+	return interactive.CoreMessage{
+		Header: fmt.Sprintf("Dry run of automation %q", preview.DisplayName),
+		Message: api.Message{
+			Sections: sections,
+		},
+	}
+}
+
+func previewBindingsSection(preview Preview) api.Section {
+	section := api.Section{
+		Base: api.Base{
+			Header: "Bindings",
+		},
+		TextFields: api.TextFields{
+			{Key: "Sources", Value: joinOrNone(preview.SourceBindings)},
+			{Key: "Executors", Value: joinOrNone(preview.ExecutorBindings)},
+		},
+	}
+
+	switch {
+	case preview.SourceName == "":
+	case preview.SourceMatched:
+		section.Context = api.ContextItems{{Text: fmt.Sprintf("The event comes from the bound %q source.", preview.SourceName)}}
+	default:
+		section.Context = api.ContextItems{{Text: fmt.Sprintf("The event comes from the %q source, which is not bound to the action, so the action won't run for such events.", preview.SourceName)}}
+	}
+	return section
+}
+
+func previewConditionSection(preview Preview) api.Section {
+	if preview.Condition == "" {
+		return api.Section{
+			Base: api.Base{
+				Header:      "Condition",
+				Description: "No condition defined. The action runs for every event from the bound sources.",
+			},
+		}
+	}
+
+	return api.Section{
+		Base: api.Base{
+			Header: "Condition",
+			Body:   api.Body{CodeBlock: preview.Condition},
+		},
+		Context: api.ContextItems{{Text: fmt.Sprintf("Condition met: %t", preview.ConditionMet)}},
+	}
+}
+
+func previewCommandSection(preview Preview) api.Section {
+	if len(preview.Steps) > 0 {
+		return api.Section{
+			Base: api.Base{
+				Header: "Steps",
+				Body:   api.Body{CodeBlock: commandsPreview(Action{Steps: preview.Steps})},
+			},
+			Context: api.ContextItems{{Text: "Steps are rendered during execution, as they may refer to results of previous steps."}},
+		}
+	}
+
+	return api.Section{
+		Base: api.Base{
+			Header: "Command",
+			Body:   api.Body{CodeBlock: preview.Command},
+		},
+	}
+}
+
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,102 @@
+package action_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestPreviewerRender(t *testing.T) {
+	// given
+	cfg := config.Actions{
+		"show-logs": {
+			Enabled:     true,
+			DisplayName: "Show logs",
+			Command:     "kubectl logs {{ .Event.Name }} -n {{ .Event.Namespace }}",
+			Condition:   `{{ eq .Event.Namespace "prod" }}`,
+			Cooldown: config.ActionCooldown{
+				Key: "{{ .Event.Namespace }}/{{ .Event.Name }}",
+			},
+			Bindings: config.ActionBindings{
+				Sources:   []string{"k8s-err-events"},
+				Executors: []string{"kubectl-read-only"},
+			},
+		},
+		"runbook": {
+			DisplayName: "Runbook",
+			Steps: []config.ActionStep{
+				{Name: "pod", Command: "kubectl get pod {{ .Event.Name }}"},
+			},
+			Bindings: config.ActionBindings{
+				Sources: []string{"k8s-err-events"},
+			},
+		},
+		"invalid": {
+			DisplayName: "Invalid",
+			Command:     "kubectl get {{ .Event.Kind",
+			Condition:   "{{ .Event.Name }}",
+			Bindings: config.ActionBindings{
+				Sources: []string{"k8s-err-events"},
+			},
+		},
+	}
+	previewer := action.NewPreviewer(cfg)
+	event := map[string]any{"Name": "api-0", "Namespace": "prod"}
+
+	t.Run("Command", func(t *testing.T) {
+		// when
+		preview, err := previewer.Render("show-logs", event, "k8s-all-events")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, action.Preview{
+			Name:             "show-logs",
+			DisplayName:      "Show logs",
+			Enabled:          true,
+			SourceBindings:   []string{"k8s-err-events"},
+			ExecutorBindings: []string{"kubectl-read-only"},
+			SourceName:       "k8s-all-events",
+			SourceMatched:    false,
+			Condition:        `{{ eq .Event.Namespace "prod" }}`,
+			ConditionMet:     true,
+			Command:          "kubectl logs api-0 -n prod",
+			CooldownKey:      "prod/api-0",
+		}, preview)
+	})
+
+	t.Run("Steps", func(t *testing.T) {
+		// when
+		preview, err := previewer.Render("runbook", event, "")
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, preview.SourceMatched)
+		assert.True(t, preview.ConditionMet)
+		assert.Empty(t, preview.Command)
+		assert.Equal(t, cfg["runbook"].Steps, preview.Steps)
+	})
+
+	t.Run("Template errors", func(t *testing.T) {
+		// when
+		preview, err := previewer.Render("invalid", event, "k8s-err-events")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `condition "{{ .Event.Name }}" for Action "Invalid" evaluated to "api-0", which is not a boolean value`)
+		assert.Contains(t, err.Error(), `while parsing command template "kubectl get {{ .Event.Kind" for Action "Invalid"`)
+		assert.Equal(t, "invalid", preview.Name)
+		assert.True(t, preview.SourceMatched)
+	})
+
+	t.Run("Unknown action", func(t *testing.T) {
+		// when
+		_, err := previewer.RenderMessage("unknown", event, "")
+
+		// then
+		assert.EqualError(t, err, `action "unknown" not found`)
+	})
+}
@@ -5,12 +5,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"html/template"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	sprig "github.com/go-task/slim-sprig"
 	"github.com/sirupsen/logrus"
 
+	"github.com/kubeshop/botkube/internal/approval"
+	"github.com/kubeshop/botkube/internal/throttle"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
@@ -23,13 +27,22 @@ import (
 const (
 	// unknownValue defines an unknown string value.
 	unknownValue = "n/a"
+
+	defaultApprovalTimeout = time.Hour
 )
 
 // Action describes an automated action for a given event.
 type Action struct {
+	Name             string
 	Command          string
 	ExecutorBindings []string
 	DisplayName      string
+	// Steps holds steps of a multi-step action. They are rendered during execution, as they may refer to results of previous steps.
+	Steps []config.ActionStep
+	// Event is the event which triggered a multi-step action.
+	Event any
+	// LimitReached is set when the action exceeded its execution limit. Instead of executing the command, the action is disabled.
+	LimitReached bool
 }
 
 // ExecutorFactory facilitates creation of execute.Executor instances.
@@ -42,19 +55,22 @@ type Provider struct {
 	log             logrus.FieldLogger
 	cfg             config.Actions
 	executorFactory ExecutorFactory
+	tracker         *throttle.Tracker
+	actionsStorage  execute.ActionsStorage
+	approvals       *approval.Store
 }
 
 // NewProvider returns new instance of Provider.
-func NewProvider(log logrus.FieldLogger, cfg config.Actions, executorFactory ExecutorFactory) *Provider {
-	return &Provider{log: log, cfg: cfg, executorFactory: executorFactory}
+func NewProvider(log logrus.FieldLogger, cfg config.Actions, executorFactory ExecutorFactory, tracker *throttle.Tracker, actionsStorage execute.ActionsStorage, approvals *approval.Store) *Provider {
+	return &Provider{log: log, cfg: cfg, executorFactory: executorFactory, tracker: tracker, actionsStorage: actionsStorage, approvals: approvals}
 }
 
 // RenderedActions finds and processes actions for given data.
 func (p *Provider) RenderedActions(e any, sourceBindings []string) ([]Action, error) {
 	var actions []Action
 	errs := multierror.New()
-	for _, action := range p.cfg {
-		if !action.Enabled {
+	for name, action := range p.cfg {
+		if !action.Enabled || p.tracker.IsLimitReached(name) {
 			continue
 		}
 
@@ -62,23 +78,59 @@ func (p *Provider) RenderedActions(e any, sourceBindings []string) ([]Action, er
 			continue
 		}
 
-		p.log.Debugf("Rendering Action %q (command: %q)...", action.DisplayName, action.Command)
 		renderingData := renderingData{
 			Event: e,
 		}
-		renderedCmd, err := p.renderActionCommand(action, renderingData)
+		matched, err := evaluateActionCondition(action, renderingData)
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
 		}
+		if !matched {
+			p.log.Debugf("Condition for Action %q not met (condition: %q). Skipping...", action.DisplayName, action.Condition)
+			continue
+		}
 
-		p.log.Debugf("Rendered command: %q", renderedCmd)
-
-		actions = append(actions, Action{
+		act := Action{
+			Name:             name,
 			DisplayName:      action.DisplayName,
-			Command:          fmt.Sprintf("%s %s", api.MessageBotNamePlaceholder, renderedCmd),
 			ExecutorBindings: action.Bindings.Executors,
-		})
+		}
+		if len(action.Steps) > 0 {
+			act.Steps = action.Steps
+			act.Event = e
+		} else {
+			p.log.Debugf("Rendering Action %q (command: %q)...", action.DisplayName, action.Command)
+			renderedCmd, err := renderActionCommand(action, renderingData)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+
+			p.log.Debugf("Rendered command: %q", renderedCmd)
+			act.Command = fmt.Sprintf("%s %s", api.MessageBotNamePlaceholder, renderedCmd)
+		}
+
+		cooldownKey, err := renderCooldownKey(action, renderingData)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		switch p.tracker.Acquire(name, cooldownKey, action.Cooldown, action.Limit) {
+		case throttle.CoolingDown:
+			p.log.Debugf("Action %q is cooling down for key %q. Skipping...", action.DisplayName, cooldownKey)
+		case throttle.LimitReached:
+			p.log.Warnf("Action %q exceeded its execution limit. Disabling...", action.DisplayName)
+			actions = append(actions, Action{
+				Name:             name,
+				DisplayName:      action.DisplayName,
+				ExecutorBindings: action.Bindings.Executors,
+				LimitReached:     true,
+			})
+		case throttle.Allowed:
+			actions = append(actions, act)
+		}
 	}
 
 	return actions, errs.ErrorOrNil()
@@ -86,6 +138,30 @@ func (p *Provider) RenderedActions(e any, sourceBindings []string) ([]Action, er
 
 // ExecuteAction executes action for given event.
 func (p *Provider) ExecuteAction(ctx context.Context, action Action) interactive.CoreMessage {
+	if action.LimitReached {
+		return p.disableAction(ctx, action)
+	}
+
+	if p.cfg[action.Name].RequireApproval {
+		return p.requestApproval(action)
+	}
+
+	return p.run(ctx, action)
+}
+
+// run executes a given action without checking its limits and approval requirement.
+func (p *Provider) run(ctx context.Context, action Action) interactive.CoreMessage {
+	if len(action.Steps) > 0 {
+		return p.executeSteps(ctx, action)
+	}
+
+	msg, _ := p.executeCommand(ctx, action, action.Command)
+	return msg
+}
+
+// executeCommand executes a given command using action executor bindings.
+// The returned error is already reported in the returned message.
+func (p *Provider) executeCommand(ctx context.Context, action Action, cmd string) (interactive.CoreMessage, error) {
 	userName := fmt.Sprintf("Automation %q", action.DisplayName)
 	e := p.executorFactory.NewDefault(execute.NewDefaultInput{
 		Conversation: execute.Conversation{
@@ -98,7 +174,7 @@ func (p *Provider) ExecuteAction(ctx context.Context, action Action) interactive
 		CommGroupName:   unknownValue,
 		Platform:        unknownValue,
 		NotifierHandler: &universalNotifierHandler{},
-		Message:         strings.TrimSpace(strings.TrimPrefix(action.Command, api.MessageBotNamePlaceholder)),
+		Message:         strings.TrimSpace(strings.TrimPrefix(cmd, api.MessageBotNamePlaceholder)),
 		User: execute.UserInput{
 			Mention:     userName,
 			DisplayName: userName,
@@ -106,14 +182,94 @@ func (p *Provider) ExecuteAction(ctx context.Context, action Action) interactive
 	})
 	response := e.Execute(ctx)
 
-	return response
+	if reporter, ok := e.(execute.ErrorReporter); ok {
+		return response, reporter.Err()
+	}
+	return response, nil
+}
+
+// requestApproval stores the action as pending and returns a message with buttons to approve or reject it.
+func (p *Provider) requestApproval(action Action) interactive.CoreMessage {
+	cfg := p.cfg[action.Name].Approval
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultApprovalTimeout
+	}
+	expiresAt := time.Now().Add(timeout)
+
+	id := p.approvals.Add(approval.Request{
+		ActionName:  action.Name,
+		DisplayName: action.DisplayName,
+		Command:     action.Command,
+		Approvers:   cfg.Approvers,
+		ExpiresAt:   expiresAt,
+		Execute: func(ctx context.Context) interactive.CoreMessage {
+			return p.run(ctx, action)
+		},
+	})
+	p.log.WithField("approvalID", id).Infof("Action %q is waiting for approval...", action.DisplayName)
+
+	approvers := "everyone in this channel"
+	if len(cfg.Approvers) > 0 {
+		approvers = strings.Join(cfg.Approvers, ", ")
+	}
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	return interactive.CoreMessage{
+		Header: fmt.Sprintf("Automation %q requires approval", action.DisplayName),
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Description: "The following command will be executed once approved:",
+						Body: api.Body{
+							CodeBlock: commandsPreview(action),
+						},
+					},
+					Buttons: []api.Button{
+						btnBuilder.ForCommandWithoutDesc("Approve", fmt.Sprintf("%s action %s", command.ApproveVerb, id), api.ButtonStylePrimary),
+						btnBuilder.ForCommandWithoutDesc("Reject", fmt.Sprintf("%s action %s", command.RejectVerb, id), api.ButtonStyleDanger),
+					},
+					Context: []api.ContextItem{
+						{Text: fmt.Sprintf("Can be decided by %s until %s.", approvers, expiresAt.UTC().Format(time.RFC3339))},
+					},
+				},
+			},
+		},
+	}
+}
+
+// disableAction persists the disabled action state and returns a notification about it.
+func (p *Provider) disableAction(ctx context.Context, action Action) interactive.CoreMessage {
+	limit := p.cfg[action.Name].Limit
+	msg := fmt.Sprintf("Action %q was executed more than %d times within %s, so it has been disabled to prevent an execution loop. To enable it back, run `%s enable action %s`.",
+		action.DisplayName, limit.MaxExecutions, limit.Window, api.MessageBotNamePlaceholder, action.Name)
+
+	if p.actionsStorage != nil {
+		if err := p.actionsStorage.PersistActionEnabled(ctx, action.Name, false); err != nil {
+			p.log.Errorf("while disabling action %q: %s", action.Name, err.Error())
+			msg = fmt.Sprintf("Action %q was executed more than %d times within %s, so it has been disabled to prevent an execution loop until the next restart. Persisting the disabled state failed: %s",
+				action.DisplayName, limit.MaxExecutions, limit.Window, err.Error())
+		}
+	}
+
+	return interactive.CoreMessage{
+		Header: fmt.Sprintf("Automation %q disabled", action.DisplayName),
+		Message: api.Message{
+			BaseBody: api.Body{
+				Plaintext: msg,
+			},
+		},
+	}
 }
 
 type renderingData struct {
 	Event any
+	// Steps holds results of already executed steps of a multi-step action, by step name.
+	Steps map[string]stepResult
 }
 
-func (p *Provider) renderActionCommand(action config.Action, data renderingData) (string, error) {
+func renderActionCommand(action config.Action, data renderingData) (string, error) {
 	tpl := template.New("action-cmd").Funcs(sprig.FuncMap())
 	tpl, err := tpl.Parse(action.Command)
 	if err != nil {
@@ -129,6 +285,53 @@ func (p *Provider) renderActionCommand(action config.Action, data renderingData)
 	return result.String(), nil
 }
 
+// renderCooldownKey returns an empty key if a given action doesn't define it.
+func renderCooldownKey(action config.Action, data renderingData) (string, error) {
+	if action.Cooldown.Key == "" {
+		return "", nil
+	}
+
+	tpl := template.New("action-cooldown-key").Funcs(sprig.FuncMap())
+	tpl, err := tpl.Parse(action.Cooldown.Key)
+	if err != nil {
+		return "", fmt.Errorf("while parsing cooldown key template %q for Action %q: %w", action.Cooldown.Key, action.DisplayName, err)
+	}
+
+	var result bytes.Buffer
+	err = tpl.Execute(&result, data)
+	if err != nil {
+		return "", fmt.Errorf("while rendering cooldown key %q for Action %q: %w", action.Cooldown.Key, action.DisplayName, err)
+	}
+
+	return result.String(), nil
+}
+
+// evaluateActionCondition returns true if a given action has no condition, or its condition evaluates to "true".
+func evaluateActionCondition(action config.Action, data renderingData) (bool, error) {
+	if strings.TrimSpace(action.Condition) == "" {
+		return true, nil
+	}
+
+	tpl := template.New("action-condition").Funcs(sprig.FuncMap())
+	tpl, err := tpl.Parse(action.Condition)
+	if err != nil {
+		return false, fmt.Errorf("while parsing condition template %q for Action %q: %w", action.Condition, action.DisplayName, err)
+	}
+
+	var result bytes.Buffer
+	err = tpl.Execute(&result, data)
+	if err != nil {
+		return false, fmt.Errorf("while evaluating condition %q for Action %q: %w", action.Condition, action.DisplayName, err)
+	}
+
+	out := strings.TrimSpace(result.String())
+	matched, err := strconv.ParseBool(out)
+	if err != nil {
+		return false, fmt.Errorf("condition %q for Action %q evaluated to %q, which is not a boolean value", action.Condition, action.DisplayName, out)
+	}
+	return matched, nil
+}
+
 type universalNotifierHandler struct{}
 
 func (n *universalNotifierHandler) NotificationsEnabled(_ string) bool {
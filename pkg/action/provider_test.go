@@ -3,12 +3,16 @@ package action_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kubeshop/botkube/internal/approval"
+	"github.com/kubeshop/botkube/internal/throttle"
 	"github.com/kubeshop/botkube/pkg/action"
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
@@ -35,12 +39,27 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 			Event:          fixEvent("name"),
 			ExpectedResult: []action.Action{
 				{
+					Name:             "success",
 					Command:          "{{BotName}} kubectl get po name",
 					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
 					DisplayName:      "Success",
 				},
 			},
 		},
+		{
+			Name:           "Success - values are not escaped",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"success"},
+			Event:          fixEvent("api+v2's"),
+			ExpectedResult: []action.Action{
+				{
+					Name:             "success",
+					Command:          "{{BotName}} kubectl get po api+v2's",
+					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
+					DisplayName:      "Success",
+				},
+			},
+		},
 		{
 			Name:           "No matching actions",
 			Config:         fixActionsConfig(),
@@ -55,6 +74,7 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 			Event:          fixEvent("name"),
 			ExpectedResult: []action.Action{
 				{
+					Name:             "success",
 					Command:          "{{BotName}} kubectl get po name",
 					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
 					DisplayName:      "Success",
@@ -64,11 +84,59 @@ func TestProvider_RenderedActionsForEvent(t *testing.T) {
 				1 error occurred:
 					* while rendering command "kubectl get po {{ .SomethingElse }}" for Action "Invalid Command": template: action-cmd:1:18: executing "action-cmd" at <.SomethingElse>: can't evaluate field SomethingElse in type action.renderingData`),
 		},
+		{
+			Name:           "Condition met",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"conditional"},
+			Event:          fixEvent("oom"),
+			ExpectedResult: []action.Action{
+				{
+					Name:             "conditional",
+					Command:          "{{BotName}} kubectl get po oom",
+					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
+					DisplayName:      "Conditional",
+				},
+			},
+		},
+		{
+			Name:           "Condition not met",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"conditional"},
+			Event:          fixEvent("name"),
+			ExpectedResult: nil,
+		},
+		{
+			Name:           "Condition which cannot be evaluated",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"success", "invalid-condition"},
+			Event:          fixEvent("name"),
+			ExpectedResult: []action.Action{
+				{
+					Name:             "success",
+					Command:          "{{BotName}} kubectl get po name",
+					ExecutorBindings: []string{"executor-binding1", "executor-binding2"},
+					DisplayName:      "Success",
+				},
+			},
+			ExpectedErrMessage: heredoc.Doc(`
+				1 error occurred:
+					* while evaluating condition "{{ eq .Event.Reason \"OOMKilled\" }}" for Action "Invalid Condition": template: action-condition:1:12: executing "action-condition" at <.Event.Reason>: can't evaluate field Reason in type interface {}`),
+		},
+		{
+			Name:           "Condition which doesn't evaluate to a boolean value",
+			Config:         fixActionsConfig(),
+			SourceBindings: []string{"non-bool-condition"},
+			Event:          fixEvent("name"),
+			ExpectedResult: nil,
+			ExpectedErrMessage: heredoc.Doc(`
+				1 error occurred:
+					* condition "{{ .Event.Name }}" for Action "Non-bool Condition" evaluated to "name", which is not a boolean value`),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
-			provider := action.NewProvider(loggerx.NewNoop(), tc.Config, nil)
+			provider := action.NewProvider(loggerx.NewNoop(), tc.Config, nil, nil, nil, nil)
 
 			// when
 			result, err := provider.RenderedActions(tc.Event, tc.SourceBindings)
@@ -114,7 +182,7 @@ func TestProvider_ExecuteEventAction(t *testing.T) {
 	}
 
 	execFactory := &fakeFactory{t: t, expectedInput: expectedExecutorInput}
-	provider := action.NewProvider(loggerx.NewNoop(), config.Actions{}, execFactory)
+	provider := action.NewProvider(loggerx.NewNoop(), config.Actions{}, execFactory, nil, nil, nil)
 
 	// when
 	msg := provider.ExecuteAction(context.Background(), eventAction)
@@ -124,6 +192,147 @@ func TestProvider_ExecuteEventAction(t *testing.T) {
 	assert.Equal(t, fixInteractiveMessage(botName), msg)
 }
 
+func TestProvider_RenderedActionsWithThrottling(t *testing.T) {
+	// given
+	cfg := config.Actions{
+		"restart": {
+			Enabled:     true,
+			DisplayName: "Restart",
+			Command:     "kubectl rollout restart deploy/{{ .Event.Name }}",
+			Cooldown: config.ActionCooldown{
+				Key:    "{{ .Event.Name }}",
+				Period: time.Hour,
+			},
+			Limit: config.ActionLimit{
+				MaxExecutions: 2,
+				Window:        time.Hour,
+			},
+			Bindings: config.ActionBindings{
+				Sources:   []string{"k8s-events"},
+				Executors: []string{"k8s-tools"},
+			},
+		},
+	}
+	tracker := throttle.NewTracker()
+	storage := &fakeActionsStorage{}
+	provider := action.NewProvider(loggerx.NewNoop(), cfg, nil, tracker, storage, nil)
+
+	fixRestartAction := func(name string) []action.Action {
+		return []action.Action{
+			{
+				Name:             "restart",
+				Command:          "{{BotName}} kubectl rollout restart deploy/" + name,
+				ExecutorBindings: []string{"k8s-tools"},
+				DisplayName:      "Restart",
+			},
+		}
+	}
+
+	// when
+	var results [][]action.Action
+	for _, name := range []string{"api", "api", "web", "worker", "api"} {
+		result, err := provider.RenderedActions(fixEvent(name), []string{"k8s-events"})
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+
+	// then
+	assert.Equal(t, [][]action.Action{
+		fixRestartAction("api"),
+		nil, // cooling down
+		fixRestartAction("web"),
+		{
+			{
+				Name:             "restart",
+				ExecutorBindings: []string{"k8s-tools"},
+				DisplayName:      "Restart",
+				LimitReached:     true,
+			},
+		},
+		nil, // disabled
+	}, results)
+
+	// when
+	msg := provider.ExecuteAction(context.Background(), results[3][0])
+
+	// then
+	assert.Equal(t, map[string]bool{"restart": false}, storage.enabled)
+	assert.Equal(t, `Automation "Restart" disabled`, msg.Header)
+	assert.Equal(t, "Action \"Restart\" was executed more than 2 times within 1h0m0s, so it has been disabled to prevent an execution loop. To enable it back, run `{{BotName}} enable action restart`.", msg.BaseBody.Plaintext)
+
+	executions, _, limitReached := tracker.GetStats("restart")
+	assert.Equal(t, 2, executions)
+	assert.True(t, limitReached)
+}
+
+func TestProvider_ExecuteActionWithApproval(t *testing.T) {
+	// given
+	executorBindings := []string{"k8s-tools"}
+	cfg := config.Actions{
+		"scale-down": {
+			Enabled:         true,
+			DisplayName:     "Scale down",
+			RequireApproval: true,
+			Approval: config.ActionApproval{
+				Approvers: []string{"alice", "U123"},
+				Timeout:   time.Minute,
+			},
+		},
+	}
+	eventAction := action.Action{
+		Name:             "scale-down",
+		Command:          "{{BotName}} kubectl scale deploy api --replicas=0",
+		ExecutorBindings: executorBindings,
+		DisplayName:      "Scale down",
+	}
+	userName := `Automation "Scale down"`
+	execFactory := &fakeFactory{t: t, expectedInput: execute.NewDefaultInput{
+		CommGroupName: "n/a",
+		Platform:      "n/a",
+		Conversation: execute.Conversation{
+			Alias:            "n/a",
+			ID:               "n/a",
+			ExecutorBindings: executorBindings,
+			IsKnown:          true,
+			CommandOrigin:    command.AutomationOrigin,
+		},
+		Message: "kubectl scale deploy api --replicas=0",
+		User: execute.UserInput{
+			Mention:     userName,
+			DisplayName: userName,
+		},
+	}}
+	approvals := approval.NewStore()
+	provider := action.NewProvider(loggerx.NewNoop(), cfg, execFactory, nil, nil, approvals)
+
+	// when
+	msg := provider.ExecuteAction(context.Background(), eventAction)
+
+	// then
+	assert.Equal(t, `Automation "Scale down" requires approval`, msg.Header)
+	require.Len(t, msg.Sections, 1)
+	section := msg.Sections[0]
+	assert.Equal(t, "{{BotName}} kubectl scale deploy api --replicas=0", section.Body.CodeBlock)
+	require.Len(t, section.Buttons, 2)
+
+	id := strings.TrimPrefix(section.Buttons[0].Command, "{{BotName}} approve action ")
+	assert.Equal(t, "{{BotName}} reject action "+id, section.Buttons[1].Command)
+	require.Len(t, section.Context, 1)
+	assert.Contains(t, section.Context[0].Text, "Can be decided by alice, U123 until")
+
+	req, found := approvals.Get(id)
+	require.True(t, found)
+	assert.Equal(t, "scale-down", req.ActionName)
+	assert.Equal(t, []string{"alice", "U123"}, req.Approvers)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), req.ExpiresAt, 5*time.Second)
+
+	// when
+	out := req.Execute(context.Background())
+
+	// then
+	assert.Equal(t, fixInteractiveMessage("{{BotName}}"), out)
+}
+
 func fixActionsConfig() config.Actions {
 	executorBindings := []string{"executor-binding1", "executor-binding2"}
 	sampleCommand := "kubectl get po {{ .Event.Name }}"
@@ -155,6 +364,36 @@ func fixActionsConfig() config.Actions {
 				Executors: executorBindings,
 			},
 		},
+		"conditional": {
+			Enabled:     true,
+			DisplayName: "Conditional",
+			Command:     sampleCommand,
+			Condition:   `{{ eq .Event.Name "oom" }}`,
+			Bindings: config.ActionBindings{
+				Sources:   []string{"conditional"},
+				Executors: executorBindings,
+			},
+		},
+		"invalid-condition": {
+			Enabled:     true,
+			DisplayName: "Invalid Condition",
+			Command:     sampleCommand,
+			Condition:   `{{ eq .Event.Reason "OOMKilled" }}`,
+			Bindings: config.ActionBindings{
+				Sources:   []string{"invalid-condition"},
+				Executors: executorBindings,
+			},
+		},
+		"non-bool-condition": {
+			Enabled:     true,
+			DisplayName: "Non-bool Condition",
+			Command:     sampleCommand,
+			Condition:   "{{ .Event.Name }}",
+			Bindings: config.ActionBindings{
+				Sources:   []string{"non-bool-condition"},
+				Executors: executorBindings,
+			},
+		},
 		"invalid-command": {
 			Enabled:     true,
 			DisplayName: "Invalid Command",
@@ -177,6 +416,18 @@ func fixEvent(name string) event {
 	}
 }
 
+type fakeActionsStorage struct {
+	enabled map[string]bool
+}
+
+func (f *fakeActionsStorage) PersistActionEnabled(_ context.Context, name string, enabled bool) error {
+	if f.enabled == nil {
+		f.enabled = map[string]bool{}
+	}
+	f.enabled[name] = enabled
+	return nil
+}
+
 type fakeFactory struct {
 	t             *testing.T
 	expectedInput execute.NewDefaultInput
@@ -0,0 +1,188 @@
+package action
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	sprig "github.com/go-task/slim-sprig"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/maputil"
+)
+
+const stepSkippedMsg = "Skipped, as one of the previous steps failed."
+
+// stepResult holds a result of an executed step. It's available in templates of subsequent steps.
+type stepResult struct {
+	Output string
+	Values map[string]string
+	Failed bool
+}
+
+// executeSteps executes steps of a multi-step action one by one and returns their results as a single message.
+func (p *Provider) executeSteps(ctx context.Context, action Action) interactive.CoreMessage {
+	data := renderingData{
+		Event: action.Event,
+		Steps: map[string]stepResult{},
+	}
+
+	var (
+		sections []api.Section
+		aborted  bool
+	)
+	for i, step := range action.Steps {
+		section := api.Section{
+			Base: api.Base{
+				Header: fmt.Sprintf("%d. %s", i+1, step.Name),
+			},
+		}
+		if aborted {
+			section.Context = api.ContextItems{{Text: stepSkippedMsg}}
+			sections = append(sections, section)
+			continue
+		}
+
+		cmd, result, err := p.executeStep(ctx, action, step, data)
+		data.Steps[step.Name] = result
+
+		if cmd != "" {
+			section.Description = fmt.Sprintf("`%s`", cmd)
+		}
+		section.Body.CodeBlock = result.Output
+		if err != nil {
+			p.log.WithField("step", step.Name).Errorf("while executing step of Action %q: %s", action.DisplayName, err.Error())
+			section.Context = api.ContextItems{{Text: fmt.Sprintf("Step failed: %s", err.Error())}}
+			aborted = !step.ContinueOnError
+		}
+		sections = append(sections, section)
+	}
+
+	return interactive.CoreMessage{
+		Header: fmt.Sprintf("Results of automation %q", action.DisplayName),
+		Message: api.Message{
+			Sections: sections,
+		},
+	}
+}
+
+func (p *Provider) executeStep(ctx context.Context, action Action, step config.ActionStep, data renderingData) (string, stepResult, error) {
+	cmd, err := renderStepCommand(step, data)
+	if err != nil {
+		return "", stepResult{Failed: true}, err
+	}
+
+	msg, err := p.executeCommand(ctx, action, cmd)
+	result := stepResult{Output: commandOutput(msg)}
+	if err != nil {
+		result.Failed = true
+		return cmd, result, err
+	}
+
+	result.Values, err = extractValues(step.Extract, result.Output)
+	if err != nil {
+		result.Failed = true
+		return cmd, result, err
+	}
+	return cmd, result, nil
+}
+
+func renderStepCommand(step config.ActionStep, data renderingData) (string, error) {
+	tpl := template.New("action-step").Funcs(sprig.FuncMap())
+	tpl, err := tpl.Parse(step.Command)
+	if err != nil {
+		return "", fmt.Errorf("while parsing command template %q: %w", step.Command, err)
+	}
+
+	var result bytes.Buffer
+	err = tpl.Execute(&result, data)
+	if err != nil {
+		return "", fmt.Errorf("while rendering command %q: %w", step.Command, err)
+	}
+
+	return strings.TrimSpace(result.String()), nil
+}
+
+// commandOutput returns a raw output of an executed command.
+func commandOutput(msg interactive.CoreMessage) string {
+	switch {
+	case execute.IsEmptyResponse(msg.Message):
+		return ""
+	case msg.BaseBody.CodeBlock != "":
+		return msg.BaseBody.CodeBlock
+	case msg.BaseBody.Plaintext != "":
+		return msg.BaseBody.Plaintext
+	default:
+		return strings.TrimSpace(interactive.MessageToPlaintext(interactive.CoreMessage{Message: msg.Message}, interactive.NewlineFormatter))
+	}
+}
+
+func extractValues(extracts map[string]config.ActionStepExtract, output string) (map[string]string, error) {
+	if len(extracts) == 0 {
+		return nil, nil
+	}
+
+	out := map[string]string{}
+	for _, key := range maputil.SortKeys(extracts) {
+		val, err := extractValue(extracts[key], output)
+		if err != nil {
+			return out, fmt.Errorf("while extracting %q: %w", key, err)
+		}
+		out[key] = val
+	}
+	return out, nil
+}
+
+func extractValue(extract config.ActionStepExtract, output string) (string, error) {
+	if extract.Regex != "" {
+		re, err := regexp.Compile(extract.Regex)
+		if err != nil {
+			return "", fmt.Errorf("while compiling regex: %w", err)
+		}
+		match := re.FindStringSubmatch(output)
+		switch len(match) {
+		case 0:
+			return "", fmt.Errorf("regex %q doesn't match the output", extract.Regex)
+		case 1:
+			return match[0], nil
+		default:
+			return match[1], nil
+		}
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(output), &data); err != nil {
+		return "", fmt.Errorf("while parsing output as JSON: %w", err)
+	}
+
+	jp := jsonpath.New("extract")
+	if err := jp.Parse(extract.JSONPath); err != nil {
+		return "", fmt.Errorf("while parsing JSONPath %q: %w", extract.JSONPath, err)
+	}
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("while executing JSONPath %q: %w", extract.JSONPath, err)
+	}
+	return buf.String(), nil
+}
+
+// commandsPreview returns a command, or all step commands of a given action in the template form.
+func commandsPreview(action Action) string {
+	if len(action.Steps) == 0 {
+		return action.Command
+	}
+
+	var out []string
+	for i, step := range action.Steps {
+		out = append(out, fmt.Sprintf("%d. %s: %s", i+1, step.Name, step.Command))
+	}
+	return strings.Join(out, "\n")
+}
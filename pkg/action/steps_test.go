@@ -0,0 +1,220 @@
+package action_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/botkube/pkg/action"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestProvider_ExecuteMultiStepAction(t *testing.T) {
+	// given
+	steps := []config.ActionStep{
+		{
+			Name:    "pod",
+			Command: "kubectl get pods -n {{ .Event.Name }} -o json",
+			Extract: map[string]config.ActionStepExtract{
+				"name": {JSONPath: "{.items[0].metadata.name}"},
+				"node": {Regex: `"nodeName":\s*"([^"]+)"`},
+			},
+		},
+		{
+			Name:            "logs",
+			Command:         "kubectl logs {{ .Steps.pod.Values.name }} -n {{ .Event.Name }}",
+			ContinueOnError: true,
+		},
+		{
+			Name:    "node",
+			Command: "kubectl describe node {{ .Steps.pod.Values.node }}{{ if .Steps.logs.Failed }} --show-events{{ end }}",
+		},
+		{
+			Name:    "events",
+			Command: "kubectl get events -n {{ .Event.Name }}",
+		},
+		{
+			Name:    "top",
+			Command: "kubectl top pod {{ .Steps.pod.Values.name }}",
+		},
+	}
+	factory := &fakeStepsFactory{
+		results: map[string]fakeStepResult{
+			"kubectl get pods -n prod -o json":           {output: `{"items": [{"metadata": {"name": "api-1"}, "spec": {"nodeName": "node-a"}}]}`},
+			"kubectl logs api-1 -n prod":                 {output: "container is terminated", err: errors.New("exit status 1")},
+			"kubectl describe node node-a --show-events": {output: "Name: node-a"},
+			"kubectl get events -n prod":                 {output: "Forbidden", err: errors.New("forbidden")},
+		},
+	}
+	provider := action.NewProvider(loggerx.NewNoop(), config.Actions{}, factory, nil, nil, nil)
+
+	// when
+	msg := provider.ExecuteAction(context.Background(), action.Action{
+		Name:             "runbook",
+		DisplayName:      "Runbook",
+		ExecutorBindings: []string{"k8s-tools"},
+		Steps:            steps,
+		Event:            fixEvent("prod"),
+	})
+
+	// then
+	assert.Equal(t, []string{
+		"kubectl get pods -n prod -o json",
+		"kubectl logs api-1 -n prod",
+		"kubectl describe node node-a --show-events",
+		"kubectl get events -n prod",
+	}, factory.executed)
+	assert.Equal(t, interactive.CoreMessage{
+		Header: `Results of automation "Runbook"`,
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Header:      "1. pod",
+						Description: "`kubectl get pods -n prod -o json`",
+						Body:        api.Body{CodeBlock: `{"items": [{"metadata": {"name": "api-1"}, "spec": {"nodeName": "node-a"}}]}`},
+					},
+				},
+				{
+					Base: api.Base{
+						Header:      "2. logs",
+						Description: "`kubectl logs api-1 -n prod`",
+						Body:        api.Body{CodeBlock: "container is terminated"},
+					},
+					Context: api.ContextItems{{Text: "Step failed: exit status 1"}},
+				},
+				{
+					Base: api.Base{
+						Header:      "3. node",
+						Description: "`kubectl describe node node-a --show-events`",
+						Body:        api.Body{CodeBlock: "Name: node-a"},
+					},
+				},
+				{
+					Base: api.Base{
+						Header:      "4. events",
+						Description: "`kubectl get events -n prod`",
+						Body:        api.Body{CodeBlock: "Forbidden"},
+					},
+					Context: api.ContextItems{{Text: "Step failed: forbidden"}},
+				},
+				{
+					Base: api.Base{
+						Header: "5. top",
+					},
+					Context: api.ContextItems{{Text: "Skipped, as one of the previous steps failed."}},
+				},
+			},
+		},
+	}, msg)
+}
+
+func TestProvider_ExecuteMultiStepActionExtractionError(t *testing.T) {
+	// given
+	steps := []config.ActionStep{
+		{
+			Name:    "pod",
+			Command: "kubectl get pods -o name",
+			Extract: map[string]config.ActionStepExtract{
+				"name": {Regex: `pod/(\S+)`},
+			},
+		},
+		{
+			Name:    "logs",
+			Command: "kubectl logs {{ .Steps.pod.Values.name }}",
+		},
+	}
+	factory := &fakeStepsFactory{
+		results: map[string]fakeStepResult{
+			"kubectl get pods -o name": {output: "No resources found"},
+		},
+	}
+	provider := action.NewProvider(loggerx.NewNoop(), config.Actions{}, factory, nil, nil, nil)
+
+	// when
+	msg := provider.ExecuteAction(context.Background(), action.Action{
+		Name:        "runbook",
+		DisplayName: "Runbook",
+		Steps:       steps,
+		Event:       fixEvent("prod"),
+	})
+
+	// then
+	assert.Equal(t, []string{"kubectl get pods -o name"}, factory.executed)
+	assert.Equal(t, api.ContextItems{{Text: `Step failed: while extracting "name": regex "pod/(\\S+)" doesn't match the output`}}, msg.Sections[0].Context)
+	assert.Equal(t, api.ContextItems{{Text: "Skipped, as one of the previous steps failed."}}, msg.Sections[1].Context)
+}
+
+func TestProvider_ExecuteMultiStepActionDoesNotEscapeValues(t *testing.T) {
+	// given
+	steps := []config.ActionStep{
+		{
+			Name:    "deploy",
+			Command: "kubectl get deploy api --show-labels",
+			Extract: map[string]config.ActionStepExtract{
+				"labels": {Regex: `labels=(\S+)`},
+			},
+		},
+		{
+			Name:    "pods",
+			Command: "kubectl get pods -l '{{ .Steps.deploy.Values.labels }}'",
+		},
+	}
+	factory := &fakeStepsFactory{
+		results: map[string]fakeStepResult{
+			"kubectl get deploy api --show-labels": {output: "labels=app=api,version=1.0+build"},
+		},
+	}
+	provider := action.NewProvider(loggerx.NewNoop(), config.Actions{}, factory, nil, nil, nil)
+
+	// when
+	provider.ExecuteAction(context.Background(), action.Action{
+		Name:        "runbook",
+		DisplayName: "Runbook",
+		Steps:       steps,
+		Event:       fixEvent("prod"),
+	})
+
+	// then
+	assert.Equal(t, []string{
+		"kubectl get deploy api --show-labels",
+		"kubectl get pods -l 'app=api,version=1.0+build'",
+	}, factory.executed)
+}
+
+type fakeStepResult struct {
+	output string
+	err    error
+}
+
+type fakeStepsFactory struct {
+	results  map[string]fakeStepResult
+	executed []string
+}
+
+func (f *fakeStepsFactory) NewDefault(input execute.NewDefaultInput) execute.Executor {
+	f.executed = append(f.executed, input.Message)
+	return &fakeStepExecutor{result: f.results[input.Message]}
+}
+
+type fakeStepExecutor struct {
+	result fakeStepResult
+}
+
+func (f *fakeStepExecutor) Execute(context.Context) interactive.CoreMessage {
+	return interactive.CoreMessage{
+		Message: api.Message{
+			BaseBody: api.Body{CodeBlock: f.result.output},
+		},
+	}
+}
+
+func (f *fakeStepExecutor) Err() error {
+	return f.result.err
+}
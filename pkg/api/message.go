@@ -79,6 +79,10 @@ type Message struct {
 
 	// ParentActivityID represents the originating message that started a thread. If set, message will be sent in that thread instead of the default one.
 	ParentActivityID string `json:"parentActivityId,omitempty" yaml:"parentActivityId,omitempty"`
+
+	// UpdateKey identifies a message sent by a source, e.g. an alert group. If set, platforms which support editing messages
+	// update the previously sent message with the same key instead of posting a new one.
+	UpdateKey string `json:"updateKey,omitempty" yaml:"updateKey,omitempty"`
 }
 
 func (msg *Message) IsEmpty() bool {
@@ -91,6 +91,7 @@ type (
 		// You can construct a complex message.data or just use one of our helper functions:
 		//   - api.NewCodeBlockMessage("body", true)
 		//   - api.NewPlaintextMessage("body", true)
+		// If both the message and raw object are empty, e.g. when the payload was filtered out, nothing is dispatched.
 		Event Event
 	}
 
@@ -0,0 +1,227 @@
+package transformer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/api/source"
+)
+
+// Transformer defines the Botkube transformer plugin functionality.
+type Transformer interface {
+	Transform(context.Context, TransformInput) (TransformOutput, error)
+	Metadata(context.Context) (api.MetadataOutput, error)
+}
+
+type (
+	// TransformInput holds the input of the Transform function.
+	TransformInput struct {
+		// Configs is a list of Transformer configurations specified by users.
+		Configs []*Config
+		// Event is the source event before dispatching it to communication platforms.
+		// It is either the event emitted by a source plugin, or the output of the previous transformer in a chain.
+		Event source.Event
+		// Context holds transformation context.
+		Context TransformInputContext
+	}
+
+	// TransformInputContext holds transformation context.
+	TransformInputContext struct {
+		// IsInteractivitySupported is set to true only if the event is dispatched to communication platforms which support interactive Messages.
+		IsInteractivitySupported bool
+
+		// ClusterName is the name of the underlying Kubernetes cluster which is provided by end user.
+		ClusterName string
+
+		// SourceName is the name of the source configuration which emitted the event.
+		SourceName string
+
+		// SourcePluginName is the name of the source plugin which emitted the event.
+		SourcePluginName string
+	}
+
+	// TransformOutput holds the output of the Transform function.
+	TransformOutput struct {
+		// Event is the transformed event. It may be the unmodified input event.
+		Event source.Event
+		// Drop is set to true if the event should not be dispatched. Remaining transformers in a chain are not called.
+		Drop bool
+	}
+)
+
+// ProtocolVersion is the version that must match between Botkube core
+// and Botkube plugins. This should be bumped whenever a change happens in
+// one or the other that makes it so that they can't safely communicate.
+// This could be adding a new interface value, it could be how helper/schema computes diffs, etc.
+//
+// NOTE: In the future we can consider using VersionedPlugins. These can be used to negotiate
+// a compatible version between client and server. If this is set, Handshake.ProtocolVersion is not required.
+const ProtocolVersion = 3
+
+var _ plugin.GRPCPlugin = &Plugin{}
+
+// Plugin This is the implementation of plugin.GRPCPlugin, so we can serve and consume different Botkube Transformers.
+type Plugin struct {
+	// The GRPC plugin must still implement the Plugin interface.
+	plugin.NetRPCUnsupportedPlugin
+
+	// Transformer represents a concrete implementation that handles the business logic.
+	Transformer Transformer
+}
+
+// GRPCServer registers plugin for serving with the given GRPCServer.
+func (p *Plugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	RegisterTransformerServer(s, &grpcServer{
+		Impl: p.Transformer,
+	})
+	return nil
+}
+
+// GRPCClient returns the interface implementation for the plugin that is serving via gRPC by GRPCServer.
+func (p *Plugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &grpcClient{
+		client: NewTransformerClient(c),
+	}, nil
+}
+
+type grpcClient struct {
+	client TransformerClient
+}
+
+func (p *grpcClient) Transform(ctx context.Context, in TransformInput) (TransformOutput, error) {
+	rawEvent, err := json.Marshal(in.Event)
+	if err != nil {
+		return TransformOutput{}, fmt.Errorf("while marshalling event to JSON: %w", err)
+	}
+
+	res, err := p.client.Transform(ctx, &TransformRequest{
+		Configs: in.Configs,
+		Event:   rawEvent,
+		Context: &TransformContext{
+			IsInteractivitySupported: in.Context.IsInteractivitySupported,
+			ClusterName:              in.Context.ClusterName,
+			SourceName:               in.Context.SourceName,
+			SourcePluginName:         in.Context.SourcePluginName,
+		},
+	})
+	if err != nil {
+		return TransformOutput{}, err
+	}
+
+	if res.Drop {
+		return TransformOutput{Drop: true}, nil
+	}
+
+	var event source.Event
+	if len(res.Event) != 0 {
+		if err := json.Unmarshal(res.Event, &event); err != nil {
+			return TransformOutput{}, fmt.Errorf("while unmarshalling event from JSON: %w", err)
+		}
+	}
+
+	return TransformOutput{
+		Event: event,
+	}, nil
+}
+
+func (p *grpcClient) Metadata(ctx context.Context) (api.MetadataOutput, error) {
+	resp, err := p.client.Metadata(ctx, &emptypb.Empty{})
+	if err != nil {
+		return api.MetadataOutput{}, err
+	}
+
+	return api.MetadataOutput{
+		Version:          resp.Version,
+		Description:      resp.Description,
+		DocumentationURL: resp.DocumentationUrl,
+		JSONSchema: api.JSONSchema{
+			Value:  resp.GetJsonSchema().GetValue(),
+			RefURL: resp.GetJsonSchema().GetRefUrl(),
+		},
+		Dependencies: api.ConvertDependenciesToAPI(resp.Dependencies),
+		Recommended:  resp.Recommended,
+	}, nil
+}
+
+type grpcServer struct {
+	UnimplementedTransformerServer
+	Impl Transformer
+}
+
+func (p *grpcServer) Transform(ctx context.Context, request *TransformRequest) (*TransformResponse, error) {
+	var event source.Event
+	if len(request.Event) != 0 {
+		if err := json.Unmarshal(request.Event, &event); err != nil {
+			return nil, fmt.Errorf("while unmarshalling event from JSON: %w", err)
+		}
+	}
+
+	var transformCtx TransformInputContext
+	if request.Context != nil {
+		transformCtx = TransformInputContext{
+			IsInteractivitySupported: request.Context.IsInteractivitySupported,
+			ClusterName:              request.Context.ClusterName,
+			SourceName:               request.Context.SourceName,
+			SourcePluginName:         request.Context.SourcePluginName,
+		}
+	}
+
+	out, err := p.Impl.Transform(ctx, TransformInput{
+		Configs: request.Configs,
+		Event:   event,
+		Context: transformCtx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if out.Drop {
+		return &TransformResponse{Drop: true}, nil
+	}
+
+	marshalled, err := json.Marshal(out.Event)
+	if err != nil {
+		return nil, fmt.Errorf("while marshalling event to JSON: %w", err)
+	}
+
+	return &TransformResponse{
+		Event: marshalled,
+	}, nil
+}
+
+func (p *grpcServer) Metadata(ctx context.Context, _ *emptypb.Empty) (*MetadataResponse, error) {
+	meta, err := p.Impl.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &MetadataResponse{
+		Version:          meta.Version,
+		Description:      meta.Description,
+		DocumentationUrl: meta.DocumentationURL,
+		JsonSchema: &JSONSchema{
+			Value:  meta.JSONSchema.Value,
+			RefUrl: meta.JSONSchema.RefURL,
+		},
+		Dependencies: api.ConvertDependenciesFromAPI[*Dependency, Dependency](meta.Dependencies),
+		Recommended:  meta.Recommended,
+	}, nil
+}
+
+// Serve serves given plugins.
+func Serve(p map[string]plugin.Plugin) {
+	plugin.Serve(&plugin.ServeConfig{
+		Plugins: p,
+		HandshakeConfig: plugin.HandshakeConfig{
+			ProtocolVersion:  ProtocolVersion,
+			MagicCookieKey:   api.HandshakeConfig.MagicCookieKey,
+			MagicCookieValue: api.HandshakeConfig.MagicCookieValue,
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
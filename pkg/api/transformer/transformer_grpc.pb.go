@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.0
+// source: transformer.proto
+
+package transformer
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Transformer_Transform_FullMethodName = "/transformer.Transformer/Transform"
+	Transformer_Metadata_FullMethodName  = "/transformer.Transformer/Metadata"
+)
+
+// TransformerClient is the client API for Transformer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TransformerClient interface {
+	Transform(ctx context.Context, in *TransformRequest, opts ...grpc.CallOption) (*TransformResponse, error)
+	Metadata(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetadataResponse, error)
+}
+
+type transformerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransformerClient(cc grpc.ClientConnInterface) TransformerClient {
+	return &transformerClient{cc}
+}
+
+func (c *transformerClient) Transform(ctx context.Context, in *TransformRequest, opts ...grpc.CallOption) (*TransformResponse, error) {
+	out := new(TransformResponse)
+	err := c.cc.Invoke(ctx, Transformer_Transform_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transformerClient) Metadata(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MetadataResponse, error) {
+	out := new(MetadataResponse)
+	err := c.cc.Invoke(ctx, Transformer_Metadata_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransformerServer is the server API for Transformer service.
+// All implementations must embed UnimplementedTransformerServer
+// for forward compatibility
+type TransformerServer interface {
+	Transform(context.Context, *TransformRequest) (*TransformResponse, error)
+	Metadata(context.Context, *emptypb.Empty) (*MetadataResponse, error)
+	mustEmbedUnimplementedTransformerServer()
+}
+
+// UnimplementedTransformerServer must be embedded to have forward compatible implementations.
+type UnimplementedTransformerServer struct {
+}
+
+func (UnimplementedTransformerServer) Transform(context.Context, *TransformRequest) (*TransformResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transform not implemented")
+}
+func (UnimplementedTransformerServer) Metadata(context.Context, *emptypb.Empty) (*MetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Metadata not implemented")
+}
+func (UnimplementedTransformerServer) mustEmbedUnimplementedTransformerServer() {}
+
+// UnsafeTransformerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TransformerServer will
+// result in compilation errors.
+type UnsafeTransformerServer interface {
+	mustEmbedUnimplementedTransformerServer()
+}
+
+func RegisterTransformerServer(s grpc.ServiceRegistrar, srv TransformerServer) {
+	s.RegisterService(&Transformer_ServiceDesc, srv)
+}
+
+func _Transformer_Transform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransformRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransformerServer).Transform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Transformer_Transform_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransformerServer).Transform(ctx, req.(*TransformRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Transformer_Metadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransformerServer).Metadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Transformer_Metadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransformerServer).Metadata(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Transformer_ServiceDesc is the grpc.ServiceDesc for Transformer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Transformer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "transformer.Transformer",
+	HandlerType: (*TransformerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Transform",
+			Handler:    _Transformer_Transform_Handler,
+		},
+		{
+			MethodName: "Metadata",
+			Handler:    _Transformer_Metadata_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "transformer.proto",
+}
@@ -3,11 +3,14 @@ package bot
 import (
 	"context"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/kubeshop/botkube/internal/analytics"
 	"github.com/kubeshop/botkube/internal/health"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/notifier"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
 const (
@@ -71,6 +74,23 @@ type channelConfigByName struct {
 	notify bool
 }
 
+// channelFilter reports whether a channel with a given identifier, alias and source bindings should be notified.
+type channelFilter func(identifier, alias string, sourceBindings []string) bool
+
+// boundToSources returns a filter which selects channels bound to any of given sources.
+func boundToSources(sources []string) channelFilter {
+	return func(_, _ string, sourceBindings []string) bool {
+		return sliceutil.Intersect(sources, sourceBindings)
+	}
+}
+
+// selectedChannels returns a filter which selects channels referenced by identifiers or aliases.
+func selectedChannels(channels []string) channelFilter {
+	return func(identifier, alias string, _ []string) bool {
+		return slices.Contains(channels, identifier) || slices.Contains(channels, alias)
+	}
+}
+
 type CommGroupMetadata struct {
 	Name  string
 	Index int
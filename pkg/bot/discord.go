@@ -21,7 +21,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/multierror"
-	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/notifier"
 )
 
 // TODO: Refactor this file as a part of https://github.com/kubeshop/botkube/issues/667
@@ -58,6 +58,7 @@ type Discord struct {
 	status                health.PlatformStatusMsg
 	failureReason         health.FailureReasonMsg
 	errorMsg              string
+	sentMessages          *sentMessages
 }
 
 // discordMessage contains message details to execute command and send back the result.
@@ -96,6 +97,7 @@ func NewDiscord(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata, cfg
 		discordMessageWorkers: pool.New().WithMaxGoroutines(platformMessageWorkersCount),
 		status:                health.StatusUnknown,
 		failureReason:         "",
+		sentMessages:          newSentMessages(),
 	}, nil
 }
 
@@ -143,14 +145,25 @@ func (b *Discord) Start(ctx context.Context) error {
 	return nil
 }
 
-// SendMessage sends interactive message to selected Discord channels.
+// SendMessage sends interactive message to selected Discord channels. Threads are not supported, so no message references are returned.
 // Context is not supported by client: See https://github.com/bwmarrin/discordgo/issues/752.
-func (b *Discord) SendMessage(_ context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+func (b *Discord) SendMessage(_ context.Context, msg interactive.CoreMessage, sourceBindings []string) ([]notifier.MessageRef, error) {
+	return nil, b.sendToChannels(msg, b.getChannelsToNotify(boundToSources(sourceBindings)))
+}
+
+// SendMessageToChannels sends interactive message to given Discord channels, regardless of their source bindings.
+// Threads are not supported, so no message references are returned.
+// Context is not supported by client: See https://github.com/bwmarrin/discordgo/issues/752.
+func (b *Discord) SendMessageToChannels(_ context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	return nil, b.sendToChannels(msg, b.getChannelsToNotify(selectedChannels(channels)))
+}
+
+func (b *Discord) sendToChannels(msg interactive.CoreMessage, channelIDs []string) error {
 	errs := multierror.New()
-	for _, channelID := range b.getChannelsToNotify(sourceBindings) {
+	for _, channelID := range channelIDs {
 		err := b.send(channelID, msg)
 		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("while sending Discord message to channel %q: %w", channelID, err))
+			errs = multierror.Append(errs, notifier.NewChannelError(channelID, fmt.Errorf("while sending Discord message to channel %q: %w", channelID, err)))
 			continue
 		}
 	}
@@ -186,14 +199,14 @@ func (b *Discord) Type() config.IntegrationType {
 }
 
 // TODO: Support custom routing via annotations for Discord as well
-func (b *Discord) getChannelsToNotify(sourceBindings []string) []string {
+func (b *Discord) getChannelsToNotify(filter channelFilter) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
 		switch {
 		case !cfg.notify:
 			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
 		default:
-			if sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+			if filter(cfg.Identifier(), cfg.alias, cfg.Bindings.Sources) {
 				out = append(out, cfg.Identifier())
 			}
 		}
@@ -260,11 +273,13 @@ func (b *Discord) handleMessage(ctx context.Context, dm discordMessage) error {
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			CommandPolicy:    channel.CommandPolicy,
 			IsKnown:          exists,
 			CommandOrigin:    command.TypedOrigin,
 		},
 		Message: req,
 		User: execute.UserInput{
+			ID:          dm.Event.Author.ID,
 			Mention:     fmt.Sprintf("<@%s>", dm.Event.Author.ID),
 			DisplayName: dm.Event.Author.String(),
 		},
@@ -288,9 +303,30 @@ func (b *Discord) send(channelID string, resp interactive.CoreMessage) error {
 	if err != nil {
 		return fmt.Errorf("while formatting message: %w", err)
 	}
-	if _, err := b.api.ChannelMessageSendComplex(channelID, discordMsg); err != nil {
+
+	// messages uploaded as files cannot be edited, so they are always sent as new ones
+	if sent, found := b.sentMessages.Get(channelID, resp.UpdateKey); found && len(discordMsg.Files) == 0 {
+		content := discordMsg.Content
+		_, err := b.api.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:         sent.ID,
+			Channel:    sent.ChannelID,
+			Content:    &content,
+			Embeds:     discordMsg.Embeds,
+			Components: discordMsg.Components,
+		})
+		if err != nil {
+			return fmt.Errorf("while updating message: %w", discordError(err, channelID))
+		}
+
+		b.log.Debugf("Message successfully updated in channel %q", channelID)
+		return nil
+	}
+
+	msg, err := b.api.ChannelMessageSendComplex(channelID, discordMsg)
+	if err != nil {
 		return fmt.Errorf("while sending message: %w", discordError(err, channelID))
 	}
+	b.sentMessages.Set(channelID, resp.UpdateKey, sentMessage{ChannelID: msg.ChannelID, ID: msg.ID})
 
 	b.log.Debugf("Message successfully sent to channel %q", channelID)
 	return nil
@@ -416,9 +452,9 @@ func discordError(err error, channel string) error {
 	case *discordgo.RESTError:
 		switch err.Response.StatusCode {
 		case http.StatusUnauthorized:
-			return errors.New("invalid discord credentials")
+			return notifier.NewPermanentError(errors.New("invalid discord credentials"))
 		case http.StatusNotFound:
-			return fmt.Errorf("channel %q not found", channel)
+			return notifier.NewPermanentError(fmt.Errorf("channel %q not found", channel))
 		}
 	}
 	return err
@@ -95,7 +95,9 @@ func (h *HelpMessage) basicCommands() []api.Section {
 			Base: api.Base{
 				Header: "🛠️ Basic commands",
 				Description: fmt.Sprintf("`%s ping` - ping your cluster and check its status\n", api.MessageBotNamePlaceholder) +
-					fmt.Sprintf("`%s list [source|executor|action|alias]` - list available plugins and features", api.MessageBotNamePlaceholder),
+					fmt.Sprintf("`%s list [source|executor|action|alias]` - list available plugins and features\n", api.MessageBotNamePlaceholder) +
+					fmt.Sprintf("`%s events [list|show|replay]` - browse and replay recent events\n", api.MessageBotNamePlaceholder) +
+					fmt.Sprintf("`%s deprecations scan` - find resources using deprecated Kubernetes API versions", api.MessageBotNamePlaceholder),
 			},
 			Buttons: []api.Button{
 				h.btnBuilder.ForCommandWithoutDesc("Ping cluster", "ping"),
@@ -22,7 +22,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/execute"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/multierror"
-	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/notifier"
 )
 
 // TODO: Refactor this file as a part of https://github.com/kubeshop/botkube/issues/667
@@ -67,12 +67,14 @@ type Mattermost struct {
 	botMentionRegex   *regexp.Regexp
 	renderer          *MattermostRenderer
 	userNamesForID    map[string]string
+	userEmailsForID   map[string]string
 	messages          chan mattermostMessage
 	messageWorkers    *pool.Pool
 	shutdownOnce      sync.Once
 	status            health.PlatformStatusMsg
 	failureReason     health.FailureReasonMsg
 	errorMsg          string
+	sentMessages      *sentMessages
 }
 
 // mattermostMessage contains message details to execute command and send back the result
@@ -139,10 +141,12 @@ func NewMattermost(ctx context.Context, log logrus.FieldLogger, commGroupMetadat
 		botMentionRegex:   botMentionRegex,
 		renderer:          NewMattermostRenderer(),
 		userNamesForID:    map[string]string{},
+		userEmailsForID:   map[string]string{},
 		messages:          make(chan mattermostMessage, platformMessageChannelSize),
 		messageWorkers:    pool.New().WithMaxGoroutines(platformMessageWorkersCount),
 		status:            health.StatusUnknown,
 		failureReason:     "",
+		sentMessages:      newSentMessages(),
 	}, nil
 }
 
@@ -287,17 +291,20 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm mattermostMessage) er
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			CommandPolicy:    channel.CommandPolicy,
 			IsKnown:          exists,
 			CommandOrigin:    command.TypedOrigin,
 		},
 		User: execute.UserInput{
+			ID: post.UserId,
 			//Mention:     "", // not used currently
 			DisplayName: userName,
+			Email:       b.userEmailsForID[post.UserId],
 		},
 		Message: req,
 	})
 	response := e.Execute(ctx)
-	err = b.send(ctx, channelID, response)
+	_, err = b.send(ctx, channelID, response)
 	if err != nil {
 		return fmt.Errorf("while sending message: %w", err)
 	}
@@ -305,22 +312,35 @@ func (b *Mattermost) handleMessage(ctx context.Context, mm mattermostMessage) er
 	return nil
 }
 
-// Send messages to Mattermost
-func (b *Mattermost) send(ctx context.Context, channelID string, resp interactive.CoreMessage) error {
+// Send messages to Mattermost. It returns the ID of the sent post.
+func (b *Mattermost) send(ctx context.Context, channelID string, resp interactive.CoreMessage) (string, error) {
 	b.log.Debugf("Sending message to channel %q: %+v", channelID, resp)
 
 	resp.ReplaceBotNamePlaceholder(b.BotName())
 	post, err := b.formatMessage(ctx, resp, channelID)
 	if err != nil {
-		return fmt.Errorf("while formatting message: %w", err)
+		return "", fmt.Errorf("while formatting message: %w", err)
 	}
+	post.RootId = resp.ParentActivityID
 
-	if _, _, err := b.apiClient.CreatePost(ctx, post); err != nil {
+	if sent, found := b.sentMessages.Get(channelID, resp.UpdateKey); found {
+		post.Id = sent.ID
+		if _, _, err := b.apiClient.UpdatePost(ctx, sent.ID, post); err != nil {
+			b.log.Error("Failed to update message. Error: ", err)
+		}
+		b.log.Debugf("Message successfully updated in channel %q", channelID)
+		return sent.ID, nil
+	}
+
+	created, _, err := b.apiClient.CreatePost(ctx, post)
+	if err != nil {
 		b.log.Error("Failed to send message. Error: ", err)
+		return "", nil
 	}
+	b.sentMessages.Set(channelID, resp.UpdateKey, sentMessage{ChannelID: created.ChannelId, ID: created.Id})
 
 	b.log.Debugf("Message successfully sent to channel %q", channelID)
-	return nil
+	return created.Id, nil
 }
 
 func (b *Mattermost) formatMessage(ctx context.Context, msg interactive.CoreMessage, channelID string) (*model.Post, error) {
@@ -421,14 +441,14 @@ func (b *Mattermost) listen(ctx context.Context) {
 	}
 }
 
-func (b *Mattermost) getChannelsToNotify(eventSources []string) []string {
+func (b *Mattermost) getChannelsToNotify(filter channelFilter) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
 		switch {
 		case !cfg.notify:
 			b.log.Infof("Skipping notification for channel %q as notifications are disabled.", cfg.Identifier())
 		default:
-			if sliceutil.Intersect(eventSources, cfg.Bindings.Sources) {
+			if filter(cfg.Identifier(), cfg.alias, cfg.Bindings.Sources) {
 				out = append(out, cfg.Identifier())
 			}
 		}
@@ -437,17 +457,30 @@ func (b *Mattermost) getChannelsToNotify(eventSources []string) []string {
 }
 
 // SendMessage sends message to selected Mattermost channels.
-func (b *Mattermost) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+func (b *Mattermost) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) ([]notifier.MessageRef, error) {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(boundToSources(sourceBindings)))
+}
+
+// SendMessageToChannels sends message to given Mattermost channels, regardless of their source bindings.
+func (b *Mattermost) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(selectedChannels(channels)))
+}
+
+func (b *Mattermost) sendToChannels(ctx context.Context, msg interactive.CoreMessage, channelIDs []string) ([]notifier.MessageRef, error) {
+	var refs []notifier.MessageRef
 	errs := multierror.New()
-	for _, channelID := range b.getChannelsToNotify(sourceBindings) {
-		err := b.send(ctx, channelID, msg)
+	for _, channelID := range channelIDs {
+		postID, err := b.send(ctx, channelID, msg)
 		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("while sending Mattermost message to channel %q: %w", channelID, err))
+			errs = multierror.Append(errs, notifier.NewChannelError(channelID, fmt.Errorf("while sending Mattermost message to channel %q: %w", channelID, err)))
 			continue
 		}
+		if postID != "" {
+			refs = append(refs, notifier.MessageRef{Channel: channelID, ID: postID})
+		}
 	}
 
-	return errs.ErrorOrNil()
+	return refs, errs.ErrorOrNil()
 }
 
 // SendMessageToAll sends message to all Mattermost channels.
@@ -455,7 +488,7 @@ func (b *Mattermost) SendMessageToAll(ctx context.Context, msg interactive.CoreM
 	errs := multierror.New()
 	for _, channel := range b.getChannels() {
 		channelID := channel.ID
-		err := b.send(ctx, channelID, msg)
+		_, err := b.send(ctx, channelID, msg)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while sending Mattermost message to channel %q: %w", channelID, err))
 			continue
@@ -500,6 +533,8 @@ func (b *Mattermost) getUserName(ctx context.Context, userID string) (string, er
 		return "", fmt.Errorf("while getting user with ID %q: %w", userID, err)
 	}
 	b.userNamesForID[userID] = user.Username
+	// email is empty if the Mattermost privacy settings hide it
+	b.userEmailsForID[userID] = user.Email
 
 	return user.Username, nil
 }
@@ -565,8 +600,9 @@ func mattermostChannelsCfgFrom(ctx context.Context, client *model.Client4, teamI
 
 		res[fetchedChannel.Id] = channelConfigByID{
 			ChannelBindingsByID: config.ChannelBindingsByID{
-				ID:       fetchedChannel.Id,
-				Bindings: channCfg.Bindings,
+				ID:            fetchedChannel.Id,
+				Bindings:      channCfg.Bindings,
+				CommandPolicy: channCfg.CommandPolicy,
 			},
 			alias:  channAlias,
 			notify: !channCfg.Notification.Disabled,
@@ -0,0 +1,65 @@
+package bot
+
+import "sync"
+
+const maxSentMessagesWithUpdateKey = 1000
+
+// sentMessage identifies a message sent to a communication platform.
+type sentMessage struct {
+	// ChannelID is the ID of the channel where the message was sent. Some platforms accept channel names only when posting new messages.
+	ChannelID string
+	ID        string
+}
+
+// sentMessages remembers IDs of messages sent with api.Message.UpdateKey, so they can be updated instead of posted again.
+// The oldest entries are forgotten first.
+type sentMessages struct {
+	mu    sync.Mutex
+	max   int
+	ids   map[string]sentMessage
+	order []string
+}
+
+func newSentMessages() *sentMessages {
+	return &sentMessages{
+		max: maxSentMessagesWithUpdateKey,
+		ids: map[string]sentMessage{},
+	}
+}
+
+// Get returns the message sent with a given update key to a given channel.
+func (s *sentMessages) Get(channel, updateKey string) (sentMessage, bool) {
+	if s == nil || updateKey == "" {
+		return sentMessage{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, found := s.ids[s.key(channel, updateKey)]
+	return msg, found
+}
+
+// Set stores the message sent with a given update key to a given channel.
+func (s *sentMessages) Set(channel, updateKey string, msg sentMessage) {
+	if s == nil || updateKey == "" || msg.ID == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.key(channel, updateKey)
+	if _, found := s.ids[key]; !found {
+		s.order = append(s.order, key)
+	}
+	s.ids[key] = msg
+
+	for len(s.order) > s.max {
+		delete(s.ids, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+func (s *sentMessages) key(channel, updateKey string) string {
+	return channel + "/" + updateKey
+}
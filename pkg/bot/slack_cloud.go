@@ -35,7 +35,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/grpcx"
 	"github.com/kubeshop/botkube/pkg/multierror"
-	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/notifier"
 )
 
 const (
@@ -56,6 +56,7 @@ type CloudSlack struct {
 	reporter          AnalyticsCommandReporter
 	commGroupMetadata CommGroupMetadata
 	realNamesForID    map[string]string
+	emailsForID       map[string]string
 	botMentionRegex   *regexp.Regexp
 	botID             string
 	channelsMutex     sync.RWMutex
@@ -69,6 +70,7 @@ type CloudSlack struct {
 	failureReason     health.FailureReasonMsg
 	errorMsg          string
 	reportOnce        sync.Once
+	sentMessages      *sentMessages
 }
 
 func NewCloudSlack(log logrus.FieldLogger,
@@ -107,7 +109,9 @@ func NewCloudSlack(log logrus.FieldLogger,
 		botID:             cfg.BotID,
 		clusterName:       clusterName,
 		realNamesForID:    map[string]string{},
+		emailsForID:       map[string]string{},
 		msgStatusTracker:  NewSlackMessageStatusTracker(log, client),
+		sentMessages:      newSentMessages(),
 		status:            health.StatusUnknown,
 		failuresNo:        0,
 		failureReason:     "",
@@ -296,6 +300,7 @@ func (b *CloudSlack) handleStreamMessage(ctx context.Context, data *pb.ConnectRe
 				UserID:          ev.User,
 				EventTimeStamp:  ev.EventTimeStamp,
 				UserName:        userName,
+				UserEmail:       b.emailsForID[ev.User],
 				CommandOrigin:   command.TypedOrigin,
 			}
 
@@ -313,7 +318,7 @@ func (b *CloudSlack) handleStreamMessage(ctx context.Context, data *pb.ConnectRe
 			b.setFailureReason(health.FailureReasonQuotaExceeded, quotaExceededMsg)
 			response := quotaExceeded()
 
-			if err := b.send(ctx, msg, response); err != nil {
+			if _, err := b.send(ctx, msg, response); err != nil {
 				return fmt.Errorf("while sending message: %w", err), true
 			}
 		}
@@ -375,6 +380,7 @@ func (b *CloudSlack) handleStreamMessage(ctx context.Context, data *pb.ConnectRe
 				TriggerID:       callback.TriggerID,
 				UserID:          callback.User.ID,
 				UserName:        userName,
+				UserEmail:       b.emailsForID[callback.User.ID],
 				CommandOrigin:   cmdOrigin,
 				State:           state,
 				EventTimeStamp:  callback.Message.Timestamp,
@@ -398,6 +404,7 @@ func (b *CloudSlack) handleStreamMessage(ctx context.Context, data *pb.ConnectRe
 						Channel:        callback.View.PrivateMetadata,
 						UserID:         callback.User.ID,
 						UserName:       userName,
+						UserEmail:      b.emailsForID[callback.User.ID],
 						EventTimeStamp: "", // there is no timestamp for interactive callbacks
 						CommandOrigin:  cmdOrigin,
 					}
@@ -415,21 +422,34 @@ func (b *CloudSlack) handleStreamMessage(ctx context.Context, data *pb.ConnectRe
 	return nil, false
 }
 
-func (b *CloudSlack) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+func (b *CloudSlack) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) ([]notifier.MessageRef, error) {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(boundToSources(sourceBindings)))
+}
+
+// SendMessageToChannels sends message to given Slack channels, regardless of their source bindings.
+func (b *CloudSlack) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(selectedChannels(channels)))
+}
+
+func (b *CloudSlack) sendToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	var refs []notifier.MessageRef
 	errs := multierror.New()
-	for _, channelName := range b.getChannelsToNotify(sourceBindings) {
+	for _, channelName := range channels {
 		msgMetadata := slackMessage{
 			Channel: channelName,
 			BlockID: uuid.New().String(),
 		}
-		err := b.send(ctx, msgMetadata, msg)
+		ts, err := b.send(ctx, msgMetadata, msg)
 		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("while sending Slack message to channel %q: %w", channelName, err))
+			errs = multierror.Append(errs, notifier.NewChannelError(channelName, fmt.Errorf("while sending Slack message to channel %q: %w", channelName, err)))
 			continue
 		}
+		if ts != "" {
+			refs = append(refs, notifier.MessageRef{Channel: channelName, ID: ts})
+		}
 	}
 
-	return errs.ErrorOrNil()
+	return refs, errs.ErrorOrNil()
 }
 
 func (b *CloudSlack) SendMessageToAll(ctx context.Context, msg interactive.CoreMessage) error {
@@ -440,7 +460,7 @@ func (b *CloudSlack) SendMessageToAll(ctx context.Context, msg interactive.CoreM
 			Channel: channelName,
 			BlockID: uuid.New().String(),
 		}
-		err := b.send(ctx, msgMetadata, msg)
+		_, err := b.send(ctx, msgMetadata, msg)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while sending Slack message to channel %q (alias: %q): %w", channelName, channel.alias, err))
 			continue
@@ -470,7 +490,13 @@ func (b *CloudSlack) getRealNameWithFallbackToUserID(ctx context.Context, userID
 		return userID
 	}
 
-	if user == nil || user.RealName == "" {
+	if user == nil {
+		return userID
+	}
+	// email is available only if the app has the 'users:read.email' scope
+	b.emailsForID[userID] = user.Profile.Email
+
+	if user.RealName == "" {
 		return userID
 	}
 
@@ -512,6 +538,7 @@ func (b *CloudSlack) handleMessage(ctx context.Context, event slackMessage) erro
 			DisplayName:      info.Name,
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			CommandPolicy:    channel.CommandPolicy,
 			IsKnown:          exists,
 			CommandOrigin:    event.CommandOrigin,
 			SlackState:       event.State,
@@ -519,8 +546,10 @@ func (b *CloudSlack) handleMessage(ctx context.Context, event slackMessage) erro
 		},
 		Message: request,
 		User: execute.UserInput{
+			ID:          event.UserID,
 			Mention:     fmt.Sprintf("<@%s>", event.UserID),
 			DisplayName: event.UserName,
+			Email:       event.UserEmail,
 		},
 	})
 
@@ -528,7 +557,7 @@ func (b *CloudSlack) handleMessage(ctx context.Context, event slackMessage) erro
 	b.msgStatusTracker.MarkAsReceived(msgRef)
 
 	response := e.Execute(ctx)
-	err = b.send(ctx, event, response)
+	_, err = b.send(ctx, event, response)
 	if err != nil {
 		return fmt.Errorf("while sending message: %w", err)
 	}
@@ -538,18 +567,19 @@ func (b *CloudSlack) handleMessage(ctx context.Context, event slackMessage) erro
 	return nil
 }
 
-func (b *CloudSlack) send(ctx context.Context, event slackMessage, resp interactive.CoreMessage) error {
+// send sends a given message to a given channel. It returns the timestamp of the posted message.
+func (b *CloudSlack) send(ctx context.Context, event slackMessage, resp interactive.CoreMessage) (string, error) {
 	b.log.Debugf("Sending message to channel %q: %+v", event.Channel, resp)
 
 	if resp.IsEmpty() { // don't send empty messages
-		return nil
+		return "", nil
 	}
 
 	resp.ReplaceBotNamePlaceholder(b.BotName(), api.BotNameWithClusterName(b.clusterName))
 	markdown := b.renderer.MessageToMarkdown(resp)
 
 	if len(markdown) == 0 {
-		return errors.New("got empty message while converting executor response to Markdown")
+		return "", errors.New("got empty message while converting executor response to Markdown")
 	}
 
 	// Upload message as a file if too long
@@ -558,11 +588,11 @@ func (b *CloudSlack) send(ctx context.Context, event slackMessage, resp interact
 	if len(markdown) >= slackMaxMessageSize {
 		file, err = b.uploadFileToSlack(ctx, event, resp)
 		if err != nil {
-			return err
+			return "", err
 		}
 		// the main message body was sent as a file, the only think that left is the filter input (if any)
 		if len(resp.PlaintextInputs) == 0 {
-			return nil
+			return "", nil
 		}
 
 		resp = interactive.CoreMessage{
@@ -593,22 +623,33 @@ func (b *CloudSlack) send(ctx context.Context, event slackMessage, resp interact
 		options = append(options, slack.MsgOptionReplaceOriginal(event.ResponseURL))
 	}
 
+	var postedTS string
 	if resp.OnlyVisibleForYou {
 		if _, err := b.client.PostEphemeralContext(ctx, event.Channel, event.UserID, options...); err != nil {
-			return fmt.Errorf("while posting Slack message visible only to user: %w", err)
+			return "", fmt.Errorf("while posting Slack message visible only to user: %w", err)
 		}
 	} else {
 		if ts := b.getThreadOptionIfNeeded(resp, event, file); ts != nil {
 			options = append(options, ts)
 		}
 
-		if _, _, err := b.client.PostMessageContext(ctx, event.Channel, options...); err != nil {
-			return fmt.Errorf("while posting Slack message: %w", err)
+		if sent, found := b.sentMessages.Get(event.Channel, resp.UpdateKey); found {
+			if _, _, _, err := b.client.UpdateMessageContext(ctx, sent.ChannelID, sent.ID, options...); err != nil {
+				return "", fmt.Errorf("while updating Slack message: %w", slackError(err, event.Channel))
+			}
+			postedTS = sent.ID
+		} else {
+			channelID, ts, err := b.client.PostMessageContext(ctx, event.Channel, options...)
+			if err != nil {
+				return "", fmt.Errorf("while posting Slack message: %w", slackError(err, event.Channel))
+			}
+			b.sentMessages.Set(event.Channel, resp.UpdateKey, sentMessage{ChannelID: channelID, ID: ts})
+			postedTS = ts
 		}
 	}
 
 	b.log.Debugf("Message successfully sent to channel %q", event.Channel)
-	return nil
+	return postedTS, nil
 }
 
 func (b *CloudSlack) uploadFileToSlack(ctx context.Context, event slackMessage, resp interactive.CoreMessage) (*slack.File, error) {
@@ -709,7 +750,7 @@ func (b *CloudSlack) setChannels(channels map[string]channelConfigByName) {
 	b.channels = channels
 }
 
-func (b *CloudSlack) getChannelsToNotify(sourceBindings []string) []string {
+func (b *CloudSlack) getChannelsToNotify(filter channelFilter) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
 		if !cfg.notify {
@@ -717,7 +758,7 @@ func (b *CloudSlack) getChannelsToNotify(sourceBindings []string) []string {
 			continue
 		}
 
-		if !sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+		if !filter(cfg.Identifier(), cfg.alias, cfg.Bindings.Sources) {
 			continue
 		}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/config"
 	conversationx "github.com/kubeshop/botkube/pkg/conversation"
 	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/notifier"
 )
 
 const slackBotMentionPrefixFmt = "^<@%s>"
@@ -45,11 +46,11 @@ func slackBotMentionRegex(botID string) (*regexp.Regexp, error) {
 func slackError(err error, channel string) error {
 	switch err.Error() {
 	case "channel_not_found":
-		err = fmt.Errorf("channel %q not found", channel)
+		return notifier.NewPermanentError(fmt.Errorf("channel %q not found", channel))
 	case "not_in_channel":
-		err = fmt.Errorf("botkube is not in channel %q", channel)
+		return notifier.NewPermanentError(fmt.Errorf("botkube is not in channel %q", channel))
 	case "invalid_auth":
-		err = fmt.Errorf("invalid slack credentials")
+		return notifier.NewPermanentError(fmt.Errorf("invalid slack credentials"))
 	}
 	return err
 }
@@ -61,6 +62,7 @@ type slackMessage struct {
 	ThreadTimeStamp      string
 	UserID               string
 	UserName             string
+	UserEmail            string
 	TriggerID            string
 	CommandOrigin        command.Origin
 	State                *slack.BlockActionStates
@@ -26,7 +26,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/multierror"
-	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/notifier"
 )
 
 // TODO: Refactor this file as a part of https://github.com/kubeshop/botkube/issues/667
@@ -60,6 +60,7 @@ type SocketSlack struct {
 	commGroupMetadata CommGroupMetadata
 	renderer          *SlackRenderer
 	realNamesForID    map[string]string
+	emailsForID       map[string]string
 	msgStatusTracker  *SlackMessageStatusTracker
 	messages          chan slackMessage
 	messageWorkers    *pool.Pool
@@ -67,6 +68,7 @@ type SocketSlack struct {
 	status            health.PlatformStatusMsg
 	failureReason     health.FailureReasonMsg
 	errorMsg          string
+	sentMessages      *sentMessages
 }
 
 // socketSlackAnalyticsReporter defines a reporter that collects analytics data.
@@ -106,11 +108,13 @@ func NewSocketSlack(log logrus.FieldLogger, commGroupMetadata CommGroupMetadata,
 		renderer:          NewSlackRenderer(),
 		botMentionRegex:   botMentionRegex,
 		realNamesForID:    map[string]string{},
+		emailsForID:       map[string]string{},
 		msgStatusTracker:  NewSlackMessageStatusTracker(log, client),
 		messages:          make(chan slackMessage, platformMessageChannelSize),
 		messageWorkers:    pool.New().WithMaxGoroutines(platformMessageWorkersCount),
 		status:            health.StatusUnknown,
 		failureReason:     "",
+		sentMessages:      newSentMessages(),
 	}, nil
 }
 
@@ -188,6 +192,7 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 							continue
 						}
 
+						userName := b.getRealNameWithFallbackToUserID(ctx, ev.User)
 						msg := slackMessage{
 							Text:                 ev.Text,
 							Channel:              ev.Channel,
@@ -195,7 +200,8 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 							ThreadTimeStamp:      ev.ThreadTimeStamp,
 							EventTimeStamp:       ev.EventTimeStamp,
 							UserID:               ev.User,
-							UserName:             b.getRealNameWithFallbackToUserID(ctx, ev.User),
+							UserName:             userName,
+							UserEmail:            b.emailsForID[ev.User],
 						}
 
 						b.messages <- msg
@@ -205,6 +211,7 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 							continue
 						}
 						b.log.Debugf("Got app mention %s", formatx.StructDumper().Sdump(innerEvent))
+						userName := b.getRealNameWithFallbackToUserID(ctx, ev.User)
 						msg := slackMessage{
 							Text:                 ev.Text,
 							Channel:              ev.Channel,
@@ -212,7 +219,8 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 							ThreadTimeStamp:      ev.ThreadTimeStamp,
 							EventTimeStamp:       ev.EventTimeStamp,
 							UserID:               ev.User,
-							UserName:             b.getRealNameWithFallbackToUserID(ctx, ev.User),
+							UserName:             userName,
+							UserEmail:            b.emailsForID[ev.User],
 							CommandOrigin:        command.TypedOrigin,
 						}
 
@@ -275,6 +283,7 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 						TriggerID:       callback.TriggerID,
 						UserID:          callback.User.ID,
 						UserName:        userName,
+						UserEmail:       b.emailsForID[callback.User.ID],
 						CommandOrigin:   cmdOrigin,
 						State:           state,
 						EventTimeStamp:  callback.Message.Timestamp,
@@ -296,6 +305,7 @@ func (b *SocketSlack) Start(ctx context.Context) error {
 								Channel:        callback.View.PrivateMetadata,
 								UserID:         callback.User.ID,
 								UserName:       userName,
+								UserEmail:      b.emailsForID[callback.User.ID],
 								EventTimeStamp: "", // there is no timestamp for interactive modals
 								CommandOrigin:  cmdOrigin,
 							}
@@ -451,6 +461,7 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event slackMessage) err
 			DisplayName:      info.Name,
 			ExecutorBindings: bindings.Executors,
 			SourceBindings:   bindings.Sources,
+			CommandPolicy:    channel.CommandPolicy,
 			IsKnown:          exists,
 			CommandOrigin:    event.CommandOrigin,
 			SlackState:       event.State,
@@ -460,8 +471,10 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event slackMessage) err
 		},
 		Message: request,
 		User: execute.UserInput{
+			ID:          event.UserID,
 			Mention:     fmt.Sprintf("<@%s>", event.UserID),
 			DisplayName: event.UserName,
+			Email:       event.UserEmail,
 		},
 	})
 
@@ -471,7 +484,7 @@ func (b *SocketSlack) handleMessage(ctx context.Context, event slackMessage) err
 	}
 
 	response := e.Execute(ctx)
-	err = b.send(ctx, event, response)
+	_, err = b.send(ctx, event, response)
 	if err != nil {
 		return fmt.Errorf("while sending message: %w", err)
 	}
@@ -503,7 +516,8 @@ func (b *SocketSlack) hasMatchingTextMessageTrigger(channel channelConfigByName,
 	return config.TextMessageTriggers{}, false
 }
 
-func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interactive.CoreMessage) error {
+// send sends a given message to a given channel. It returns the timestamp of the first message posted to the channel.
+func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interactive.CoreMessage) (string, error) {
 	b.log.Debugf("Sending message to channel %q: %+v", event.Channel, in)
 
 	var msgs []api.Message
@@ -513,6 +527,8 @@ func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interacti
 
 	msgs = append(msgs, in.Messages...)
 
+	var postedTS string
+
 	for idx := range msgs {
 		if msgs[idx].IsEmpty() {
 			continue
@@ -529,7 +545,7 @@ func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interacti
 		markdown := b.renderer.MessageToMarkdown(resp)
 
 		if len(markdown) == 0 {
-			return errors.New("while reading Slack response: empty response")
+			return "", errors.New("while reading Slack response: empty response")
 		}
 
 		// Upload message as a file if too long
@@ -538,7 +554,7 @@ func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interacti
 		if len(markdown) >= slackMaxMessageSize {
 			file, err = b.uploadFileToSlack(ctx, event.Channel, resp, event.ThreadTimeStamp)
 			if err != nil {
-				return err
+				return "", err
 			}
 			resp = interactive.CoreMessage{
 				Message: api.Message{
@@ -553,9 +569,9 @@ func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interacti
 			modalView.PrivateMetadata = event.Channel
 			_, err := b.client.OpenViewContext(ctx, event.TriggerID, modalView)
 			if err != nil {
-				return fmt.Errorf("while opening modal: %w", err)
+				return "", fmt.Errorf("while opening modal: %w", err)
 			}
-			return nil
+			return "", nil
 		}
 
 		options := []slack.MsgOption{
@@ -576,7 +592,7 @@ func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interacti
 
 		if resp.Message.OnlyVisibleForYou {
 			if _, err := b.client.PostEphemeralContext(ctx, event.Channel, event.UserID, options...); err != nil {
-				return fmt.Errorf("while posting Slack message visible only to user: %w", err)
+				return "", fmt.Errorf("while posting Slack message visible only to user: %w", err)
 			}
 		} else {
 			id := event.Channel
@@ -588,19 +604,32 @@ func (b *SocketSlack) send(ctx context.Context, event slackMessage, in interacti
 				options = append(options, slack.MsgOptionTS(resp.Message.ParentActivityID))
 			}
 
-			_, _, err = b.client.PostMessageContext(ctx, id, options...)
-			if err != nil {
-				return fmt.Errorf("while posting Slack message: %w", slackError(err, event.Channel))
+			var ts string
+			if sent, found := b.sentMessages.Get(id, resp.Message.UpdateKey); found {
+				if _, _, _, err := b.client.UpdateMessageContext(ctx, sent.ChannelID, sent.ID, options...); err != nil {
+					return "", fmt.Errorf("while updating Slack message: %w", slackError(err, event.Channel))
+				}
+				ts = sent.ID
+			} else {
+				channelID, postTS, err := b.client.PostMessageContext(ctx, id, options...)
+				if err != nil {
+					return "", fmt.Errorf("while posting Slack message: %w", slackError(err, event.Channel))
+				}
+				b.sentMessages.Set(id, resp.Message.UpdateKey, sentMessage{ChannelID: channelID, ID: postTS})
+				ts = postTS
+			}
+			if postedTS == "" {
+				postedTS = ts
 			}
 		}
 
 		b.log.Debugf("Message successfully sent to channel %q", event.Channel)
 	}
 
-	return nil
+	return postedTS, nil
 }
 
-func (b *SocketSlack) getChannelsToNotify(sourceBindings []string) []string {
+func (b *SocketSlack) getChannelsToNotify(filter channelFilter) []string {
 	var out []string
 	for _, cfg := range b.getChannels() {
 		if !cfg.notify {
@@ -608,7 +637,7 @@ func (b *SocketSlack) getChannelsToNotify(sourceBindings []string) []string {
 			continue
 		}
 
-		if !sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+		if !filter(cfg.Identifier(), cfg.alias, cfg.Bindings.Sources) {
 			continue
 		}
 
@@ -618,22 +647,35 @@ func (b *SocketSlack) getChannelsToNotify(sourceBindings []string) []string {
 }
 
 // SendMessage sends message with interactive sections to selected Slack channels.
-func (b *SocketSlack) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
+func (b *SocketSlack) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) ([]notifier.MessageRef, error) {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(boundToSources(sourceBindings)))
+}
+
+// SendMessageToChannels sends message with interactive sections to given Slack channels, regardless of their source bindings.
+func (b *SocketSlack) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	return b.sendToChannels(ctx, msg, b.getChannelsToNotify(selectedChannels(channels)))
+}
+
+func (b *SocketSlack) sendToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	var refs []notifier.MessageRef
 	errs := multierror.New()
-	for _, channelName := range b.getChannelsToNotify(sourceBindings) {
+	for _, channelName := range channels {
 		msgMetadata := slackMessage{
 			Channel:         channelName,
 			ThreadTimeStamp: "",
 			BlockID:         uuid.New().String(),
 		}
-		err := b.send(ctx, msgMetadata, msg)
+		ts, err := b.send(ctx, msgMetadata, msg)
 		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("while sending Slack message to channel %q: %w", channelName, err))
+			errs = multierror.Append(errs, notifier.NewChannelError(channelName, fmt.Errorf("while sending Slack message to channel %q: %w", channelName, err)))
 			continue
 		}
+		if ts != "" {
+			refs = append(refs, notifier.MessageRef{Channel: channelName, ID: ts})
+		}
 	}
 
-	return errs.ErrorOrNil()
+	return refs, errs.ErrorOrNil()
 }
 
 // SendMessageToAll sends message with interactive sections to all Slack channels.
@@ -645,7 +687,7 @@ func (b *SocketSlack) SendMessageToAll(ctx context.Context, msg interactive.Core
 			Channel: channelName,
 			BlockID: uuid.New().String(),
 		}
-		err := b.send(ctx, msgMetadata, msg)
+		_, err := b.send(ctx, msgMetadata, msg)
 		if err != nil {
 			errs = multierror.Append(errs, fmt.Errorf("while sending Slack message to channel %q (alias: %q): %w", channelName, channel.alias, err))
 			continue
@@ -775,7 +817,13 @@ func (b *SocketSlack) getRealNameWithFallbackToUserID(ctx context.Context, userI
 		return userID
 	}
 
-	if user == nil || user.RealName == "" {
+	if user == nil {
+		return userID
+	}
+	// email is available only if the app has the 'users:read.email' scope
+	b.emailsForID[userID] = user.Profile.Email
+
+	if user.RealName == "" {
 		return userID
 	}
 
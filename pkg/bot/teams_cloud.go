@@ -26,7 +26,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/multierror"
-	"github.com/kubeshop/botkube/pkg/sliceutil"
+	"github.com/kubeshop/botkube/pkg/notifier"
 )
 
 const (
@@ -130,8 +130,19 @@ func (b *CloudTeams) SendMessageToAll(ctx context.Context, msg interactive.CoreM
 }
 
 // SendMessage sends the message to MS CloudTeams to selected conversations.
-func (b *CloudTeams) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) error {
-	return b.sendAgentActivity(ctx, msg, b.getChannelsToNotify(sourceBindings))
+// Messages are sent asynchronously via Botkube Cloud, so no message references are returned.
+func (b *CloudTeams) SendMessage(ctx context.Context, msg interactive.CoreMessage, sourceBindings []string) ([]notifier.MessageRef, error) {
+	filter := boundToSources(sourceBindings)
+	if sourceBindings == nil {
+		filter = func(string, string, []string) bool { return true }
+	}
+	return nil, b.sendAgentActivity(ctx, msg, b.getChannelsToNotify(filter))
+}
+
+// SendMessageToChannels sends the message to MS CloudTeams to given conversations, regardless of their source bindings.
+// Messages are sent asynchronously via Botkube Cloud, so no message references are returned.
+func (b *CloudTeams) SendMessageToChannels(ctx context.Context, msg interactive.CoreMessage, channels []string) ([]notifier.MessageRef, error) {
+	return nil, b.sendAgentActivity(ctx, msg, b.getChannelsToNotify(selectedChannels(channels)))
 }
 
 // IntegrationName describes the integration name.
@@ -312,12 +323,14 @@ func (b *CloudTeams) processMessage(ctx context.Context, act schema.Activity, ch
 			ID:               channel.Identifier(),
 			ExecutorBindings: channel.Bindings.Executors,
 			SourceBindings:   channel.Bindings.Sources,
+			CommandPolicy:    channel.CommandPolicy,
 			CommandOrigin:    b.mapToCommandOrigin(act),
 			DisplayName:      channelDisplayName,
 			ParentActivityID: act.Conversation.ID,
 		},
 		Message: trimmedMsg,
 		User: execute.UserInput{
+			ID: act.From.AadObjectID,
 			// Note: this is a plain text mention, a native mentions will be provided as a part of:
 			// https://github.com/kubeshop/botkube/issues/1331
 			Mention:     act.From.Name,
@@ -387,7 +400,7 @@ func (b *CloudTeams) sendAgentActivity(ctx context.Context, msg interactive.Core
 		msg.ReplaceBotNamePlaceholder(b.BotName(), api.BotNameWithClusterName(b.clusterName))
 		raw, err := json.Marshal(msg)
 		if err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("while proxing message via agent for channel id %q: %w", channel.ID, err))
+			errs = multierror.Append(errs, notifier.NewChannelError(channel.Identifier(), fmt.Errorf("while proxing message via agent for channel id %q: %w", channel.ID, err)))
 			continue
 		}
 
@@ -436,7 +449,7 @@ func (b *CloudTeams) getChannelForActivity(act schema.Activity) (teamsCloudChann
 	return channel, exists, nil
 }
 
-func (b *CloudTeams) getChannelsToNotify(sourceBindings []string) []teamsCloudChannelConfigByID {
+func (b *CloudTeams) getChannelsToNotify(filter channelFilter) []teamsCloudChannelConfigByID {
 	var out []teamsCloudChannelConfigByID
 	for _, cfg := range b.getChannels() {
 		if !cfg.notify {
@@ -444,7 +457,7 @@ func (b *CloudTeams) getChannelsToNotify(sourceBindings []string) []teamsCloudCh
 			continue
 		}
 
-		if sourceBindings != nil && !sliceutil.Intersect(sourceBindings, cfg.Bindings.Sources) {
+		if !filter(cfg.Identifier(), cfg.alias, cfg.Bindings.Sources) {
 			continue
 		}
 
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/mitchellh/mapstructure"
+)
+
+// maxAmbiguousCommandFlags limits the number of flags which may take the next argument as a value.
+// Each of them doubles the number of command variants checked by MatchesInAnyFlagOrder.
+const maxAmbiguousCommandFlags = 10
+
+type compiledCommandPattern struct {
+	regex *regexp.Regexp
+	glob  glob.Glob
+}
+
+func (c *compiledCommandPattern) match(cmd string) bool {
+	if c.regex != nil {
+		return c.regex.MatchString(cmd)
+	}
+	return c.glob.Match(cmd)
+}
+
+// String returns the pattern in a human-readable form.
+func (p CommandPattern) String() string {
+	if p.Regex != "" {
+		return fmt.Sprintf("regex %q", p.Regex)
+	}
+	return fmt.Sprintf("glob %q", p.Glob)
+}
+
+// Matches returns true if a given command matches the pattern as typed.
+func (p CommandPattern) Matches(cmd string) (bool, error) {
+	compiled, err := p.getCompiled()
+	if err != nil {
+		return false, fmt.Errorf("while matching %q with %s: %w", cmd, p, err)
+	}
+	return compiled.match(cmd), nil
+}
+
+// MatchesInAnyFlagOrder returns true if a given command matches the pattern as typed, or once its flags are moved
+// after positional arguments. For example, `kubectl -n prod delete pod api` is matched as `kubectl delete pod api -n prod`.
+// Use it for rules which restrict commands, so they can't be bypassed by placing flags before the command verb.
+func (p CommandPattern) MatchesInAnyFlagOrder(cmd string) (bool, error) {
+	compiled, err := p.getCompiled()
+	if err != nil {
+		return false, fmt.Errorf("while matching %q with %s: %w", cmd, p, err)
+	}
+	if compiled.match(cmd) {
+		return true, nil
+	}
+
+	variants, err := withFlagsMovedToEnd(cmd)
+	if err != nil {
+		return false, fmt.Errorf("while matching %q with %s: %w", cmd, p, err)
+	}
+	for _, variant := range variants {
+		if compiled.match(variant) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (p CommandPattern) getCompiled() (*compiledCommandPattern, error) {
+	if p.compiled != nil {
+		return p.compiled, nil
+	}
+	return p.compile()
+}
+
+func (p CommandPattern) compile() (*compiledCommandPattern, error) {
+	if p.Regex != "" {
+		regex, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", p.Regex))
+		if err != nil {
+			return nil, err
+		}
+		return &compiledCommandPattern{regex: regex}, nil
+	}
+
+	g, err := glob.Compile(p.Glob)
+	if err != nil {
+		return nil, err
+	}
+	return &compiledCommandPattern{glob: g}, nil
+}
+
+// withFlagsMovedToEnd returns variants of a given command with flags moved after positional arguments.
+// It's unknown whether a flag without the `=` sign takes the next argument as its value, so all combinations are returned.
+// Arguments after `--` are never treated as flags and they stay at the end.
+func withFlagsMovedToEnd(cmd string) ([]string, error) {
+	args := strings.Fields(cmd)
+
+	end := len(args)
+	var ambiguous []int
+	for i := 1; i < len(args); i++ {
+		if args[i] == "--" {
+			end = i
+			break
+		}
+		if isCommandFlag(args[i]) && !strings.Contains(args[i], "=") && i+1 < len(args) && !isCommandFlag(args[i+1]) && args[i+1] != "--" {
+			ambiguous = append(ambiguous, i)
+		}
+	}
+	if len(ambiguous) > maxAmbiguousCommandFlags {
+		return nil, fmt.Errorf("command has more than %d flags which may take a value", maxAmbiguousCommandFlags)
+	}
+
+	var out []string
+	seen := map[string]struct{}{}
+	for mask := 0; mask < 1<<len(ambiguous); mask++ {
+		takesValue := map[int]bool{}
+		for bit, idx := range ambiguous {
+			takesValue[idx] = mask&(1<<bit) != 0
+		}
+
+		var positional, flags []string
+		for i := 0; i < end; i++ {
+			switch {
+			case i == 0 || !isCommandFlag(args[i]):
+				positional = append(positional, args[i])
+			case takesValue[i]:
+				flags = append(flags, args[i], args[i+1])
+				i++
+			default:
+				flags = append(flags, args[i])
+			}
+		}
+		variant := strings.Join(append(append(positional, flags...), args[end:]...), " ")
+		if _, found := seen[variant]; found {
+			continue
+		}
+		seen[variant] = struct{}{}
+		out = append(out, variant)
+	}
+	return out, nil
+}
+
+func isCommandFlag(arg string) bool {
+	return strings.HasPrefix(arg, "-") && arg != "-" && arg != "--"
+}
+
+// commandPatternHookFunc compiles command patterns when the config is loaded, so they aren't compiled for every command.
+// Invalid patterns are left uncompiled, as they are reported by the validator.
+func commandPatternHookFunc() mapstructure.DecodeHookFuncType {
+	patternType := reflect.TypeOf(CommandPattern{})
+	return func(_ reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		if to != patternType {
+			return data, nil
+		}
+
+		var pattern CommandPattern
+		if err := mapstructure.Decode(data, &pattern); err != nil {
+			return nil, err
+		}
+		pattern.compiled, _ = pattern.compile()
+		return pattern, nil
+	}
+}
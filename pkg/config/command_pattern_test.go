@@ -0,0 +1,107 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestCommandPatternMatchesInAnyFlagOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		pattern      config.CommandPattern
+		cmd          string
+		expAsTyped   bool
+		expAnyOrder  bool
+		expErrSubstr string
+	}{
+		{
+			name:        "Command as typed",
+			pattern:     config.CommandPattern{Glob: "kubectl delete *"},
+			cmd:         "kubectl delete pod api -n prod",
+			expAsTyped:  true,
+			expAnyOrder: true,
+		},
+		{
+			name:        "Flag with value before verb",
+			pattern:     config.CommandPattern{Glob: "kubectl delete *"},
+			cmd:         "kubectl -n prod delete pod api",
+			expAnyOrder: true,
+		},
+		{
+			name:        "Boolean and value flags before verb",
+			pattern:     config.CommandPattern{Glob: "kubectl delete *"},
+			cmd:         "kubectl --insecure-skip-tls-verify --context prod delete pod api",
+			expAnyOrder: true,
+		},
+		{
+			name:        "Flag with equal sign before verb",
+			pattern:     config.CommandPattern{Regex: `kubectl scale .*--replicas=0.*`},
+			cmd:         "kubectl --namespace=prod scale --replicas=0 deploy api",
+			expAnyOrder: true,
+		},
+		{
+			name:    "Arguments after double dash are not flags",
+			pattern: config.CommandPattern{Glob: "kubectl exec * -- rm *"},
+			cmd:     "kubectl -n prod exec api -- ls -la /tmp",
+		},
+		{
+			name:    "Different command",
+			pattern: config.CommandPattern{Glob: "kubectl delete *"},
+			cmd:     "kubectl -n prod get pods",
+		},
+		{
+			name:         "Too many flags",
+			pattern:      config.CommandPattern{Glob: "kubectl delete *"},
+			cmd:          "kubectl -a 1 -b 2 -c 3 -d 4 -e 5 -f 6 -g 7 -h 8 -i 9 -j 10 -k 11 get pods",
+			expErrSubstr: "command has more than 10 flags which may take a value",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			asTyped, err := tc.pattern.Matches(tc.cmd)
+			require.NoError(t, err)
+			anyOrder, err := tc.pattern.MatchesInAnyFlagOrder(tc.cmd)
+
+			// then
+			assert.Equal(t, tc.expAsTyped, asTyped)
+			if tc.expErrSubstr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expErrSubstr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expAnyOrder, anyOrder)
+		})
+	}
+}
+
+func TestLoadWithDefaultsCompilesCommandPatterns(t *testing.T) {
+	// given
+	cfg := []byte(heredoc.Doc(`
+		communications:
+		  'default-group':
+		    socketSlack:
+		      enabled: false
+		settings:
+		  commandConfirmation:
+		    commands:
+		      - glob: "kubectl delete *"
+		      - regex: "kubectl scale .*--replicas=0.*"`))
+
+	// when
+	gotCfg, _, err := config.LoadWithDefaults([][]byte{cfg})
+
+	// then
+	require.NoError(t, err)
+	commands := gotCfg.Settings.CommandConfirmation.Commands
+	require.Len(t, commands, 2)
+	for _, pattern := range commands {
+		assert.True(t, pattern.IsCompiled(), pattern.String())
+	}
+}
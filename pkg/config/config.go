@@ -147,6 +147,7 @@ type Config struct {
 	Actions        Actions                   `yaml:"actions" validate:"dive"`
 	Sources        map[string]Sources        `yaml:"sources" validate:"dive"`
 	Executors      map[string]Executors      `yaml:"executors" validate:"dive"`
+	Transformers   map[string]Transformers   `yaml:"transformers" validate:"dive"`
 	Aliases        Aliases                   `yaml:"aliases" validate:"dive"`
 	Communications map[string]Communications `yaml:"communications"  validate:"required,min=1,dive"`
 
@@ -194,6 +195,73 @@ type IncomingWebhook struct {
 
 	// InClusterBaseURL is the in-cluster URL of the incoming webhook. Passed for plugins in context.
 	InClusterBaseURL string `yaml:"inClusterBaseURL"`
+	// MaxBodySize is the maximum size of a request body in bytes. Larger requests are rejected with the 413 status code.
+	MaxBodySize int64 `yaml:"maxBodySize" validate:"gte=0"`
+
+	// TLS configures HTTPS for the incoming webhook server.
+	TLS IncomingWebhookTLS `yaml:"tls,omitempty"`
+	// Auth contains authentication settings per source name. Requests for sources without an entry are not authenticated.
+	Auth map[string]IncomingWebhookAuth `yaml:"auth,omitempty" validate:"dive"`
+}
+
+// IncomingWebhookTLS contains TLS configuration for the incoming webhook server.
+type IncomingWebhookTLS struct {
+	Enabled  bool   `yaml:"enabled"`
+	CertFile string `yaml:"certFile" validate:"required_if=Enabled true"`
+	KeyFile  string `yaml:"keyFile" validate:"required_if=Enabled true"`
+	// ClientCAFile is a CA bundle used to verify client certificates. It's required for mTLS.
+	ClientCAFile string `yaml:"clientCAFile,omitempty"`
+}
+
+// IncomingWebhookAuth contains authentication settings for a given source. All configured methods must succeed.
+type IncomingWebhookAuth struct {
+	// BearerTokens contains references to Secret keys with accepted static tokens, passed in the `Authorization: Bearer <token>` header.
+	BearerTokens []SecretKeyRef `yaml:"bearerTokens,omitempty" validate:"dive"`
+	// Signature configures HMAC signature verification of the request body.
+	Signature *IncomingWebhookSignature `yaml:"signature,omitempty"`
+	// ClientCert requires a verified client certificate (mTLS). It requires TLS with ClientCAFile to be configured.
+	ClientCert *IncomingWebhookClientCert `yaml:"clientCert,omitempty"`
+}
+
+// IncomingWebhookSignatureStyle defines how the HMAC signature is passed in the request.
+type IncomingWebhookSignatureStyle string
+
+const (
+	// GitHubIncomingWebhookSignatureStyle is a hex-encoded HMAC of the request body with an optional prefix, e.g. `X-Hub-Signature-256: sha256=<hex>`.
+	GitHubIncomingWebhookSignatureStyle IncomingWebhookSignatureStyle = "GitHub"
+	// StripeIncomingWebhookSignatureStyle is a hex-encoded HMAC of the `<timestamp>.<body>` payload, e.g. `Stripe-Signature: t=<timestamp>,v1=<hex>`.
+	StripeIncomingWebhookSignatureStyle IncomingWebhookSignatureStyle = "Stripe"
+	// GitLabIncomingWebhookSignatureStyle is the shared secret passed as is, e.g. `X-Gitlab-Token: <secret>`.
+	GitLabIncomingWebhookSignatureStyle IncomingWebhookSignatureStyle = "GitLab"
+)
+
+// IncomingWebhookSignature contains signature verification settings.
+type IncomingWebhookSignature struct {
+	Style IncomingWebhookSignatureStyle `yaml:"style" validate:"omitempty,oneof=GitHub Stripe GitLab"`
+	// Header is the name of the header with the signature, e.g. `X-Hub-Signature-256`.
+	Header string `yaml:"header" validate:"required"`
+	// Algorithm is the hash function used for HMAC. Not used for the GitLab style.
+	Algorithm string `yaml:"algorithm,omitempty" validate:"omitempty,oneof=sha1 sha256 sha512"`
+	// Prefix is trimmed from the header value before comparing, e.g. `sha256=`. Used only for the GitHub style.
+	Prefix string `yaml:"prefix,omitempty"`
+	// Tolerance is the maximum age of the signed timestamp. Used only for the Stripe style.
+	Tolerance time.Duration `yaml:"tolerance,omitempty"`
+	// Secret is a reference to the Secret key with the shared HMAC secret or, for the GitLab style, the expected token.
+	Secret SecretKeyRef `yaml:"secret"`
+}
+
+// IncomingWebhookClientCert contains client certificate requirements.
+type IncomingWebhookClientCert struct {
+	// AllowedNames contains allowed client certificate common names or DNS SANs. If empty, all verified certificates are accepted.
+	AllowedNames []string `yaml:"allowedNames,omitempty"`
+}
+
+// SecretKeyRef references a key in a given K8s Secret.
+type SecretKeyRef struct {
+	Name string `yaml:"name" validate:"required"`
+	// Namespace defaults to the Botkube namespace.
+	Namespace string `yaml:"namespace,omitempty"`
+	Key       string `yaml:"key" validate:"required"`
 }
 
 // CloudSlackChannel contains configuration bindings per channel.
@@ -214,6 +282,7 @@ type ChannelBindingsByName struct {
 	Notification    ChannelNotification   `yaml:"notification"` // TODO: rename to `notifications` later
 	Bindings        BotBindings           `yaml:"bindings"`
 	MessageTriggers []TextMessageTriggers `yaml:"messageTriggers"`
+	CommandPolicy   CommandPolicy         `yaml:"commandPolicy,omitempty"`
 }
 
 // Identifier returns ChannelBindingsByName identifier.
@@ -258,9 +327,10 @@ func (m *TextMessageTriggers) IsUserExcluded(userID string) bool {
 
 // ChannelBindingsByID contains configuration bindings per channel.
 type ChannelBindingsByID struct {
-	ID           string              `yaml:"id"`
-	Notification ChannelNotification `yaml:"notification"` // TODO: rename to `notifications` later
-	Bindings     BotBindings         `yaml:"bindings"`
+	ID            string              `yaml:"id"`
+	Notification  ChannelNotification `yaml:"notification"` // TODO: rename to `notifications` later
+	Bindings      BotBindings         `yaml:"bindings"`
+	CommandPolicy CommandPolicy       `yaml:"commandPolicy,omitempty"`
 }
 
 // Identifier returns ChannelBindingsByID identifier.
@@ -273,6 +343,32 @@ func (c ChannelBindingsByID) GetBotBindings() BotBindings {
 	return c.Bindings
 }
 
+// CommandPolicy defines which executor commands can be run in a given channel.
+type CommandPolicy struct {
+	// Allow lists commands which can be run. If empty, all commands which are not denied can be run.
+	// Commands must match allow rules as typed, so flags placed before the command verb may need to be moved.
+	Allow []CommandPattern `yaml:"allow,omitempty" validate:"dive"`
+	// Deny lists commands which can't be run. It takes precedence over Allow.
+	// Commands are matched also with their flags moved after positional arguments, so flag order doesn't bypass them.
+	Deny []CommandPattern `yaml:"deny,omitempty" validate:"dive"`
+}
+
+// IsDefined returns true if any rule is defined.
+func (p CommandPolicy) IsDefined() bool {
+	return len(p.Allow) > 0 || len(p.Deny) > 0
+}
+
+// CommandPattern matches a whole command with aliases expanded. Either Glob or Regex must be defined.
+type CommandPattern struct {
+	// Glob is a glob pattern, where `*` matches any sequence of characters, including spaces.
+	Glob string `yaml:"glob,omitempty"`
+	// Regex is a regular expression.
+	Regex string `yaml:"regex,omitempty"`
+
+	// compiled holds the pattern compiled when the config is loaded.
+	compiled *compiledCommandPattern
+}
+
 // BotBindings contains configuration for possible Bot bindings.
 type BotBindings struct {
 	Sources   []string `yaml:"sources"`
@@ -289,10 +385,69 @@ type Actions map[string]Action
 
 // Action contains configuration for Botkube app event automations.
 type Action struct {
-	Enabled     bool           `yaml:"enabled"`
-	DisplayName string         `yaml:"displayName"`
-	Command     string         `yaml:"command" validate:"required_if=Enabled true"`
-	Bindings    ActionBindings `yaml:"bindings"`
+	Enabled     bool   `yaml:"enabled"`
+	DisplayName string `yaml:"displayName"`
+	Command     string `yaml:"command"`
+	// Steps is an ordered list of commands executed one by one. It cannot be used together with Command.
+	Steps []ActionStep `yaml:"steps,omitempty" validate:"dive"`
+	// Condition is an optional Go template rendered with a given event. The action is executed only if it evaluates to "true".
+	Condition string `yaml:"condition,omitempty"`
+	// Cooldown prevents executing the action too often for the same event key.
+	Cooldown ActionCooldown `yaml:"cooldown,omitempty"`
+	// Limit disables the action once it's executed too many times, for example, in an event loop.
+	Limit ActionLimit `yaml:"limit,omitempty"`
+	// RequireApproval defines whether the rendered command is executed only after a human approves it.
+	RequireApproval bool `yaml:"requireApproval,omitempty"`
+	// Approval contains configuration for the approval step. It's used only if RequireApproval is enabled.
+	Approval ActionApproval `yaml:"approval,omitempty"`
+	Bindings ActionBindings `yaml:"bindings"`
+}
+
+// ActionApproval contains configuration for action approval.
+type ActionApproval struct {
+	// Approvers is a list of users allowed to approve or reject the action. Users are matched by their ID or mention only,
+	// as display names can be changed by users.
+	// If not specified, everyone in the channel can decide.
+	Approvers []string `yaml:"approvers,omitempty"`
+	// Timeout after which the approval request expires. Defaults to 1 hour.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// ActionStep contains configuration for a single step of a multi-step action.
+type ActionStep struct {
+	// Name identifies the step. Subsequent steps can refer to its result via {{ .Steps.<name> }}.
+	Name string `yaml:"name" validate:"required"`
+	// Command is a Go template rendered with a given event and results of previous steps.
+	Command string `yaml:"command" validate:"required"`
+	// Extract defines values extracted from the step output. They are available as {{ .Steps.<name>.Values.<key> }}.
+	Extract map[string]ActionStepExtract `yaml:"extract,omitempty"`
+	// ContinueOnError defines whether subsequent steps are executed if this step fails.
+	ContinueOnError bool `yaml:"continueOnError,omitempty"`
+}
+
+// ActionStepExtract defines how to extract a value from the step output. Exactly one of the fields must be set.
+type ActionStepExtract struct {
+	// Regex is a regular expression matched against the step output. If it has a capturing group, the first group is used.
+	Regex string `yaml:"regex,omitempty"`
+	// JSONPath is a Kubernetes JSONPath template, such as "{.metadata.name}", evaluated against the step output parsed as JSON.
+	JSONPath string `yaml:"jsonPath,omitempty"`
+}
+
+// ActionCooldown contains configuration for action cooldown.
+type ActionCooldown struct {
+	// Key is a Go template rendered with a given event, such as "{{ .Event.Namespace }}/{{ .Event.Name }}".
+	// Cooldown is tracked separately for each rendered key. If not specified, all events share the same key.
+	Key string `yaml:"key,omitempty"`
+	// Period is the minimal time between two action executions for the same key. Zero value disables the cooldown.
+	Period time.Duration `yaml:"period,omitempty"`
+}
+
+// ActionLimit contains configuration for the action execution limit.
+type ActionLimit struct {
+	// MaxExecutions is the maximal number of action executions within the Window. Zero value disables the limit.
+	MaxExecutions int `yaml:"maxExecutions,omitempty" validate:"gte=0"`
+	// Window is the time window in which executions are counted.
+	Window time.Duration `yaml:"window,omitempty"`
 }
 
 // ActionBindings contains configuration for action bindings.
@@ -303,8 +458,39 @@ type ActionBindings struct {
 
 // Sources contains configuration for Botkube app sources.
 type Sources struct {
-	DisplayName string  `yaml:"displayName"`
-	Plugins     Plugins `yaml:",inline" koanf:",remain"`
+	DisplayName string `yaml:"displayName"`
+	// ScheduledCommands contains Botkube commands executed periodically. Their results are sent to channels bound to this source.
+	ScheduledCommands map[string]ScheduledCommand `yaml:"scheduledCommands,omitempty" validate:"dive"`
+	// Transformers contains names of transformers applied to events emitted by this source. They are called in the given order.
+	Transformers []string `yaml:"transformers,omitempty"`
+	// Sinks configures how events emitted by this source are sent to sinks.
+	Sinks   SourceSinks `yaml:"sinks,omitempty"`
+	Plugins Plugins     `yaml:",inline" koanf:",remain"`
+}
+
+// SourceSinks contains configuration for sending source events to sinks.
+type SourceSinks struct {
+	// ReuseInteractiveStream sends events from the stream started for interactive platforms to sinks, instead of starting
+	// a dedicated non-interactive stream. Events are sent together with the plaintext rendering of the interactive message.
+	// It's used only if the source isn't bound to any non-interactive platform.
+	ReuseInteractiveStream bool `yaml:"reuseInteractiveStream,omitempty"`
+}
+
+// ScheduledCommand contains configuration for a Botkube command executed on a cron schedule.
+type ScheduledCommand struct {
+	Enabled bool `yaml:"enabled"`
+	// Schedule is a cron expression with 5 fields, e.g. `0 8 * * 1-5`, or one of the @hourly, @daily, @weekly descriptors, or `@every <duration>`.
+	Schedule string `yaml:"schedule" validate:"required_if=Enabled true"`
+	// Timezone is an IANA time zone name, e.g. `Europe/Warsaw`, in which the schedule is evaluated. If not set, the local time zone of the Botkube agent is used.
+	Timezone string `yaml:"timezone,omitempty"`
+	// Command is executed in the same way as automated actions, e.g. `kubectl get pods -A`.
+	Command string `yaml:"command" validate:"required_if=Enabled true"`
+	// ExecutorBindings contains executors used to run the command.
+	ExecutorBindings []string `yaml:"executorBindings"`
+	// OnlyIfChanged skips posting the result if the output is the same as in the previous run.
+	OnlyIfChanged bool `yaml:"onlyIfChanged"`
+	// OnlyIfNonEmpty skips posting the result if the command returned no output.
+	OnlyIfNonEmpty bool `yaml:"onlyIfNonEmpty"`
 }
 
 // GetPlugins returns Sources.Plugins.
@@ -344,6 +530,8 @@ type GroupPolicySubject struct {
 	Static GroupStaticSubject `yaml:"static"`
 	// Prefix is optional string prefixed to subjects.
 	Prefix string `yaml:"prefix"`
+	// Mapping maps chat users to subjects for given UserName or UserEmail policy rule.
+	Mapping GroupSubjectMapping `yaml:"mapping"`
 }
 
 // GroupStaticSubject references static subjects for given static policy rule.
@@ -352,6 +540,24 @@ type GroupStaticSubject struct {
 	Values []string `yaml:"values"`
 }
 
+// GroupSubjectMapping maps chat users to group subjects.
+type GroupSubjectMapping struct {
+	// Users lists chat users with their subjects. It takes precedence over the template.
+	Users []GroupSubjectMappingEntry `yaml:"users"`
+	// Template renders the subject for chat users which are not listed. The chat user is available as .ID and .Email.
+	Template string `yaml:"template"`
+	// Fallback holds subjects used for chat users which can't be mapped. If empty, such users can't run commands.
+	Fallback []string `yaml:"fallback"`
+}
+
+// GroupSubjectMappingEntry maps a given chat user to group subjects.
+type GroupSubjectMappingEntry struct {
+	// ChatUser is the chat platform user ID or email, depending on the policy type.
+	ChatUser string `yaml:"chatUser"`
+	// Values is the name of the subjects.
+	Values []string `yaml:"values"`
+}
+
 // UserPolicySubject is the RBAC subject.
 type UserPolicySubject struct {
 	// Type is the type of policy subject.
@@ -360,6 +566,8 @@ type UserPolicySubject struct {
 	Static UserStaticSubject `yaml:"static"`
 	// Prefix is optional string prefixed to subjects.
 	Prefix string `yaml:"prefix"`
+	// Mapping maps chat users to subjects for given UserName or UserEmail policy rule.
+	Mapping UserSubjectMapping `yaml:"mapping"`
 }
 
 // UserStaticSubject references static subjects for given static policy rule.
@@ -368,6 +576,24 @@ type UserStaticSubject struct {
 	Value string `yaml:"value"`
 }
 
+// UserSubjectMapping maps chat users to user subjects.
+type UserSubjectMapping struct {
+	// Users lists chat users with their subjects. It takes precedence over the template.
+	Users []UserSubjectMappingEntry `yaml:"users"`
+	// Template renders the subject for chat users which are not listed. The chat user is available as .ID and .Email.
+	Template string `yaml:"template"`
+	// Fallback is the subject used for chat users which can't be mapped. If empty, such users can't run commands.
+	Fallback string `yaml:"fallback"`
+}
+
+// UserSubjectMappingEntry maps a given chat user to a user subject.
+type UserSubjectMappingEntry struct {
+	// ChatUser is the chat platform user ID or email, depending on the policy type.
+	ChatUser string `yaml:"chatUser"`
+	// Value is the name of the subject.
+	Value string `yaml:"value"`
+}
+
 // PolicySubjectType defines the types for policy subjects.
 type PolicySubjectType string
 
@@ -378,14 +604,47 @@ const (
 	StaticPolicySubjectType PolicySubjectType = "Static"
 	// ChannelNamePolicySubjectType is the channel name policy type.
 	ChannelNamePolicySubjectType PolicySubjectType = "ChannelName"
+	// UserNamePolicySubjectType is the chat user policy type. Chat users are identified by their immutable platform user ID,
+	// such as Slack member ID, as display names can be changed by users.
+	UserNamePolicySubjectType PolicySubjectType = "UserName"
+	// UserEmailPolicySubjectType is the chat user email policy type.
+	UserEmailPolicySubjectType PolicySubjectType = "UserEmail"
 )
 
+// IsChatUserBased returns true if subjects are derived from the chat user who runs a given command.
+func (t PolicySubjectType) IsChatUserBased() bool {
+	return t == UserNamePolicySubjectType || t == UserEmailPolicySubjectType
+}
+
 // Executors contains executors configuration parameters.
 type Executors struct {
 	DisplayName string  `yaml:"displayName"`
 	Plugins     Plugins `yaml:",inline" koanf:",remain"`
 }
 
+// Transformers contains configuration for plugins which modify source events before they are dispatched.
+type Transformers struct {
+	DisplayName string `yaml:"displayName"`
+	// OnError defines what happens with an event if the transformer fails or is not running. Defaults to Drop.
+	OnError TransformerErrorPolicy `yaml:"onError,omitempty" validate:"omitempty,oneof=Drop Passthrough"`
+	Plugins Plugins                `yaml:",inline" koanf:",remain"`
+}
+
+// TransformerErrorPolicy defines what happens with an event if a transformer fails.
+type TransformerErrorPolicy string
+
+const (
+	// DropTransformerErrorPolicy drops the event, so that events are never sent without e.g. redacting sensitive data.
+	DropTransformerErrorPolicy TransformerErrorPolicy = "Drop"
+	// PassthroughTransformerErrorPolicy passes the event to the next transformer as it was.
+	PassthroughTransformerErrorPolicy TransformerErrorPolicy = "Passthrough"
+)
+
+// GetPlugins returns Transformers.Plugins.
+func (t Transformers) GetPlugins() Plugins {
+	return t.Plugins
+}
+
 // CollectCommandPrefixes returns list of command prefixes for all executors, even disabled ones.
 func (e Executors) CollectCommandPrefixes() []string {
 	var prefixes []string
@@ -499,6 +758,44 @@ type Communications struct {
 	Webhook       Webhook       `yaml:"webhook,omitempty"`
 	Elasticsearch Elasticsearch `yaml:"elasticsearch,omitempty"`
 	PagerDuty     PagerDuty     `yaml:"pagerDuty,omitempty"`
+
+	// Routing contains rules which route events to selected channels and sinks of a given communication group.
+	Routing []RoutingRule `yaml:"routing,omitempty" validate:"dive"`
+}
+
+// RoutingRule routes events matching given criteria to selected channels and sinks.
+// Rules are evaluated in order. Once an event matches a rule, source bindings of channels and sinks
+// within a given communication group are ignored, and the event is sent only to the rule targets.
+// If no rule matches, the event is delivered based on source bindings. Bots and sinks also use source bindings
+// if the matched rules don't define channels or sinks respectively.
+type RoutingRule struct {
+	Name  string       `yaml:"name" validate:"required"`
+	Match RoutingMatch `yaml:"match"`
+
+	// Channels contains identifiers (names or IDs) of channels which should receive matched events.
+	Channels []string `yaml:"channels,omitempty"`
+	// Sinks contains sink integrations which should receive matched events.
+	Sinks []CommPlatformIntegration `yaml:"sinks,omitempty" validate:"dive,oneof=webhook elasticsearch pagerDuty"`
+
+	// Continue specifies whether the next rules should be evaluated after this rule matches.
+	// Targets of all matched rules are merged.
+	Continue bool `yaml:"continue,omitempty"`
+}
+
+// RoutingMatch contains routing rule criteria. All specified criteria must match. Empty criteria match all events.
+type RoutingMatch struct {
+	// Sources contains names of the source bindings.
+	Sources []string `yaml:"sources,omitempty"`
+	// Plugins contains names of the source plugins, e.g. `botkube/kubernetes`.
+	Plugins []string `yaml:"plugins,omitempty"`
+	// Severity contains event levels, e.g. `error` or `critical`.
+	Severity []string `yaml:"severity,omitempty"`
+	// Namespaces contains allowed and excluded namespaces of the involved object.
+	Namespaces RegexConstraints `yaml:"namespaces,omitempty"`
+	// Kinds contains allowed and excluded kinds of the involved object.
+	Kinds RegexConstraints `yaml:"kinds,omitempty"`
+	// Fields maps field paths of the raw event, e.g. `metadata.labels.team`, to regular expressions the field values must match.
+	Fields map[string]string `yaml:"fields,omitempty"`
 }
 
 // SocketSlack configuration to authentication and send notifications
@@ -644,16 +941,104 @@ type InClusterCfgWatcher struct {
 
 // Settings contains Botkube's related configuration.
 type Settings struct {
-	ClusterName             string           `yaml:"clusterName"`
-	UpgradeNotifier         bool             `yaml:"upgradeNotifier"`
-	SystemConfigMap         K8sResourceRef   `yaml:"systemConfigMap"`
-	PersistentConfig        PersistentConfig `yaml:"persistentConfig"`
-	MetricsPort             string           `yaml:"metricsPort"`
-	HealthPort              string           `yaml:"healthPort"`
-	Log                     Logger           `yaml:"log"`
-	InformersResyncPeriod   time.Duration    `yaml:"informersResyncPeriod"`
-	Kubeconfig              string           `yaml:"kubeconfig"`
-	SACredentialsPathPrefix string           `yaml:"saCredentialsPathPrefix"`
+	ClusterName             string              `yaml:"clusterName"`
+	UpgradeNotifier         bool                `yaml:"upgradeNotifier"`
+	SystemConfigMap         K8sResourceRef      `yaml:"systemConfigMap"`
+	PersistentConfig        PersistentConfig    `yaml:"persistentConfig"`
+	MetricsPort             string              `yaml:"metricsPort"`
+	HealthPort              string              `yaml:"healthPort"`
+	Log                     Logger              `yaml:"log"`
+	InformersResyncPeriod   time.Duration       `yaml:"informersResyncPeriod"`
+	Kubeconfig              string              `yaml:"kubeconfig"`
+	SACredentialsPathPrefix string              `yaml:"saCredentialsPathPrefix"`
+	Delivery                Delivery            `yaml:"delivery,omitempty"`
+	EventHistory            EventHistory        `yaml:"eventHistory,omitempty"`
+	Deprecations            Deprecations        `yaml:"deprecations,omitempty"`
+	CommandConfirmation     CommandConfirmation `yaml:"commandConfirmation,omitempty"`
+}
+
+// CommandConfirmation holds configuration for confirming destructive executor commands before running them.
+type CommandConfirmation struct {
+	// Commands lists destructive commands. The confirmation is disabled if it's empty.
+	// Commands are matched also with their flags moved after positional arguments, so flag order doesn't bypass them.
+	Commands []CommandPattern `yaml:"commands,omitempty" validate:"dive"`
+	// Timeout is the time the user has to confirm a command. Defaults to 5 minutes.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// Deprecations holds configuration for the `deprecations scan` command.
+type Deprecations struct {
+	// Context defines the RBAC used to list cluster resources during the scan. The scan is disabled if RBAC is not configured.
+	Context PluginContext `yaml:"context,omitempty"`
+}
+
+// EventHistory holds configuration for the in-memory history of dispatched events.
+type EventHistory struct {
+	// MaxEventsPerSource is the number of recent events kept for each source. Set to 0 to disable the history.
+	MaxEventsPerSource int `yaml:"maxEventsPerSource" validate:"min=0"`
+}
+
+// Delivery holds configuration for per-notifier delivery queues.
+type Delivery struct {
+	// Workers is the number of concurrent workers per notifier. Messages from the same source, or posted to the same channel,
+	// are always delivered by the same worker to preserve ordering.
+	Workers int `yaml:"workers"`
+	// BufferSize is the maximum number of messages buffered in memory for a single notifier.
+	BufferSize int `yaml:"bufferSize"`
+	// OverflowPolicy defines what happens when the buffer is full.
+	OverflowPolicy DeliveryOverflowPolicy `yaml:"overflowPolicy" validate:"omitempty,oneof=Block Spill"`
+	// BlockTimeout is the maximum time a message waits for a room in the buffer when the Block policy is used.
+	// After that, the message is spilled over, so a stuck notifier doesn't stall the other ones. If zero, it waits indefinitely.
+	BlockTimeout time.Duration `yaml:"blockTimeout"`
+	// Retry holds the retry configuration for failed deliveries.
+	Retry DeliveryRetry `yaml:"retry"`
+	// Spillover holds configuration for persisting messages that don't fit into the buffer.
+	Spillover DeliverySpillover `yaml:"spillover"`
+}
+
+// DeliveryOverflowPolicy defines the behavior when a delivery queue buffer is full.
+type DeliveryOverflowPolicy string
+
+const (
+	// BlockDeliveryOverflowPolicy blocks the source until there is a room in the buffer. As a result, backpressure is propagated to source streams.
+	BlockDeliveryOverflowPolicy DeliveryOverflowPolicy = "Block"
+	// SpillDeliveryOverflowPolicy moves messages to the spillover store. If it's not configured or full, messages are dropped.
+	SpillDeliveryOverflowPolicy DeliveryOverflowPolicy = "Spill"
+)
+
+// DeliveryRetry holds the exponential backoff configuration for failed deliveries.
+type DeliveryRetry struct {
+	// MaxAttempts is the maximum number of delivery attempts, including the first one.
+	MaxAttempts uint `yaml:"maxAttempts"`
+	// InitialDelay is the delay before the first retry. It is doubled on each consecutive retry.
+	InitialDelay time.Duration `yaml:"initialDelay"`
+	// MaxDelay is the upper bound for the delay between retries.
+	MaxDelay time.Duration `yaml:"maxDelay"`
+}
+
+// DeliverySpilloverType defines the storage type for spilled messages.
+type DeliverySpilloverType string
+
+const (
+	// NoneDeliverySpilloverType disables spillover. Messages that don't fit into the buffer are dropped.
+	NoneDeliverySpilloverType DeliverySpilloverType = ""
+	// DiskDeliverySpilloverType persists spilled messages on disk.
+	DiskDeliverySpilloverType DeliverySpilloverType = "Disk"
+	// ConfigMapDeliverySpilloverType persists spilled messages in a ConfigMap.
+	ConfigMapDeliverySpilloverType DeliverySpilloverType = "ConfigMap"
+)
+
+// DeliverySpillover holds configuration for persisting queued messages, so they survive agent restarts.
+type DeliverySpillover struct {
+	Type DeliverySpilloverType `yaml:"type" validate:"omitempty,oneof=Disk ConfigMap"`
+	// MaxItems is the maximum number of spilled messages per notifier. Messages above this limit are dropped.
+	MaxItems int `yaml:"maxItems"`
+	// Path is the directory where spilled messages are stored. Used only for the Disk type.
+	// It must be a mounted persistent volume, otherwise messages don't survive Pod restarts.
+	Path string `yaml:"path" validate:"required_if=Type Disk"`
+	// ConfigMap is the ConfigMap where spilled messages are stored. Used only for the ConfigMap type.
+	// It is shared by all notifiers and limited in size, so messages which don't fit are dropped.
+	ConfigMap K8sResourceRef `yaml:"configMap"`
 }
 
 // Formatter log formatter
@@ -736,7 +1121,8 @@ func LoadWithDefaults(configs [][]byte) (*Config, LoadWithDefaultsDetails, error
 			DecodeHook: mapstructure.ComposeDecodeHookFunc(
 				mapstructure.StringToTimeDurationHookFunc(),
 				mapstructure.StringToSliceHookFunc(","),
-				mapstructure.TextUnmarshallerHookFunc()),
+				mapstructure.TextUnmarshallerHookFunc(),
+				commandPatternHookFunc()),
 			Metadata:         nil,
 			Result:           &cfg,
 			WeaklyTypedInput: true,
@@ -182,6 +182,121 @@ func TestLoadedConfigValidationErrors(t *testing.T) {
 				readTestdataFile(t, "missing-action-bindings.yaml"),
 			},
 		},
+		{
+			name: "invalid scheduled command",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 3 errors occurred:
+					* Key: 'Config.Sources[daily-report].ScheduledCommands[not-running-pods].Timezone' Timezone has invalid time zone: unknown time zone Mars/Olympus_Mons
+					* Key: 'Config.Sources[daily-report].ScheduledCommands[not-running-pods].Schedule' Schedule has invalid cron expression: invalid hour "25": value 25 out of range [0, 23]
+					* Key: 'Config.Sources[daily-report].ScheduledCommands[not-running-pods].kubectl-read-only' 'kubectl-read-only' binding not defined in Config.Executors`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-scheduled-command.yaml"),
+			},
+		},
+		{
+			name: "missing transformer",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Sources[k8s-events].enrich-owners' 'enrich-owners' binding not defined in Config.Transformers`),
+			configs: [][]byte{
+				readTestdataFile(t, "missing-transformer.yaml"),
+			},
+		},
+		{
+			name: "invalid transformer error policy",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Transformers[redact-secrets].OnError' OnError must be one of [Drop Passthrough]`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-transformer-error-policy.yaml"),
+			},
+		},
+		{
+			name: "action limit without window",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Actions[restart-on-oom].Limit.Window' Window is a required field`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-action-limit.yaml"),
+			},
+		},
+		{
+			name: "action with both command and steps",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Actions[both-command-and-steps].Steps' Steps cannot be used together with Command`),
+			configs: [][]byte{
+				readTestdataFile(t, "action-steps-with-command.yaml"),
+			},
+		},
+		{
+			name: "missing action command",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Actions[no-command].Command' Command is a required field`),
+			configs: [][]byte{
+				readTestdataFile(t, "missing-action-command.yaml"),
+			},
+		},
+		{
+			name: "invalid action steps",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 3 errors occurred:
+					* Key: 'Config.Actions[invalid-steps].Steps[0].Extract[node]' Steps[0].Extract[node] has invalid regular expression: error parsing regexp: missing closing ): ` + "`node=(`" + `
+					* Key: 'Config.Actions[invalid-steps].Steps[1].Name' Steps[1].Name "pod" is already used by another step
+					* Key: 'Config.Actions[invalid-steps].Steps[1].Extract[both]' Steps[1].Extract[both] must define either regex or jsonPath`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-action-steps.yaml"),
+			},
+		},
+		{
+			name: "invalid action templates",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 3 errors occurred:
+					* Key: 'Config.Actions[invalid-templates].Condition' Condition has invalid template: template: action:1: unexpected "}" in operand
+					* Key: 'Config.Actions[invalid-templates].Cooldown.Key' Cooldown.Key has invalid template: template: action:1: unclosed action
+					* Key: 'Config.Actions[invalid-templates].Steps[0].Command' Steps[0].Command has invalid template: template: action:1: unexpected EOF`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-action-templates.yaml"),
+			},
+		},
+		{
+			name: "action with chat user RBAC without fallback",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Actions[show-created-resource].Bindings.kubectl-per-user' Plugin botkube/kubectl has 'UserEmail' RBAC policy without a fallback. Actions are not executed by chat users, so the fallback is required. See https://docs.botkube.io/configuration/action#rbac`),
+			configs: [][]byte{
+				readTestdataFile(t, "action-user-rbac.yaml"),
+			},
+		},
+		{
+			name: "scheduled command executor with chat user RBAC without fallback",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Sources[daily-report].ScheduledCommands[not-running-pods].kubectl-per-user' Plugin botkube/kubectl has 'UserEmail' RBAC policy without a fallback. Scheduled commands are not executed by chat users, so the fallback is required.`),
+			configs: [][]byte{
+				readTestdataFile(t, "scheduled-command-user-rbac.yaml"),
+			},
+		},
+		{
+			name: "invalid RBAC mapping template",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 1 error occurred:
+					* Key: 'Config.Executors[kubectl-per-user].botkube/kubectl.Context.RBAC.Group.Mapping.Template' botkube/kubectl.Context.RBAC.Group.Mapping.Template has invalid template: template: rbac-subject:1: unclosed action`),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-rbac-mapping.yaml"),
+			},
+		},
+		{
+			name: "invalid command policy",
+			expErrMsg: heredoc.Doc(`
+				found critical validation errors: 2 errors occurred:
+					* Key: 'Config.Communications[default-workspace].SocketSlack.Channels[alias].CommandPolicy.Deny[0].Pattern' Pattern must define either glob or regex
+					* Key: 'Config.Communications[default-workspace].SocketSlack.Channels[alias].CommandPolicy.Deny[1].Regex' Regex has invalid regular expression: error parsing regexp: missing closing ): ` + "`kubectl (get`"),
+			configs: [][]byte{
+				readTestdataFile(t, "invalid-command-policy.yaml"),
+			},
+		},
 		{
 			name: "missing alias command",
 			expErrMsg: heredoc.Doc(`
@@ -3,3 +3,7 @@ package config
 func NormalizeConfigEnvName(name string) string {
 	return normalizeConfigEnvName(name)
 }
+
+func (p CommandPattern) IsCompiled() bool {
+	return p.compiled != nil
+}
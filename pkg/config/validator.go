@@ -5,14 +5,19 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
+	sprig "github.com/go-task/slim-sprig"
+	"github.com/gobwas/glob"
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/kubeshop/botkube/pkg/conversation"
+	"github.com/kubeshop/botkube/pkg/cronx"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	multierrx "github.com/kubeshop/botkube/pkg/multierror"
 )
@@ -28,6 +33,15 @@ const (
 	invalidAliasCommandTag      = "invalid_alias_command"
 	invalidPluginRBACTag        = "invalid_plugin_rbac"
 	invalidActionRBACTag        = "invalid_action_tag"
+	invalidRoutingFieldTag      = "invalid_routing_field"
+	invalidScheduleTag          = "invalid_schedule"
+	invalidTimezoneTag          = "invalid_timezone"
+	invalidActionStepTag        = "invalid_action_step"
+	invalidActionTemplateTag    = "invalid_action_template"
+	invalidRBACMappingTag       = "invalid_rbac_mapping"
+	invalidActionUserRBACTag    = "invalid_action_user_rbac"
+	invalidScheduledUserRBACTag = "invalid_scheduled_command_user_rbac"
+	invalidCommandPatternTag    = "invalid_command_pattern"
 	appTokenPrefix              = "xapp-"
 	botTokenPrefix              = "xoxb-"
 )
@@ -90,6 +104,10 @@ func ValidateStruct(in any) (ValidateResult, error) {
 
 	validate.RegisterStructValidation(sourceStructValidator, Sources{})
 	validate.RegisterStructValidation(executorStructValidator, Executors{})
+	validate.RegisterStructValidation(transformerStructValidator, Transformers{})
+	validate.RegisterStructValidation(actionStructValidator, Action{})
+	validate.RegisterStructValidation(actionLimitStructValidator, ActionLimit{})
+	validate.RegisterStructValidation(commandPatternStructValidator, CommandPattern{})
 
 	err := validate.Struct(in)
 	if err == nil {
@@ -120,8 +138,12 @@ func ValidateStruct(in any) (ValidateResult, error) {
 
 func registerCustomTranslations(validate *validator.Validate, trans ut.Translator) error {
 	return registerTranslation(validate, trans, map[string]string{
-		"invalid_slack_token": "{0} {1}",
-		invalidChannelNameTag: "The channel name '{0}' seems to be invalid. See the documentation to learn more: {1}.",
+		"invalid_slack_token":    "{0} {1}",
+		invalidChannelNameTag:    "The channel name '{0}' seems to be invalid. See the documentation to learn more: {1}.",
+		invalidActionStepTag:     "{0} {1}",
+		invalidActionTemplateTag: "{0} has invalid template: {1}",
+		invalidRBACMappingTag:    "{0} {1}",
+		invalidCommandPatternTag: "{0} {1}",
 	})
 }
 
@@ -139,6 +161,8 @@ func registerBindingsValidator(validate *validator.Validate, trans ut.Translator
 	validate.RegisterStructValidation(botBindingsStructValidator, BotBindings{})
 	validate.RegisterStructValidation(actionBindingsStructValidator, ActionBindings{})
 	validate.RegisterStructValidation(sinkBindingsStructValidator, SinkBindings{})
+	validate.RegisterStructValidation(routingRuleStructValidator, RoutingRule{})
+	validate.RegisterStructValidation(scheduledCommandStructValidator, ScheduledCommand{})
 
 	return registerTranslation(validate, trans, map[string]string{
 		invalidBindingTag:           "'{0}' binding not defined in {1}",
@@ -147,6 +171,11 @@ func registerBindingsValidator(validate *validator.Validate, trans ut.Translator
 		invalidPluginDefinitionTag:  "{0}{1}",
 		invalidPluginRBACTag:        "Binding is referencing plugins of same kind with different RBAC. '{0}' and '{1}' bindings must be identical when used together.",
 		invalidActionRBACTag:        "Plugin {0} has 'ChannelName' RBAC policy. This is not supported for actions. See https://docs.botkube.io/configuration/action#rbac",
+		invalidActionUserRBACTag:    "Plugin {0} has '{1}' RBAC policy without a fallback. Actions are not executed by chat users, so the fallback is required. See https://docs.botkube.io/configuration/action#rbac",
+		invalidScheduledUserRBACTag: "Plugin {0} has '{1}' RBAC policy without a fallback. Scheduled commands are not executed by chat users, so the fallback is required.",
+		invalidRoutingFieldTag:      "Field '{0}' has invalid regular expression: {1}",
+		invalidScheduleTag:          "{0} has invalid cron expression: {1}",
+		invalidTimezoneTag:          "{0} has invalid time zone: {1}",
 	})
 }
 
@@ -270,6 +299,16 @@ func sourceStructValidator(sl validator.StructLevel) {
 	}
 
 	validatePlugins(sl, sources.Plugins)
+
+	conf, ok := sl.Top().Interface().(Config)
+	if !ok {
+		return
+	}
+	for _, name := range sources.Transformers {
+		if _, found := conf.Transformers[name]; !found {
+			sl.ReportError(sources.Transformers, name, name, invalidBindingTag, "Config.Transformers")
+		}
+	}
 }
 
 func executorStructValidator(sl validator.StructLevel) {
@@ -279,6 +318,124 @@ func executorStructValidator(sl validator.StructLevel) {
 	}
 
 	validatePlugins(sl, executor.Plugins)
+	validateRBACMappings(sl, executor.Plugins)
+}
+
+// validateRBACMappings checks that templates of chat user mappings can be parsed.
+func validateRBACMappings(sl validator.StructLevel, plugins Plugins) {
+	for pluginKey, plugin := range plugins {
+		rbac := plugin.Context.RBAC
+		if !plugin.Enabled || rbac == nil {
+			continue
+		}
+
+		if rbac.User.Type.IsChatUserBased() {
+			validateRBACMappingTemplate(sl, pluginKey, "User", rbac.User.Mapping.Template)
+		}
+		if rbac.Group.Type.IsChatUserBased() {
+			validateRBACMappingTemplate(sl, pluginKey, "Group", rbac.Group.Mapping.Template)
+		}
+	}
+}
+
+func validateRBACMappingTemplate(sl validator.StructLevel, pluginKey, subject, tpl string) {
+	if tpl == "" {
+		return
+	}
+	if _, err := template.New("rbac-subject").Funcs(sprig.FuncMap()).Parse(tpl); err != nil {
+		field := fmt.Sprintf("%s.Context.RBAC.%s.Mapping.Template", pluginKey, subject)
+		sl.ReportError(tpl, field, field, invalidRBACMappingTag, fmt.Sprintf("has invalid template: %s", err))
+	}
+}
+
+func transformerStructValidator(sl validator.StructLevel) {
+	transformer, ok := sl.Current().Interface().(Transformers)
+	if !ok {
+		return
+	}
+
+	validatePlugins(sl, transformer.Plugins)
+}
+
+func actionStructValidator(sl validator.StructLevel) {
+	action, ok := sl.Current().Interface().(Action)
+	if !ok {
+		return
+	}
+
+	switch {
+	case action.Command != "" && len(action.Steps) > 0:
+		sl.ReportError(action.Steps, "Steps", "Steps", invalidActionStepTag, "cannot be used together with Command")
+	case action.Enabled && action.Command == "" && len(action.Steps) == 0:
+		sl.ReportError(action.Command, "Command", "Command", "required", "")
+	}
+
+	validateActionTemplate(sl, "Command", action.Command)
+	validateActionTemplate(sl, "Condition", action.Condition)
+	validateActionTemplate(sl, "Cooldown.Key", action.Cooldown.Key)
+
+	names := map[string]struct{}{}
+	for i, step := range action.Steps {
+		validateActionTemplate(sl, fmt.Sprintf("Steps[%d].Command", i), step.Command)
+		if _, found := names[step.Name]; found && step.Name != "" {
+			field := fmt.Sprintf("Steps[%d].Name", i)
+			sl.ReportError(step.Name, field, field, invalidActionStepTag, fmt.Sprintf("%q is already used by another step", step.Name))
+		}
+		names[step.Name] = struct{}{}
+
+		for key, extract := range step.Extract {
+			field := fmt.Sprintf("Steps[%d].Extract[%s]", i, key)
+			switch {
+			case (extract.Regex == "") == (extract.JSONPath == ""):
+				sl.ReportError(extract, field, field, invalidActionStepTag, "must define either regex or jsonPath")
+			case extract.Regex != "":
+				if _, err := regexp.Compile(extract.Regex); err != nil {
+					sl.ReportError(extract.Regex, field, field, invalidActionStepTag, fmt.Sprintf("has invalid regular expression: %s", err))
+				}
+			}
+		}
+	}
+}
+
+// validateActionTemplate checks that a given action template can be parsed, so errors are reported on config load instead of on each event.
+func validateActionTemplate(sl validator.StructLevel, field, tpl string) {
+	if tpl == "" {
+		return
+	}
+	if _, err := template.New("action").Funcs(sprig.FuncMap()).Parse(tpl); err != nil {
+		sl.ReportError(tpl, field, field, invalidActionTemplateTag, err.Error())
+	}
+}
+
+func commandPatternStructValidator(sl validator.StructLevel) {
+	pattern, ok := sl.Current().Interface().(CommandPattern)
+	if !ok {
+		return
+	}
+
+	switch {
+	case (pattern.Glob == "") == (pattern.Regex == ""):
+		sl.ReportError(pattern, "Pattern", "Pattern", invalidCommandPatternTag, "must define either glob or regex")
+	case pattern.Regex != "":
+		if _, err := regexp.Compile(pattern.Regex); err != nil {
+			sl.ReportError(pattern.Regex, "Regex", "Regex", invalidCommandPatternTag, fmt.Sprintf("has invalid regular expression: %s", err))
+		}
+	default:
+		if _, err := glob.Compile(pattern.Glob); err != nil {
+			sl.ReportError(pattern.Glob, "Glob", "Glob", invalidCommandPatternTag, fmt.Sprintf("has invalid pattern: %s", err))
+		}
+	}
+}
+
+func actionLimitStructValidator(sl validator.StructLevel) {
+	limit, ok := sl.Current().Interface().(ActionLimit)
+	if !ok || limit.MaxExecutions == 0 {
+		return
+	}
+
+	if limit.Window <= 0 {
+		sl.ReportError(limit.Window, "Window", "Window", "required", "")
+	}
 }
 
 func botBindingsStructValidator(sl validator.StructLevel) {
@@ -359,6 +516,56 @@ func sinkBindingsStructValidator(sl validator.StructLevel) {
 	validateSourceBindings(sl, conf.Sources, bindings.Sources)
 }
 
+func routingRuleStructValidator(sl validator.StructLevel) {
+	rule, ok := sl.Current().Interface().(RoutingRule)
+	if !ok {
+		return
+	}
+	conf, ok := sl.Top().Interface().(Config)
+	if !ok {
+		return
+	}
+
+	for _, source := range rule.Match.Sources {
+		if _, ok := conf.Sources[source]; !ok {
+			sl.ReportError(rule.Match.Sources, source, source, invalidBindingTag, "Config.Sources")
+		}
+	}
+
+	for path, expr := range rule.Match.Fields {
+		if _, err := regexp.Compile(expr); err != nil {
+			sl.ReportError(rule.Match.Fields, path, path, invalidRoutingFieldTag, err.Error())
+		}
+	}
+}
+
+func scheduledCommandStructValidator(sl validator.StructLevel) {
+	cmd, ok := sl.Current().Interface().(ScheduledCommand)
+	if !ok || !cmd.Enabled {
+		return
+	}
+	conf, ok := sl.Top().Interface().(Config)
+	if !ok {
+		return
+	}
+
+	var loc *time.Location
+	if cmd.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(cmd.Timezone)
+		if err != nil {
+			sl.ReportError(cmd.Timezone, "Timezone", "Timezone", invalidTimezoneTag, err.Error())
+		}
+	}
+	if cmd.Schedule != "" {
+		if _, err := cronx.ParseInLocation(cmd.Schedule, loc); err != nil {
+			sl.ReportError(cmd.Schedule, "Schedule", "Schedule", invalidScheduleTag, err.Error())
+		}
+	}
+	validateExecutorBindings(sl, conf.Executors, cmd.ExecutorBindings)
+	validateChatUserRBACFallback(sl, conf.Executors, cmd.ExecutorBindings, invalidScheduledUserRBACTag)
+}
+
 func validateSourceBindings(sl validator.StructLevel, sources map[string]Sources, bindings []string) {
 	var enabledPluginsViaBindings []string
 	for _, source := range bindings {
@@ -431,11 +638,33 @@ func validateActionExecutors(sl validator.StructLevel, executors map[string]Exec
 			if plugin.Context.RBAC == nil {
 				continue
 			}
-			if plugin.Context.RBAC.Group.Type == ChannelNamePolicySubjectType {
+			rbac := plugin.Context.RBAC
+			if rbac.Group.Type == ChannelNamePolicySubjectType {
 				sl.ReportError(bindings, pluginKey, executor, invalidActionRBACTag, "")
 			}
 		}
 	}
+	validateChatUserRBACFallback(sl, executors, bindings, invalidActionUserRBACTag)
+}
+
+// validateChatUserRBACFallback reports chat user based RBAC policies without a fallback.
+// It is used for commands which are executed by automation, so there is no chat user to map.
+func validateChatUserRBACFallback(sl validator.StructLevel, executors map[string]Executors, bindings []string, tag string) {
+	for _, executor := range bindings {
+		execConf := executors[executor]
+		for pluginKey, plugin := range execConf.Plugins {
+			if !plugin.Enabled || plugin.Context.RBAC == nil {
+				continue
+			}
+			rbac := plugin.Context.RBAC
+			if rbac.User.Type.IsChatUserBased() && rbac.User.Mapping.Fallback == "" {
+				sl.ReportError(bindings, pluginKey, executor, tag, string(rbac.User.Type))
+			}
+			if rbac.Group.Type.IsChatUserBased() && len(rbac.Group.Mapping.Fallback) == 0 {
+				sl.ReportError(bindings, pluginKey, executor, tag, string(rbac.Group.Type))
+			}
+		}
+	}
 }
 
 func validateExecutorBindings(sl validator.StructLevel, executors map[string]Executors, bindings []string) {
@@ -0,0 +1,255 @@
+package cronx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// embeds the time zone database, so that schedules can be evaluated in any time zone also on minimal images
+	_ "time/tzdata"
+)
+
+// cronTZPrefix is the prefix of the cron expression used to set its time zone.
+const cronTZPrefix = "CRON_TZ="
+
+// maxSearchPeriod limits how far in the future the next activation time is searched for, e.g. for `0 0 30 2 *`.
+const maxSearchPeriod = 5 * 366 * 24 * time.Hour
+
+// Schedule returns activation times.
+type Schedule interface {
+	// Next returns the first activation time after a given time. Zero time is returned if there is no activation time.
+	Next(time.Time) time.Time
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type field struct {
+	name   string
+	min    int
+	max    int
+	values map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, values: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = field{name: "day of week", min: 0, max: 7, values: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Parse parses a standard cron expression with 5 fields (minute, hour, day of month, month, day of week).
+// Additionally, the @yearly, @monthly, @weekly, @daily, @hourly descriptors and `@every <duration>` are supported.
+// The expression can be prefixed with `CRON_TZ=<time zone>`, e.g. `CRON_TZ=Europe/Warsaw 0 8 * * *`. Otherwise, it is evaluated in the time zone of the time passed to Schedule.Next.
+func Parse(expr string) (Schedule, error) {
+	return ParseInLocation(expr, nil)
+}
+
+// ParseInLocation parses a cron expression in the same way as Parse, but evaluates it in a given location.
+// The `CRON_TZ=<time zone>` prefix of the expression takes precedence over the given location.
+func ParseInLocation(expr string, loc *time.Location) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if rawTZ, rest, found := strings.Cut(expr, " "); found && strings.HasPrefix(rawTZ, cronTZPrefix) {
+		var err error
+		loc, err = time.LoadLocation(strings.TrimPrefix(rawTZ, cronTZPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("while loading time zone: %w", err)
+		}
+		expr = strings.TrimSpace(rest)
+	}
+	if rawDuration, found := strings.CutPrefix(expr, "@every "); found {
+		interval, err := time.ParseDuration(strings.TrimSpace(rawDuration))
+		if err != nil {
+			return nil, fmt.Errorf("while parsing interval: %w", err)
+		}
+		if interval < time.Second {
+			return nil, fmt.Errorf("interval must be at least 1s, got %s", interval)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+	if desc, found := descriptors[strings.ToLower(expr)]; found {
+		expr = desc
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var (
+		out = cronSchedule{loc: loc}
+		err error
+	)
+	for _, item := range []struct {
+		dst *uint64
+		raw string
+		def field
+	}{
+		{&out.minute, fields[0], minuteField},
+		{&out.hour, fields[1], hourField},
+		{&out.dom, fields[2], domField},
+		{&out.month, fields[3], monthField},
+		{&out.dow, fields[4], dowField},
+	} {
+		*item.dst, err = parseField(item.raw, item.def)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Sunday can be written as both 0 and 7
+	if out.dow&(1<<7) != 0 {
+		out.dow |= 1
+	}
+	out.domStar = fields[2] == "*"
+	out.dowStar = fields[4] == "*"
+	return out, nil
+}
+
+func parseField(raw string, def field) (uint64, error) {
+	var out uint64
+	for _, part := range strings.Split(raw, ",") {
+		bits, err := parseFieldPart(part, def)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", def.name, raw, err)
+		}
+		out |= bits
+	}
+	return out, nil
+}
+
+func parseFieldPart(part string, def field) (uint64, error) {
+	rangePart, rawStep, hasStep := strings.Cut(part, "/")
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(rawStep)
+		if err != nil || step < 1 {
+			return 0, fmt.Errorf("invalid step %q", rawStep)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangePart == "*":
+		start, end = def.min, def.max
+	case strings.Contains(rangePart, "-"):
+		rawStart, rawEnd, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(rawStart, def); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(rawEnd, def); err != nil {
+			return 0, err
+		}
+	default:
+		value, err := parseValue(rangePart, def)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		if hasStep {
+			end = def.max
+		}
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("range start %d is greater than end %d", start, end)
+	}
+
+	var out uint64
+	for i := start; i <= end; i += step {
+		out |= 1 << uint(i)
+	}
+	return out, nil
+}
+
+func parseValue(raw string, def field) (int, error) {
+	if value, found := def.values[strings.ToLower(raw)]; found {
+		return value, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", raw)
+	}
+	if value < def.min || value > def.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", value, def.min, def.max)
+	}
+	return value, nil
+}
+
+// cronSchedule holds allowed values of each field as bit sets.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+	// loc is the location in which the schedule is evaluated. If nil, the location of a given time is used.
+	loc *time.Location
+}
+
+// Next returns the first activation time after a given time.
+func (s cronSchedule) Next(t time.Time) time.Time {
+	if s.loc != nil {
+		t = t.In(s.loc)
+	}
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearchPeriod)
+
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows the cron semantics: if both day of month and day of week are restricted, any of them must match.
+func (s cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+type everySchedule struct {
+	interval time.Duration
+}
+
+// Next returns the time after a given interval.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
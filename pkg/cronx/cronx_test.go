@@ -0,0 +1,118 @@
+package cronx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// given
+	// Thursday
+	now := time.Date(2023, 10, 5, 12, 34, 56, 0, time.UTC)
+
+	tests := []struct {
+		expr    string
+		expNext time.Time
+	}{
+		{expr: "* * * * *", expNext: time.Date(2023, 10, 5, 12, 35, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", expNext: time.Date(2023, 10, 5, 12, 45, 0, 0, time.UTC)},
+		{expr: "0 8 * * *", expNext: time.Date(2023, 10, 6, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * * MON-FRI", expNext: time.Date(2023, 10, 6, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * * sat,7", expNext: time.Date(2023, 10, 7, 8, 0, 0, 0, time.UTC)},
+		{expr: "30 9 1 * *", expNext: time.Date(2023, 11, 1, 9, 30, 0, 0, time.UTC)},
+		{expr: "0 0 1 jan *", expNext: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 13 * 5", expNext: time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", expNext: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", expNext: time.Date(2023, 10, 5, 13, 0, 0, 0, time.UTC)},
+		{expr: "@daily", expNext: time.Date(2023, 10, 6, 0, 0, 0, 0, time.UTC)},
+		{expr: "@every 90m", expNext: time.Date(2023, 10, 5, 14, 4, 56, 0, time.UTC)},
+		{expr: "0 0 30 2 *", expNext: time.Time{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			// when
+			schedule, err := Parse(tc.expr)
+			require.NoError(t, err)
+
+			// then
+			assert.Equal(t, tc.expNext, schedule.Next(now))
+		})
+	}
+}
+
+func TestScheduleNextInLocation(t *testing.T) {
+	// given
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+	// 22:34 UTC is already the next day in Warsaw
+	now := time.Date(2023, 10, 5, 22, 34, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expr    string
+		loc     *time.Location
+		expNext time.Time
+	}{
+		{
+			name:    "Location",
+			expr:    "0 8 * * *",
+			loc:     warsaw,
+			expNext: time.Date(2023, 10, 6, 8, 0, 0, 0, warsaw),
+		},
+		{
+			name:    "CRON_TZ prefix",
+			expr:    "CRON_TZ=Europe/Warsaw 0 8 * * *",
+			expNext: time.Date(2023, 10, 6, 8, 0, 0, 0, warsaw),
+		},
+		{
+			name:    "CRON_TZ prefix takes precedence",
+			expr:    "CRON_TZ=UTC 0 8 * * *",
+			loc:     warsaw,
+			expNext: time.Date(2023, 10, 6, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Day of week in location",
+			expr:    "0 8 * * FRI",
+			loc:     warsaw,
+			expNext: time.Date(2023, 10, 6, 8, 0, 0, 0, warsaw),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			schedule, err := ParseInLocation(tc.expr, tc.loc)
+			require.NoError(t, err)
+
+			// then
+			next := schedule.Next(now)
+			assert.True(t, tc.expNext.Equal(next), "expected %s, got %s", tc.expNext, next)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		expErr string
+	}{
+		{expr: "* * * *", expErr: "expected 5 fields, got 4"},
+		{expr: "60 * * * *", expErr: `invalid minute "60": value 60 out of range [0, 59]`},
+		{expr: "* 5-1 * * *", expErr: `invalid hour "5-1": range start 5 is greater than end 1`},
+		{expr: "*/0 * * * *", expErr: `invalid minute "*/0": invalid step "0"`},
+		{expr: "* * * foo *", expErr: `invalid month "foo": invalid value "foo"`},
+		{expr: "@every 10ms", expErr: "interval must be at least 1s, got 10ms"},
+		{expr: "CRON_TZ=Mars/Olympus_Mons 0 8 * * *", expErr: "while loading time zone: unknown time zone Mars/Olympus_Mons"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			// when
+			_, err := Parse(tc.expr)
+
+			// then
+			assert.EqualError(t, err, tc.expErr)
+		})
+	}
+}
@@ -4,20 +4,38 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
 
+	"github.com/kubeshop/botkube/internal/approval"
+	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/history"
+	remoteapi "github.com/kubeshop/botkube/internal/remote"
+	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/maputil"
+	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
 const (
 	actionNameMissing = "You forgot to pass action name. Please pass one of the following valid actions:\n\n%s"
 	actionEnabled     = "I have enabled '%s' action on '%s' cluster."
 	actionDisabled    = "Done. I won't run '%s' action on '%s' cluster."
+
+	actionApprovalIDMissing = "You forgot to pass the approval request ID."
+	actionApprovalNotFound  = "Approval request '%s' not found on '%s' cluster. It was already decided or it expired."
+	actionApprovalForbidden = "Sorry, you are not allowed to decide on '%s' action."
+	actionApprovalRejected  = "Done. I won't run '%s' action on '%s' cluster."
+
+	actionNotFound         = "Action '%s' not found. Use 'list actions' to see all configured actions."
+	actionTestEventMissing = "You forgot to select an event. Use '--with-last-event' to render the action against the most recent event from its sources, or '--event <ID>' to select one of the events listed by 'events list'."
+	actionTestNoEvents     = "There are no recent events from sources bound to '%s' action."
 )
 
 var (
@@ -31,19 +49,50 @@ type ActionsStorage interface {
 	PersistActionEnabled(ctx context.Context, name string, enabled bool) error
 }
 
+// ActionStats provides execution statistics of automated actions.
+type ActionStats interface {
+	GetStats(name string) (executions int, lastExecutedAt time.Time, limitReached bool)
+	Reset(name string)
+}
+
+// ActionApprovals provides automated actions waiting for approval.
+type ActionApprovals interface {
+	Get(id string) (approval.Request, bool)
+	Remove(id string) bool
+}
+
+// ActionPreviewer renders automated actions for given events without executing them.
+type ActionPreviewer interface {
+	RenderMessage(name string, event any, sourceName string) (interactive.CoreMessage, error)
+}
+
 // ActionExecutor executes all commands that are related to actions.
 type ActionExecutor struct {
-	log        logrus.FieldLogger
-	cfgManager ActionsStorage
-	actions    map[string]config.Action
+	log           logrus.FieldLogger
+	cfgManager    ActionsStorage
+	actions       map[string]config.Action
+	stats         ActionStats
+	approvals     ActionApprovals
+	history       EventHistory
+	previewer     ActionPreviewer
+	auditReporter audit.AuditReporter
 }
 
 // NewActionExecutor returns a new ActionExecutor instance.
-func NewActionExecutor(log logrus.FieldLogger, cfgManager ActionsStorage, cfg config.Config) *ActionExecutor {
+func NewActionExecutor(log logrus.FieldLogger, cfgManager ActionsStorage, cfg config.Config, stats ActionStats, approvals ActionApprovals, eventHistory EventHistory, previewer ActionPreviewer, auditReporter audit.AuditReporter) *ActionExecutor {
+	if eventHistory == nil {
+		// history is disabled, e.g. when executors are used outside the agent
+		eventHistory = history.NewStore(0)
+	}
 	return &ActionExecutor{
-		log:        log,
-		cfgManager: cfgManager,
-		actions:    cfg.Actions,
+		log:           log,
+		cfgManager:    cfgManager,
+		actions:       cfg.Actions,
+		stats:         stats,
+		approvals:     approvals,
+		history:       eventHistory,
+		previewer:     previewer,
+		auditReporter: auditReporter,
 	}
 }
 
@@ -53,6 +102,9 @@ func (e *ActionExecutor) Commands() map[command.Verb]CommandFn {
 		command.ListVerb:    e.List,
 		command.EnableVerb:  e.Enable,
 		command.DisableVerb: e.Disable,
+		command.ApproveVerb: e.Approve,
+		command.RejectVerb:  e.Reject,
+		command.TestVerb:    e.Test,
 	}
 }
 
@@ -79,6 +131,10 @@ func (e *ActionExecutor) Enable(ctx context.Context, cmdCtx CommandContext) (int
 	if err := e.cfgManager.PersistActionEnabled(ctx, actionName, enabled); err != nil {
 		return interactive.CoreMessage{}, fmt.Errorf("while setting action %q to %t: %w", actionName, enabled, err)
 	}
+	if e.stats != nil {
+		// the action might have been disabled because it exceeded its execution limit
+		e.stats.Reset(actionName)
+	}
 	return respond(fmt.Sprintf(actionEnabled, actionName, cmdCtx.ClusterName), cmdCtx), nil
 }
 
@@ -97,15 +153,171 @@ func (e *ActionExecutor) Disable(ctx context.Context, cmdCtx CommandContext) (in
 	return respond(fmt.Sprintf(actionDisabled, actionName, cmdCtx.ClusterName), cmdCtx), nil
 }
 
+// Approve executes an automated action waiting for approval.
+func (e *ActionExecutor) Approve(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	return e.decide(ctx, cmdCtx, true)
+}
+
+// Reject discards an automated action waiting for approval.
+func (e *ActionExecutor) Reject(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	return e.decide(ctx, cmdCtx, false)
+}
+
+// Test renders a given action against a recent event without executing it.
+func (e *ActionExecutor) Test(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 3 || strings.HasPrefix(cmdCtx.Args[2], "-") {
+		return respond(fmt.Sprintf(actionNameMissing, e.ActionsTabularOutput()), cmdCtx), nil
+	}
+	actionName := cmdCtx.Args[2]
+
+	var (
+		withLastEvent bool
+		eventID       string
+	)
+	f := pflag.NewFlagSet("test-action", pflag.ContinueOnError)
+	f.BoolVar(&withLastEvent, "with-last-event", false, "Use the most recent event from the action sources")
+	f.StringVar(&eventID, "event", "", "ID of the event from the history")
+	if err := f.Parse(cmdCtx.Args[3:]); err != nil {
+		return interactive.CoreMessage{}, NewExecutionCommandError("while parsing flags: %s", err.Error())
+	}
+
+	action, found := e.actions[actionName]
+	if !found || e.previewer == nil {
+		return respond(fmt.Sprintf(actionNotFound, actionName), cmdCtx), nil
+	}
+
+	var event history.Event
+	switch {
+	case eventID != "":
+		event, found = e.history.Get(eventID)
+		if !found {
+			return respond(fmt.Sprintf(eventNotFound, eventID), cmdCtx), nil
+		}
+	case withLastEvent:
+		event, found = e.lastEvent(action.Bindings.Sources)
+		if !found {
+			return respond(fmt.Sprintf(actionTestNoEvents, actionName), cmdCtx), nil
+		}
+	default:
+		return respond(actionTestEventMissing, cmdCtx), nil
+	}
+
+	e.log.WithField("eventID", event.ID).Debugf("Testing action %q...", actionName)
+	msg, err := e.previewer.RenderMessage(actionName, event.RawObject, event.SourceName)
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while rendering action %q: %w", actionName, err)
+	}
+	msg.Description = fmt.Sprintf("Rendered for event %s from %q source, created at %s.", event.ID, event.SourceName, event.CreatedAt.UTC().Format(time.RFC3339))
+	return msg, nil
+}
+
+// lastEvent returns the most recent event from given sources.
+func (e *ActionExecutor) lastEvent(sources []string) (history.Event, bool) {
+	events := e.history.List(history.ListFilter{})
+	for i := len(events) - 1; i >= 0; i-- {
+		if sliceutil.Intersect(sources, []string{events[i].SourceName}) {
+			return events[i], true
+		}
+	}
+	return history.Event{}, false
+}
+
+func (e *ActionExecutor) decide(ctx context.Context, cmdCtx CommandContext, approved bool) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 3 {
+		return respond(actionApprovalIDMissing, cmdCtx), nil
+	}
+	id := cmdCtx.Args[2]
+
+	if e.approvals == nil {
+		return respond(fmt.Sprintf(actionApprovalNotFound, id, cmdCtx.ClusterName), cmdCtx), nil
+	}
+	req, found := e.approvals.Get(id)
+	if !found {
+		return respond(fmt.Sprintf(actionApprovalNotFound, id, cmdCtx.ClusterName), cmdCtx), nil
+	}
+	if !req.IsApprover(cmdCtx.User.Mention) {
+		e.log.Infof("User %q is not allowed to decide on action %q", cmdCtx.User.DisplayName, req.ActionName)
+		return respond(fmt.Sprintf(actionApprovalForbidden, req.DisplayName), cmdCtx), nil
+	}
+	if !e.approvals.Remove(id) {
+		return respond(fmt.Sprintf(actionApprovalNotFound, id, cmdCtx.ClusterName), cmdCtx), nil
+	}
+
+	decision := "rejected"
+	if approved {
+		decision = "approved"
+	}
+	e.log.WithField("approvalID", id).Infof("Action %q %s by %q", req.ActionName, decision, cmdCtx.User.DisplayName)
+	if err := e.reportApprovalDecision(ctx, cmdCtx, req, decision); err != nil {
+		e.log.Errorf("while reporting approval decision for action %q: %s", req.ActionName, err.Error())
+	}
+
+	if !approved {
+		return respond(fmt.Sprintf(actionApprovalRejected, req.DisplayName, cmdCtx.ClusterName), cmdCtx), nil
+	}
+	return req.Execute(ctx), nil
+}
+
+func (e *ActionExecutor) reportApprovalDecision(ctx context.Context, cmdCtx CommandContext, req approval.Request, decision string) error {
+	if e.auditReporter == nil {
+		return nil
+	}
+
+	channelName := cmdCtx.Conversation.ID
+	if cmdCtx.Conversation.DisplayName != "" {
+		channelName = cmdCtx.Conversation.DisplayName
+	}
+
+	auditCtx := map[string]interface{}{}
+	for key, val := range cmdCtx.AuditContext {
+		auditCtx[key] = val
+	}
+	auditCtx["actionApproval"] = map[string]interface{}{
+		"id":       req.ID,
+		"action":   req.ActionName,
+		"decision": decision,
+	}
+
+	return e.auditReporter.ReportExecutorAuditEvent(ctx, audit.ExecutorAuditEvent{
+		PlatformUser:            cmdCtx.User.DisplayName,
+		CreatedAt:               time.Now().Format(time.RFC3339),
+		Channel:                 channelName,
+		Command:                 strings.TrimSpace(strings.TrimPrefix(req.Command, api.MessageBotNamePlaceholder)),
+		BotPlatform:             remoteapi.NewBotPlatform(cmdCtx.Platform.String()),
+		AdditionalCreateContext: auditCtx,
+	})
+}
+
 // ActionsTabularOutput sorts actions by key and returns a printable table
 func (e *ActionExecutor) ActionsTabularOutput() string {
 	keys := maputil.SortKeys(e.actions)
 
 	buf := new(bytes.Buffer)
 	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
-	fmt.Fprintf(w, "ACTION\tENABLED \tDISPLAY NAME")
+	fmt.Fprintf(w, "ACTION\tENABLED \tDISPLAY NAME\tEXECUTIONS\tSTATUS\tLAST_EXECUTION")
 	for _, name := range keys {
-		fmt.Fprintf(w, "\n%s\t%v \t%s", name, e.actions[name].Enabled, e.actions[name].DisplayName)
+		var (
+			executions     int
+			lastExecutedAt time.Time
+			limitReached   bool
+		)
+		if e.stats != nil {
+			executions, lastExecutedAt, limitReached = e.stats.GetStats(name)
+		}
+
+		status := "Active"
+		switch {
+		case limitReached:
+			status = "LimitReached"
+		case !e.actions[name].Enabled:
+			status = "Inactive"
+		}
+
+		var lastExecution string
+		if !lastExecutedAt.IsZero() {
+			lastExecution = lastExecutedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "\n%s\t%v \t%s\t%d\t%s\t%s", name, e.actions[name].Enabled, e.actions[name].DisplayName, executions, status, lastExecution)
 	}
 	w.Flush()
 	return buf.String()
@@ -0,0 +1,291 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/approval"
+	"github.com/kubeshop/botkube/internal/audit"
+	"github.com/kubeshop/botkube/internal/history"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestActionExecutorList(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Actions: config.Actions{
+			"describe-created-resource": {Enabled: false, DisplayName: "Describe created resource"},
+			"restart-on-oom":            {Enabled: true, DisplayName: "Restart on OOM"},
+			"show-logs-on-error":        {Enabled: true, DisplayName: "Show logs on error"},
+		},
+	}
+	stats := fakeActionStats{
+		"restart-on-oom":     {executions: 10, lastExecutedAt: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), limitReached: true},
+		"show-logs-on-error": {executions: 2, lastExecutedAt: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+	}
+	e := NewActionExecutor(loggerx.NewNoop(), nil, cfg, stats, nil, nil, nil, nil)
+
+	// when
+	msg, err := e.List(context.Background(), CommandContext{ExecutorFilter: newExecutorTextFilter("")})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		ACTION                    ENABLED  DISPLAY NAME              EXECUTIONS STATUS       LAST_EXECUTION
+		describe-created-resource false    Describe created resource 0          Inactive     
+		restart-on-oom            true     Restart on OOM            10         LimitReached 2024-01-02T15:04:05Z
+		show-logs-on-error        true     Show logs on error        2          Active       2024-01-02T10:00:00Z`), msg.BaseBody.CodeBlock)
+}
+
+func TestActionExecutorEnableResetsLimit(t *testing.T) {
+	// given
+	stats := fakeActionStats{
+		"restart-on-oom": {executions: 10, limitReached: true},
+	}
+	e := NewActionExecutor(loggerx.NewNoop(), &fakeCfgPersistenceManager{}, config.Config{}, stats, nil, nil, nil, nil)
+
+	// when
+	_, err := e.Enable(context.Background(), CommandContext{
+		Args:           []string{"enable", "action", "restart-on-oom"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	})
+
+	// then
+	require.NoError(t, err)
+	_, _, limitReached := stats.GetStats("restart-on-oom")
+	assert.False(t, limitReached)
+}
+
+func TestActionExecutorApproval(t *testing.T) {
+	const clusterName = "prod"
+	fixRequest := func(executed *bool) approval.Request {
+		return approval.Request{
+			ActionName:  "scale-down",
+			DisplayName: "Scale down",
+			Command:     "{{BotName}} kubectl scale deploy api --replicas=0",
+			Approvers:   []string{"U1"},
+			ExpiresAt:   time.Now().Add(time.Hour),
+			Execute: func(context.Context) interactive.CoreMessage {
+				*executed = true
+				return interactive.CoreMessage{Header: "executed"}
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		user        string
+		expExecuted bool
+		expPending  bool
+		expOutput   string
+		expDecision string
+	}{
+		{
+			name:        "Approve",
+			args:        []string{"approve", "action"},
+			user:        "<@U1>",
+			expExecuted: true,
+			expDecision: "approved",
+		},
+		{
+			name:        "Reject",
+			args:        []string{"reject", "action"},
+			user:        "<@U1>",
+			expOutput:   "Done. I won't run 'Scale down' action on 'prod' cluster.",
+			expDecision: "rejected",
+		},
+		{
+			name:       "User not allowed",
+			args:       []string{"approve", "action"},
+			user:       "<@U2>",
+			expPending: true,
+			expOutput:  "Sorry, you are not allowed to decide on 'Scale down' action.",
+		},
+		{
+			name:       "Unknown request",
+			args:       []string{"approve", "action", "unknown"},
+			user:       "<@U1>",
+			expPending: true,
+			expOutput:  "Approval request 'unknown' not found on 'prod' cluster. It was already decided or it expired.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			var executed bool
+			approvals := approval.NewStore()
+			id := approvals.Add(fixRequest(&executed))
+			args := tc.args
+			if len(args) == 2 {
+				args = append(args, id)
+			}
+			auditReporter := &fakeAuditReporter{}
+			e := NewActionExecutor(loggerx.NewNoop(), nil, config.Config{}, nil, approvals, nil, nil, auditReporter)
+
+			// when
+			msg, err := e.decide(context.Background(), CommandContext{
+				Args:           args,
+				ClusterName:    clusterName,
+				User:           UserInput{Mention: tc.user, DisplayName: "alice"},
+				Conversation:   Conversation{ID: "C1", DisplayName: "ops"},
+				ExecutorFilter: newExecutorTextFilter(""),
+			}, args[0] == "approve")
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expExecuted, executed)
+			if tc.expExecuted {
+				assert.Equal(t, "executed", msg.Header)
+			} else {
+				assert.Equal(t, tc.expOutput, msg.BaseBody.CodeBlock)
+			}
+
+			_, pending := approvals.Get(id)
+			assert.Equal(t, tc.expPending, pending)
+
+			if tc.expDecision == "" {
+				assert.Empty(t, auditReporter.events)
+				return
+			}
+			require.Len(t, auditReporter.events, 1)
+			event := auditReporter.events[0]
+			assert.Equal(t, "alice", event.PlatformUser)
+			assert.Equal(t, "ops", event.Channel)
+			assert.Equal(t, "kubectl scale deploy api --replicas=0", event.Command)
+			assert.Equal(t, map[string]interface{}{
+				"id":       id,
+				"action":   "scale-down",
+				"decision": tc.expDecision,
+			}, event.AdditionalCreateContext["actionApproval"])
+		})
+	}
+}
+
+func TestActionExecutorTest(t *testing.T) {
+	// given
+	now := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+	store := history.NewStore(10)
+	oldID := store.Record(history.Event{SourceName: "k8s-err", RawObject: map[string]any{"Name": "api-0"}, CreatedAt: now.Add(-time.Hour)})
+	store.Record(history.Event{SourceName: "k8s-err", RawObject: map[string]any{"Name": "api-1"}, CreatedAt: now.Add(-30 * time.Minute)})
+	store.Record(history.Event{SourceName: "keptn", RawObject: map[string]any{"Name": "deploy"}, CreatedAt: now.Add(-10 * time.Minute)})
+
+	cfg := config.Config{
+		Actions: config.Actions{
+			"show-logs": {DisplayName: "Show logs", Bindings: config.ActionBindings{Sources: []string{"k8s-err"}}},
+			"notify":    {DisplayName: "Notify", Bindings: config.ActionBindings{Sources: []string{"argo"}}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		expRendered  *fakeActionPreview
+		expOutput    string
+		expEventDesc string
+	}{
+		{
+			name:         "With last event",
+			args:         []string{"test", "action", "show-logs", "--with-last-event"},
+			expRendered:  &fakeActionPreview{name: "show-logs", event: map[string]any{"Name": "api-1"}, sourceName: "k8s-err"},
+			expEventDesc: `Rendered for event 2 from "k8s-err" source, created at 2023-10-05T11:30:00Z.`,
+		},
+		{
+			name:         "With event ID",
+			args:         []string{"test", "action", "show-logs", "--event", oldID},
+			expRendered:  &fakeActionPreview{name: "show-logs", event: map[string]any{"Name": "api-0"}, sourceName: "k8s-err"},
+			expEventDesc: `Rendered for event 1 from "k8s-err" source, created at 2023-10-05T11:00:00Z.`,
+		},
+		{
+			name:      "Missing event",
+			args:      []string{"test", "action", "show-logs"},
+			expOutput: "You forgot to select an event. Use '--with-last-event' to render the action against the most recent event from its sources, or '--event <ID>' to select one of the events listed by 'events list'.",
+		},
+		{
+			name:      "No events from bound sources",
+			args:      []string{"test", "action", "notify", "--with-last-event"},
+			expOutput: "There are no recent events from sources bound to 'notify' action.",
+		},
+		{
+			name:      "Unknown action",
+			args:      []string{"test", "action", "unknown", "--with-last-event"},
+			expOutput: "Action 'unknown' not found. Use 'list actions' to see all configured actions.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			previewer := &fakeActionPreviewer{}
+			e := NewActionExecutor(loggerx.NewNoop(), nil, cfg, nil, nil, store, previewer, nil)
+
+			// when
+			msg, err := e.Test(context.Background(), CommandContext{
+				Args:           tc.args,
+				ExecutorFilter: newExecutorTextFilter(""),
+			})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expRendered, previewer.rendered)
+			if tc.expRendered != nil {
+				assert.Equal(t, "preview", msg.Header)
+				assert.Equal(t, tc.expEventDesc, msg.Description)
+				return
+			}
+			assert.Equal(t, tc.expOutput, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+type fakeActionPreview struct {
+	name       string
+	event      any
+	sourceName string
+}
+
+type fakeActionPreviewer struct {
+	rendered *fakeActionPreview
+}
+
+func (f *fakeActionPreviewer) RenderMessage(name string, event any, sourceName string) (interactive.CoreMessage, error) {
+	f.rendered = &fakeActionPreview{name: name, event: event, sourceName: sourceName}
+	return interactive.CoreMessage{Header: "preview"}, nil
+}
+
+type fakeActionStat struct {
+	executions     int
+	lastExecutedAt time.Time
+	limitReached   bool
+}
+
+type fakeActionStats map[string]fakeActionStat
+
+type fakeAuditReporter struct {
+	events []audit.ExecutorAuditEvent
+}
+
+func (f *fakeAuditReporter) ReportExecutorAuditEvent(_ context.Context, e audit.ExecutorAuditEvent) error {
+	f.events = append(f.events, e)
+	return nil
+}
+
+func (f *fakeAuditReporter) ReportSourceAuditEvent(context.Context, audit.SourceAuditEvent) error {
+	return nil
+}
+
+func (f fakeActionStats) GetStats(name string) (int, time.Time, bool) {
+	stat := f[name]
+	return stat.executions, stat.lastExecutedAt, stat.limitReached
+}
+
+func (f fakeActionStats) Reset(name string) {
+	stat := f[name]
+	stat.limitReached = false
+	f[name] = stat
+}
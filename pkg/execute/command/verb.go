@@ -4,16 +4,23 @@ package command
 type Verb string
 
 const (
-	PingVerb     Verb = "ping"
-	HelpVerb     Verb = "help"
-	VersionVerb  Verb = "version"
-	FeedbackVerb Verb = "feedback"
-	ListVerb     Verb = "list"
-	EnableVerb   Verb = "enable"
-	DisableVerb  Verb = "disable"
-	EditVerb     Verb = "edit"
-	StatusVerb   Verb = "status"
-	ShowVerb     Verb = "show"
+	PingVerb         Verb = "ping"
+	HelpVerb         Verb = "help"
+	VersionVerb      Verb = "version"
+	FeedbackVerb     Verb = "feedback"
+	ListVerb         Verb = "list"
+	EnableVerb       Verb = "enable"
+	DisableVerb      Verb = "disable"
+	EditVerb         Verb = "edit"
+	StatusVerb       Verb = "status"
+	ShowVerb         Verb = "show"
+	EventsVerb       Verb = "events"
+	DeprecationsVerb Verb = "deprecations"
+	ApproveVerb      Verb = "approve"
+	RejectVerb       Verb = "reject"
+	TestVerb         Verb = "test"
+	ConfirmVerb      Verb = "confirm"
+	CancelVerb       Verb = "cancel"
 )
 
 func AllVerbs() []Verb {
@@ -28,5 +35,12 @@ func AllVerbs() []Verb {
 		EditVerb,
 		StatusVerb,
 		ShowVerb,
+		EventsVerb,
+		DeprecationsVerb,
+		ApproveVerb,
+		RejectVerb,
+		TestVerb,
+		ConfirmVerb,
+		CancelVerb,
 	}
 }
@@ -0,0 +1,111 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+const (
+	commandPolicyDeniedMsgFmt     = "Sorry, the '%s' command can't be run in this channel, as it matches the %s deny rule. Ask your Botkube administrator if you need to run it."
+	commandPolicyNotAllowedMsgFmt = "Sorry, the '%s' command can't be run in this channel, as it doesn't match any allow rule. Ask your Botkube administrator if you need to run it."
+	commandPolicyErrorMsgFmt      = "Sorry, the '%s' command can't be run in this channel, as the channel command policy can't be evaluated. See the logs for more details."
+
+	commandPolicyAuditKey = "commandPolicy"
+)
+
+// commandPolicyDecision describes the result of evaluating a channel command policy.
+type commandPolicyDecision struct {
+	Allowed bool
+	// Rule is the matched rule. It's empty if the command doesn't match any rule.
+	Rule string
+	// Reason is the message for the user. It's empty if the command is allowed.
+	Reason string
+}
+
+// evaluateCommandPolicy checks a given command against deny rules first, and then against allow rules.
+// If allow rules are not defined, all commands which are not denied are allowed.
+// Deny rules match the command in any flag order, while allow rules match it only as typed.
+func evaluateCommandPolicy(policy config.CommandPolicy, cmd string) (commandPolicyDecision, error) {
+	for _, rule := range policy.Deny {
+		matched, err := rule.MatchesInAnyFlagOrder(cmd)
+		if err != nil {
+			return commandPolicyDecision{Reason: fmt.Sprintf(commandPolicyErrorMsgFmt, cmd)}, err
+		}
+		if matched {
+			return commandPolicyDecision{
+				Rule:   rule.String(),
+				Reason: fmt.Sprintf(commandPolicyDeniedMsgFmt, cmd, rule.String()),
+			}, nil
+		}
+	}
+
+	if len(policy.Allow) == 0 {
+		return commandPolicyDecision{Allowed: true}, nil
+	}
+
+	for _, rule := range policy.Allow {
+		matched, err := rule.Matches(cmd)
+		if err != nil {
+			return commandPolicyDecision{Reason: fmt.Sprintf(commandPolicyErrorMsgFmt, cmd)}, err
+		}
+		if matched {
+			return commandPolicyDecision{Allowed: true, Rule: rule.String()}, nil
+		}
+	}
+
+	return commandPolicyDecision{Reason: fmt.Sprintf(commandPolicyNotAllowedMsgFmt, cmd)}, nil
+}
+
+// enforceCommandPolicy evaluates the conversation command policy for a given plugin command.
+// The decision is added to the audit context. If the command is denied, it reports the audit event and returns a message for the user.
+func (e *DefaultExecutor) enforceCommandPolicy(ctx context.Context, pluginName string, cmdCtx *CommandContext) (interactive.CoreMessage, bool) {
+	policy := cmdCtx.Conversation.CommandPolicy
+	if !policy.IsDefined() {
+		return interactive.CoreMessage{}, true
+	}
+
+	cmd := removeMultipleSpaces(cmdCtx.CleanCmd)
+	decision, err := evaluateCommandPolicy(policy, cmd)
+	if err != nil {
+		e.log.Errorf("while evaluating command policy for %q: %s", cmd, err.Error())
+	}
+
+	cmdCtx.AuditContext = withCommandPolicyDecision(cmdCtx.AuditContext, decision)
+	if decision.Allowed {
+		return interactive.CoreMessage{}, true
+	}
+
+	e.log.WithFields(logrus.Fields{
+		"command": cmd,
+		"rule":    decision.Rule,
+		"user":    cmdCtx.User.DisplayName,
+	}).Info("Command denied by channel command policy")
+	e.err = errCommandDenied
+	if err := e.reportAuditEvent(ctx, pluginName, *cmdCtx); err != nil {
+		e.log.Errorf("while reporting executor audit event for denied command: %s", err.Error())
+	}
+
+	return respond(decision.Reason, *cmdCtx), false
+}
+
+func withCommandPolicyDecision(auditCtx map[string]interface{}, decision commandPolicyDecision) map[string]interface{} {
+	out := map[string]interface{}{}
+	for key, val := range auditCtx {
+		out[key] = val
+	}
+
+	result := "denied"
+	if decision.Allowed {
+		result = "allowed"
+	}
+	out[commandPolicyAuditKey] = map[string]interface{}{
+		"decision": result,
+		"rule":     decision.Rule,
+	}
+	return out
+}
@@ -0,0 +1,88 @@
+package execute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestEvaluateCommandPolicy(t *testing.T) {
+	// given
+	policy := config.CommandPolicy{
+		Allow: []config.CommandPattern{
+			{Glob: "kubectl *"},
+			{Regex: `helm (list|status) .+`},
+		},
+		Deny: []config.CommandPattern{
+			{Glob: "kubectl delete *"},
+			{Regex: `kubectl .*--all-namespaces.*`},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		policy   config.CommandPolicy
+		cmd      string
+		expected commandPolicyDecision
+	}{
+		{
+			name:     "Allowed by glob",
+			policy:   policy,
+			cmd:      "kubectl get pods -n default",
+			expected: commandPolicyDecision{Allowed: true, Rule: `glob "kubectl *"`},
+		},
+		{
+			name:     "Allowed by regex",
+			policy:   policy,
+			cmd:      "helm status botkube",
+			expected: commandPolicyDecision{Allowed: true, Rule: `regex "helm (list|status) .+"`},
+		},
+		{
+			name:   "Deny takes precedence over allow",
+			policy: policy,
+			cmd:    "kubectl delete deploy/nginx -n prod",
+			expected: commandPolicyDecision{
+				Rule:   `glob "kubectl delete *"`,
+				Reason: `Sorry, the 'kubectl delete deploy/nginx -n prod' command can't be run in this channel, as it matches the glob "kubectl delete *" deny rule. Ask your Botkube administrator if you need to run it.`,
+			},
+		},
+		{
+			name:   "Deny can't be bypassed by flags before verb",
+			policy: policy,
+			cmd:    "kubectl -n prod delete deploy/nginx",
+			expected: commandPolicyDecision{
+				Rule:   `glob "kubectl delete *"`,
+				Reason: `Sorry, the 'kubectl -n prod delete deploy/nginx' command can't be run in this channel, as it matches the glob "kubectl delete *" deny rule. Ask your Botkube administrator if you need to run it.`,
+			},
+		},
+		{
+			name:   "Regex must match the whole command",
+			policy: policy,
+			cmd:    "helm uninstall botkube",
+			expected: commandPolicyDecision{
+				Reason: "Sorry, the 'helm uninstall botkube' command can't be run in this channel, as it doesn't match any allow rule. Ask your Botkube administrator if you need to run it.",
+			},
+		},
+		{
+			name: "Only deny rules",
+			policy: config.CommandPolicy{
+				Deny: []config.CommandPattern{{Glob: "kubectl delete *"}},
+			},
+			cmd:      "helm uninstall botkube",
+			expected: commandPolicyDecision{Allowed: true},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			decision, err := evaluateCommandPolicy(tc.policy, tc.cmd)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, decision)
+		})
+	}
+}
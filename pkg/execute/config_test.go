@@ -41,6 +41,7 @@ func TestConfigExecutorShowConfig(t *testing.T) {
 						actions: {}
 						sources: {}
 						executors: {}
+						transformers: {}
 						aliases: {}
 						communications: {}
 						analytics:
@@ -79,6 +80,7 @@ func TestConfigExecutorShowConfig(t *testing.T) {
 						        enabled: false
 						        port: 0
 						        inClusterBaseURL: ""
+						        maxBodySize: 0
 						    restartPolicy:
 						        type: ""
 						        threshold: 0
@@ -0,0 +1,244 @@
+package execute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-shellwords"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kubeshop/botkube/internal/approval"
+	"github.com/kubeshop/botkube/internal/audit"
+	remoteapi "github.com/kubeshop/botkube/internal/remote"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	defaultCommandConfirmationTimeout = 5 * time.Minute
+
+	commandConfirmationIDMissing   = "You forgot to pass the confirmation request ID."
+	commandConfirmationNotFound    = "Confirmation request '%s' not found on '%s' cluster. It was already decided or it expired."
+	commandConfirmationForbidden   = "Sorry, only the user who ran the '%s' command can confirm it."
+	commandConfirmationCanceled    = "Done. I won't run the '%s' command on '%s' cluster."
+	commandConfirmationYesRequired = "The '%s' command matches the %s rule for destructive commands. To run it on '%s' cluster, repeat it with the --yes flag."
+
+	commandConfirmationAuditKey = "commandConfirmation"
+	commandConfirmationFlag     = "yes"
+)
+
+var commandFeatureName = FeatureName{
+	Name:    "command",
+	Aliases: []string{"commands", "cmd"},
+}
+
+// CommandConfirmationExecutor confirms or cancels destructive commands waiting for confirmation.
+type CommandConfirmationExecutor struct {
+	log           logrus.FieldLogger
+	confirmations *approval.Store
+	auditReporter audit.AuditReporter
+}
+
+// NewCommandConfirmationExecutor returns a new CommandConfirmationExecutor instance.
+func NewCommandConfirmationExecutor(log logrus.FieldLogger, confirmations *approval.Store, auditReporter audit.AuditReporter) *CommandConfirmationExecutor {
+	return &CommandConfirmationExecutor{
+		log:           log,
+		confirmations: confirmations,
+		auditReporter: auditReporter,
+	}
+}
+
+// Commands returns slice of commands the executor supports.
+func (e *CommandConfirmationExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.ConfirmVerb: e.Confirm,
+		command.CancelVerb:  e.Cancel,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor.
+func (e *CommandConfirmationExecutor) FeatureName() FeatureName {
+	return commandFeatureName
+}
+
+// Confirm executes a command waiting for confirmation.
+func (e *CommandConfirmationExecutor) Confirm(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	return e.decide(ctx, cmdCtx, true)
+}
+
+// Cancel discards a command waiting for confirmation.
+func (e *CommandConfirmationExecutor) Cancel(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	return e.decide(ctx, cmdCtx, false)
+}
+
+func (e *CommandConfirmationExecutor) decide(ctx context.Context, cmdCtx CommandContext, confirmed bool) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 3 {
+		return respond(commandConfirmationIDMissing, cmdCtx), nil
+	}
+	id := cmdCtx.Args[2]
+
+	req, found := e.confirmations.Get(id)
+	if !found {
+		return respond(fmt.Sprintf(commandConfirmationNotFound, id, cmdCtx.ClusterName), cmdCtx), nil
+	}
+	if !req.IsApprover(cmdCtx.User.Mention) {
+		e.log.Infof("User %q is not allowed to decide on command %q", cmdCtx.User.DisplayName, req.Command)
+		return respond(fmt.Sprintf(commandConfirmationForbidden, req.Command), cmdCtx), nil
+	}
+	if !e.confirmations.Remove(id) {
+		return respond(fmt.Sprintf(commandConfirmationNotFound, id, cmdCtx.ClusterName), cmdCtx), nil
+	}
+
+	decision := "canceled"
+	if confirmed {
+		decision = "confirmed"
+	}
+	e.log.WithField("confirmationID", id).Infof("Command %q %s by %q", req.Command, decision, cmdCtx.User.DisplayName)
+	if err := e.reportDecision(ctx, cmdCtx, req, decision); err != nil {
+		e.log.Errorf("while reporting confirmation decision for command %q: %s", req.Command, err.Error())
+	}
+
+	if !confirmed {
+		return respond(fmt.Sprintf(commandConfirmationCanceled, req.Command, cmdCtx.ClusterName), cmdCtx), nil
+	}
+	return req.Execute(ctx), nil
+}
+
+func (e *CommandConfirmationExecutor) reportDecision(ctx context.Context, cmdCtx CommandContext, req approval.Request, decision string) error {
+	if e.auditReporter == nil {
+		return nil
+	}
+
+	channelName := cmdCtx.Conversation.ID
+	if cmdCtx.Conversation.DisplayName != "" {
+		channelName = cmdCtx.Conversation.DisplayName
+	}
+
+	auditCtx := map[string]interface{}{}
+	for key, val := range cmdCtx.AuditContext {
+		auditCtx[key] = val
+	}
+	auditCtx[commandConfirmationAuditKey] = map[string]interface{}{
+		"id":       req.ID,
+		"decision": decision,
+	}
+
+	return e.auditReporter.ReportExecutorAuditEvent(ctx, audit.ExecutorAuditEvent{
+		PlatformUser:            cmdCtx.User.DisplayName,
+		CreatedAt:               time.Now().Format(time.RFC3339),
+		Channel:                 channelName,
+		Command:                 req.Command,
+		BotPlatform:             remoteapi.NewBotPlatform(cmdCtx.Platform.String()),
+		AdditionalCreateContext: auditCtx,
+	})
+}
+
+// requireConfirmation checks if a given plugin command is destructive and needs to be confirmed before running it.
+// On interactive platforms, it stores the command with a given run function and returns a message with confirmation buttons.
+// On other platforms, the command must be confirmed upfront with the --yes flag. The flag is removed from a given command context
+// only in such case, so it's passed through to all other commands.
+func (e *DefaultExecutor) requireConfirmation(cmdCtx *CommandContext, run approval.ExecuteFn) (interactive.CoreMessage, bool) {
+	cfg := e.cfg.Settings.CommandConfirmation
+	if len(cfg.Commands) == 0 || cmdCtx.Conversation.CommandOrigin == command.AutomationOrigin {
+		return interactive.CoreMessage{}, false
+	}
+
+	// the confirmation can be bound only to the immutable user mention, otherwise the --yes flag is required
+	if !cmdCtx.Platform.IsInteractive() || e.confirmations == nil || cmdCtx.User.Mention == "" {
+		return e.requireConfirmationFlag(cmdCtx, cfg.Commands)
+	}
+
+	cmd := removeMultipleSpaces(cmdCtx.CleanCmd)
+	rule, matched := matchDestructiveCommand(e.log, cfg.Commands, cmd)
+	if !matched {
+		return interactive.CoreMessage{}, false
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultCommandConfirmationTimeout
+	}
+	expiresAt := time.Now().Add(timeout)
+
+	id := e.confirmations.Add(approval.Request{
+		DisplayName: cmd,
+		Command:     cmd,
+		Approvers:   []string{cmdCtx.User.Mention},
+		ExpiresAt:   expiresAt,
+		Execute:     run,
+	})
+	e.log.WithField("confirmationID", id).Infof("Command %q is waiting for confirmation...", cmd)
+
+	btnBuilder := api.NewMessageButtonBuilder()
+	return interactive.CoreMessage{
+		Header: "Confirm destructive command",
+		Message: api.Message{
+			Sections: []api.Section{
+				{
+					Base: api.Base{
+						Description: fmt.Sprintf("The following command will be executed on the '%s' cluster once confirmed:", cmdCtx.ClusterName),
+						Body: api.Body{
+							CodeBlock: cmd,
+						},
+					},
+					Buttons: []api.Button{
+						btnBuilder.ForCommandWithoutDesc("Confirm", fmt.Sprintf("%s command %s", command.ConfirmVerb, id), api.ButtonStyleDanger),
+						btnBuilder.ForCommandWithoutDesc("Cancel", fmt.Sprintf("%s command %s", command.CancelVerb, id)),
+					},
+					Context: []api.ContextItem{
+						{Text: fmt.Sprintf("The command matches the %s rule. Only %s can confirm it until %s.", rule, cmdCtx.User.Mention, expiresAt.UTC().Format(time.RFC3339))},
+					},
+				},
+			},
+		},
+	}, true
+}
+
+// requireConfirmationFlag checks if a given destructive command was confirmed upfront with the --yes flag.
+// If so, the flag is consumed, so it isn't passed to the plugin.
+func (e *DefaultExecutor) requireConfirmationFlag(cmdCtx *CommandContext, rules []config.CommandPattern) (interactive.CoreMessage, bool) {
+	cleanCmd, confirmed, err := extractBoolParam(cmdCtx.CleanCmd, commandConfirmationFlag)
+	if err != nil {
+		e.log.Debugf("while extracting confirmation flag from %q: %s", cmdCtx.CleanCmd, err.Error())
+		cleanCmd, confirmed = cmdCtx.CleanCmd, false
+	}
+	cleanCmd = strings.TrimSpace(cleanCmd)
+
+	cmd := removeMultipleSpaces(cleanCmd)
+	rule, matched := matchDestructiveCommand(e.log, rules, cmd)
+	if !matched {
+		return interactive.CoreMessage{}, false
+	}
+	if !confirmed {
+		return respond(fmt.Sprintf(commandConfirmationYesRequired, cmd, rule, cmdCtx.ClusterName), *cmdCtx), true
+	}
+
+	args, err := shellwords.Parse(cleanCmd)
+	if err != nil {
+		e.log.Errorf("while parsing confirmed command %q: %s", cleanCmd, err.Error())
+		return respond(cantParseCmd, *cmdCtx), true
+	}
+	cmdCtx.CleanCmd = cleanCmd
+	cmdCtx.Args = args
+	return interactive.CoreMessage{}, false
+}
+
+// matchDestructiveCommand returns the first rule which matches a given command.
+// If a rule can't be evaluated, the command is treated as destructive.
+func matchDestructiveCommand(log logrus.FieldLogger, rules []config.CommandPattern, cmd string) (string, bool) {
+	for _, rule := range rules {
+		matched, err := rule.MatchesInAnyFlagOrder(cmd)
+		if err != nil {
+			log.Errorf("while checking if command %q is destructive: %s", cmd, err.Error())
+			return rule.String(), true
+		}
+		if matched {
+			return rule.String(), true
+		}
+	}
+	return "", false
+}
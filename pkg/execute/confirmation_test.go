@@ -0,0 +1,225 @@
+package execute
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/approval"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestRequireConfirmation(t *testing.T) {
+	// given
+	cfg := config.Config{
+		Settings: config.Settings{
+			CommandConfirmation: config.CommandConfirmation{
+				Commands: []config.CommandPattern{
+					{Glob: "kubectl delete *"},
+					{Regex: `kubectl scale .*--replicas=0.*`},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		cmd         string
+		platform    config.CommPlatformIntegration
+		mention     string
+		expRequired bool
+		expPending  bool
+		expOutput   string
+		expCmd      string
+	}{
+		{
+			name:     "Not destructive command",
+			cmd:      "kubectl get pods",
+			platform: config.SocketSlackCommPlatformIntegration,
+			expCmd:   "kubectl get pods",
+		},
+		{
+			name:        "Interactive platform",
+			cmd:         "kubectl scale deploy api --replicas=0",
+			platform:    config.SocketSlackCommPlatformIntegration,
+			mention:     "<@U1>",
+			expRequired: true,
+			expPending:  true,
+		},
+		{
+			name:        "Interactive platform without user mention",
+			cmd:         "kubectl delete pod api-0",
+			platform:    config.SocketSlackCommPlatformIntegration,
+			expRequired: true,
+			expOutput:   `The 'kubectl delete pod api-0' command matches the glob "kubectl delete *" rule for destructive commands. To run it on 'prod' cluster, repeat it with the --yes flag.`,
+		},
+		{
+			name:        "Non-interactive platform without yes flag",
+			cmd:         "kubectl delete pod api-0",
+			platform:    config.MattermostCommPlatformIntegration,
+			expRequired: true,
+			expOutput:   `The 'kubectl delete pod api-0' command matches the glob "kubectl delete *" rule for destructive commands. To run it on 'prod' cluster, repeat it with the --yes flag.`,
+		},
+		{
+			name:     "Non-interactive platform with yes flag",
+			cmd:      "kubectl delete pod api-0 --yes",
+			platform: config.MattermostCommPlatformIntegration,
+			expCmd:   "kubectl delete pod api-0",
+		},
+		{
+			name:     "Yes flag is passed through for not destructive command",
+			cmd:      "helm uninstall api --yes",
+			platform: config.MattermostCommPlatformIntegration,
+			expCmd:   "helm uninstall api --yes",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			confirmations := approval.NewStore()
+			e := &DefaultExecutor{
+				cfg:           cfg,
+				log:           loggerx.NewNoop(),
+				confirmations: confirmations,
+			}
+			cmdCtx := CommandContext{
+				CleanCmd:       tc.cmd,
+				Args:           strings.Fields(tc.cmd),
+				ClusterName:    "prod",
+				Platform:       tc.platform,
+				User:           UserInput{Mention: tc.mention, DisplayName: "alice"},
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, required := e.requireConfirmation(&cmdCtx, func(context.Context) interactive.CoreMessage {
+				return interactive.CoreMessage{}
+			})
+
+			// then
+			assert.Equal(t, tc.expRequired, required)
+			if tc.expOutput != "" {
+				assert.Equal(t, tc.expOutput, msg.BaseBody.CodeBlock)
+			}
+			if tc.expCmd != "" {
+				assert.Equal(t, tc.expCmd, cmdCtx.CleanCmd)
+				assert.Equal(t, strings.Fields(tc.expCmd), cmdCtx.Args)
+			}
+			if !tc.expPending {
+				return
+			}
+
+			require.Len(t, msg.Sections, 1)
+			section := msg.Sections[0]
+			assert.Equal(t, tc.cmd, section.Body.CodeBlock)
+			require.Len(t, section.Buttons, 2)
+
+			cmdParts := strings.Fields(section.Buttons[0].Command)
+			id := cmdParts[len(cmdParts)-1]
+			req, found := confirmations.Get(id)
+			require.True(t, found)
+			assert.Equal(t, tc.cmd, req.Command)
+			assert.Equal(t, []string{"<@U1>"}, req.Approvers)
+		})
+	}
+}
+
+func TestCommandConfirmationExecutorDecide(t *testing.T) {
+	const clusterName = "prod"
+
+	tests := []struct {
+		name        string
+		args        []string
+		user        string
+		expExecuted bool
+		expPending  bool
+		expDecision string
+		expOutput   string
+	}{
+		{
+			name:        "Confirm",
+			args:        []string{"confirm", "command"},
+			user:        "<@U1>",
+			expExecuted: true,
+			expDecision: "confirmed",
+		},
+		{
+			name:        "Cancel",
+			args:        []string{"cancel", "command"},
+			user:        "<@U1>",
+			expDecision: "canceled",
+			expOutput:   "Done. I won't run the 'kubectl delete pod api-0' command on 'prod' cluster.",
+		},
+		{
+			name:       "Different user",
+			args:       []string{"confirm", "command"},
+			user:       "<@U2>",
+			expPending: true,
+			expOutput:  "Sorry, only the user who ran the 'kubectl delete pod api-0' command can confirm it.",
+		},
+		{
+			name:       "Unknown request",
+			args:       []string{"confirm", "command", "unknown"},
+			user:       "<@U1>",
+			expPending: true,
+			expOutput:  "Confirmation request 'unknown' not found on 'prod' cluster. It was already decided or it expired.",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// given
+			var executed bool
+			confirmations := approval.NewStore()
+			id := confirmations.Add(approval.Request{
+				DisplayName: "kubectl delete pod api-0",
+				Command:     "kubectl delete pod api-0",
+				Approvers:   []string{"<@U1>"},
+				Execute: func(context.Context) interactive.CoreMessage {
+					executed = true
+					return interactive.CoreMessage{Header: "executed"}
+				},
+			})
+			args := tc.args
+			if len(args) == 2 {
+				args = append(args, id)
+			}
+			auditReporter := &fakeAuditReporter{}
+			e := NewCommandConfirmationExecutor(loggerx.NewNoop(), confirmations, auditReporter)
+
+			// when
+			msg, err := e.decide(context.Background(), CommandContext{
+				Args:           args,
+				ClusterName:    clusterName,
+				User:           UserInput{Mention: tc.user, DisplayName: "alice"},
+				Conversation:   Conversation{ID: "C1", DisplayName: "ops"},
+				ExecutorFilter: newExecutorTextFilter(""),
+			}, args[0] == "confirm")
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expExecuted, executed)
+			if tc.expExecuted {
+				assert.Equal(t, "executed", msg.Header)
+			} else {
+				assert.Equal(t, tc.expOutput, msg.BaseBody.CodeBlock)
+			}
+
+			_, pending := confirmations.Get(id)
+			assert.Equal(t, tc.expPending, pending)
+
+			if tc.expDecision == "" {
+				assert.Empty(t, auditReporter.events)
+				return
+			}
+			require.Len(t, auditReporter.events, 1)
+			assert.Equal(t, map[string]interface{}{
+				"id":       id,
+				"decision": tc.expDecision,
+			}, auditReporter.events[0].AdditionalCreateContext["commandConfirmation"])
+		})
+	}
+}
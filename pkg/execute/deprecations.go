@@ -0,0 +1,286 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubeshop/botkube/internal/kubex/deprecation"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/plugin"
+)
+
+const (
+	deprecationsScanDisabled = "The deprecations scan is disabled. Configure RBAC in 'settings.deprecations.context.rbac' to enable it."
+	deprecationsNotFound     = "No resources using deprecated API versions found."
+)
+
+var (
+	// deprecations verb has subcommands instead of features, so they are registered as feature names
+	deprecationsFeatureName = FeatureName{
+		Name: "scan",
+	}
+)
+
+// deprecationsClientsFn returns K8s clients used for a given command context.
+type deprecationsClientsFn func(cmdCtx CommandContext) (dynamic.Interface, discovery.DiscoveryInterface, error)
+
+// DeprecationsExecutor executes all commands that are related to deprecated API versions.
+type DeprecationsExecutor struct {
+	log       logrus.FieldLogger
+	clientsFn deprecationsClientsFn
+}
+
+// NewDeprecationsExecutor returns a new DeprecationsExecutor instance.
+func NewDeprecationsExecutor(log logrus.FieldLogger, cfg config.Config, restCfg *rest.Config) *DeprecationsExecutor {
+	return &DeprecationsExecutor{
+		log:       log,
+		clientsFn: kubeClientsForDeprecations(restCfg, cfg.Settings.ClusterName, cfg.Settings.Deprecations.Context),
+	}
+}
+
+// Commands returns slice of commands the executor supports
+func (e *DeprecationsExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.DeprecationsVerb: e.Deprecations,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *DeprecationsExecutor) FeatureName() FeatureName {
+	return deprecationsFeatureName
+}
+
+// Deprecations dispatches the deprecations subcommands.
+func (e *DeprecationsExecutor) Deprecations(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 2 {
+		return interactive.CoreMessage{}, errInvalidCommand
+	}
+
+	switch strings.ToLower(cmdCtx.Args[1]) {
+	case "scan":
+		return e.Scan(ctx, cmdCtx)
+	default:
+		return interactive.CoreMessage{}, errUnsupportedCommand
+	}
+}
+
+type deprecatedResource struct {
+	namespace string
+	name      string
+	api       deprecation.API
+	origin    deprecation.Origin
+}
+
+// Scan lists cluster resources which use deprecated or removed API versions.
+func (e *DeprecationsExecutor) Scan(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	var targetVersionRaw string
+	f := pflag.NewFlagSet("deprecations-scan", pflag.ContinueOnError)
+	f.StringVar(&targetVersionRaw, "target-version", "", "Kubernetes version to check removed API versions against. Defaults to the cluster version.")
+	if err := f.Parse(cmdCtx.Args[2:]); err != nil {
+		return interactive.CoreMessage{}, NewExecutionCommandError("while parsing flags: %s", err.Error())
+	}
+
+	dynamicCli, discoveryCli, err := e.clientsFn(cmdCtx)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+	if dynamicCli == nil || discoveryCli == nil {
+		return respond(deprecationsScanDisabled, cmdCtx), nil
+	}
+
+	targetVersion, err := e.targetVersion(targetVersionRaw, discoveryCli)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+
+	gvrs, err := e.gvrsToScan(discoveryCli)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+
+	var found []deprecatedResource
+	for _, gvr := range gvrs {
+		e.log.WithField("gvr", gvr.String()).Debug("Scanning resources for deprecated API versions")
+		list, err := dynamicCli.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if apierrors.IsForbidden(err) || apierrors.IsNotFound(err) {
+				e.log.WithField("gvr", gvr.String()).Debugf("Skipping resources which cannot be listed: %s", err)
+				continue
+			}
+			return interactive.CoreMessage{}, fmt.Errorf("while listing %q: %w", gvr.String(), err)
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			for _, finding := range deprecation.Check(item) {
+				if finding.Origin == deprecation.ObjectOrigin && !finding.API.Replacement.Empty() {
+					// the object was read with a deprecated version only because the replacement is not served yet,
+					// so it doesn't say anything about the version it was created with
+					continue
+				}
+				found = append(found, deprecatedResource{
+					namespace: item.GetNamespace(),
+					name:      item.GetName(),
+					api:       finding.API,
+					origin:    finding.Origin,
+				})
+			}
+		}
+	}
+
+	if len(found) == 0 {
+		return respond(deprecationsNotFound, cmdCtx), nil
+	}
+
+	return respond(deprecatedResourcesTable(found, targetVersion), cmdCtx), nil
+}
+
+func (e *DeprecationsExecutor) targetVersion(raw string, discoveryCli discovery.DiscoveryInterface) (*version.Version, error) {
+	if raw != "" {
+		out, err := version.ParseGeneric(raw)
+		if err != nil {
+			return nil, NewExecutionCommandError("invalid target version %q: %s", raw, err.Error())
+		}
+		return out, nil
+	}
+
+	info, err := discoveryCli.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("while getting cluster version: %w", err)
+	}
+	out, err := version.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing cluster version %q: %w", info.GitVersion, err)
+	}
+	return out, nil
+}
+
+// gvrsToScan returns served resources for all kinds from the deprecation table.
+// The replacement API version is preferred, as all stored objects can be read with it.
+func (e *DeprecationsExecutor) gvrsToScan(discoveryCli discovery.DiscoveryInterface) ([]schema.GroupVersionResource, error) {
+	served := map[schema.GroupVersion]map[string]struct{}{}
+	isServed := func(gvr schema.GroupVersionResource) (bool, error) {
+		resources, known := served[gvr.GroupVersion()]
+		if !known {
+			resources = map[string]struct{}{}
+			list, err := discoveryCli.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+			switch {
+			case err == nil:
+				for _, res := range list.APIResources {
+					resources[res.Name] = struct{}{}
+				}
+			case apierrors.IsNotFound(err):
+			default:
+				return false, fmt.Errorf("while getting resources for %q: %w", gvr.GroupVersion().String(), err)
+			}
+			served[gvr.GroupVersion()] = resources
+		}
+		_, found := resources[gvr.Resource]
+		return found, nil
+	}
+
+	var (
+		out  []schema.GroupVersionResource
+		seen = map[schema.GroupVersionResource]struct{}{}
+	)
+	for _, api := range deprecation.All() {
+		candidates := []schema.GroupVersionResource{api.GroupVersionResource()}
+		if replacement, ok := api.ReplacementGroupVersionResource(); ok {
+			candidates = append([]schema.GroupVersionResource{replacement}, candidates...)
+		}
+
+		for _, gvr := range candidates {
+			ok, err := isServed(gvr)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			if _, found := seen[gvr]; !found {
+				seen[gvr] = struct{}{}
+				out = append(out, gvr)
+			}
+			break
+		}
+	}
+	return out, nil
+}
+
+func deprecatedResourcesTable(resources []deprecatedResource, targetVersion *version.Version) string {
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].api.Kind != resources[j].api.Kind {
+			return resources[i].api.Kind < resources[j].api.Kind
+		}
+		if resources[i].namespace != resources[j].namespace {
+			return resources[i].namespace < resources[j].namespace
+		}
+		return resources[i].name < resources[j].name
+	})
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "Found %d resource(s) using deprecated API versions. Target Kubernetes version: v%s\n\n", len(resources), targetVersion.String())
+
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "KIND\tNAMESPACE\tNAME\tAPI VERSION\tREPLACEMENT\tREMOVED IN\tSTATUS\tSOURCE")
+	for _, res := range resources {
+		status := "deprecated"
+		if res.api.IsRemovedIn(targetVersion) {
+			status = "removed"
+		}
+		namespace := res.namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s", res.api.Kind, namespace, res.name, res.api.GroupVersion().String(), res.api.ReplacementString(), res.api.RemovedIn, status, res.origin)
+	}
+	w.Flush()
+	return buf.String()
+}
+
+func kubeClientsForDeprecations(restCfg *rest.Config, clusterName string, pluginCtx config.PluginContext) deprecationsClientsFn {
+	return func(cmdCtx CommandContext) (dynamic.Interface, discovery.DiscoveryInterface, error) {
+		if restCfg == nil {
+			return nil, nil, nil
+		}
+
+		kubeconfig, err := plugin.GenerateKubeConfig(restCfg, clusterName, pluginCtx, kubeConfigInput(cmdCtx))
+		if err != nil {
+			return nil, nil, fmt.Errorf("while generating kube config: %w", err)
+		}
+		if len(kubeconfig) == 0 {
+			return nil, nil, nil
+		}
+
+		cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("while reading kube config: %w", err)
+		}
+		dynamicCli, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("while creating dynamic K8s client: %w", err)
+		}
+		discoveryCli, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("while creating discovery K8s client: %w", err)
+		}
+		return dynamicCli, discoveryCli, nil
+	}
+}
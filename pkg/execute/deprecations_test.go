@@ -0,0 +1,125 @@
+package execute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestDeprecationsExecutorScan(t *testing.T) {
+	// given
+	dynamicCli := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "apps", Version: "v1", Resource: "deployments"}:                "DeploymentList",
+		{Group: "batch", Version: "v1", Resource: "cronjobs"}:                  "CronJobList",
+		{Group: "policy", Version: "v1beta1", Resource: "podsecuritypolicies"}: "PodSecurityPolicyList",
+	},
+		fixUnstructured("apps/v1", "Deployment", "prod", "api", `{"apiVersion":"apps/v1beta1","kind":"Deployment"}`),
+		fixUnstructured("apps/v1", "Deployment", "prod", "web", `{"apiVersion":"apps/v1","kind":"Deployment"}`),
+		fixUnstructured("batch/v1", "CronJob", "prod", "backup", `{"apiVersion":"batch/v1beta1","kind":"CronJob"}`),
+		fixUnstructured("policy/v1beta1", "PodSecurityPolicy", "", "restricted", ""),
+	)
+	discoveryCli := &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{GroupVersion: "apps/v1", APIResources: []metav1.APIResource{{Name: "deployments"}}},
+				{GroupVersion: "batch/v1", APIResources: []metav1.APIResource{{Name: "cronjobs"}}},
+				{GroupVersion: "policy/v1beta1", APIResources: []metav1.APIResource{{Name: "podsecuritypolicies"}}},
+			},
+		},
+		FakedServerVersion: &version.Info{GitVersion: "v1.24.3"},
+	}
+
+	e := NewDeprecationsExecutor(loggerx.NewNoop(), config.Config{}, nil)
+	e.clientsFn = func(CommandContext) (dynamic.Interface, discovery.DiscoveryInterface, error) {
+		return dynamicCli, discoveryCli, nil
+	}
+
+	tests := []struct {
+		name      string
+		args      []string
+		expOutput string
+	}{
+		{
+			name: "Scan against cluster version",
+			args: []string{"deprecations", "scan"},
+			expOutput: heredoc.Doc(`
+				Found 3 resource(s) using deprecated API versions. Target Kubernetes version: v1.24.3
+
+				KIND              NAMESPACE NAME       API VERSION    REPLACEMENT REMOVED IN STATUS     SOURCE
+				CronJob           prod      backup     batch/v1beta1  batch/v1    v1.25      deprecated last-applied-configuration
+				Deployment        prod      api        apps/v1beta1   apps/v1     v1.16      removed    last-applied-configuration
+				PodSecurityPolicy -         restricted policy/v1beta1 -           v1.25      deprecated object`),
+		},
+		{
+			name: "Scan against target version",
+			args: []string{"deprecations", "scan", "--target-version", "v1.25"},
+			expOutput: heredoc.Doc(`
+				Found 3 resource(s) using deprecated API versions. Target Kubernetes version: v1.25
+
+				KIND              NAMESPACE NAME       API VERSION    REPLACEMENT REMOVED IN STATUS  SOURCE
+				CronJob           prod      backup     batch/v1beta1  batch/v1    v1.25      removed last-applied-configuration
+				Deployment        prod      api        apps/v1beta1   apps/v1     v1.16      removed last-applied-configuration
+				PodSecurityPolicy -         restricted policy/v1beta1 -           v1.25      removed object`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmdCtx := CommandContext{
+				Args:           tc.args,
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := e.Deprecations(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expOutput, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+func TestDeprecationsExecutorScanDisabled(t *testing.T) {
+	// given
+	e := NewDeprecationsExecutor(loggerx.NewNoop(), config.Config{}, nil)
+
+	// when
+	msg, err := e.Deprecations(context.Background(), CommandContext{
+		Args:           []string{"deprecations", "scan"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, deprecationsScanDisabled, msg.BaseBody.CodeBlock)
+}
+
+func fixUnstructured(apiVersion, kind, namespace, name, lastApplied string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	if lastApplied != "" {
+		obj.SetAnnotations(map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": lastApplied,
+		})
+	}
+	return obj
+}
@@ -8,6 +8,7 @@ import (
 var (
 	errInvalidCommand     = errors.New("invalid command")
 	errUnsupportedCommand = errors.New("unsupported command")
+	errCommandDenied      = errors.New("command denied by channel command policy")
 )
 
 // ExecutionCommandError defines error occurred during command execution.
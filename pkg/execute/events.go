@@ -0,0 +1,214 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/botkube/internal/history"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/bot/interactive"
+	"github.com/kubeshop/botkube/pkg/execute/command"
+)
+
+const (
+	eventIDMissing       = "You forgot to pass event ID. Use 'events list' to see recent events."
+	eventNotFound        = "Event %q not found. It might have been already removed from the history."
+	eventHistoryEmpty    = "There are no recent events matching given criteria."
+	eventSummaryMaxChars = 80
+)
+
+var (
+	// events verb has subcommands instead of features, so they are registered as feature aliases
+	eventsFeatureName = FeatureName{
+		Name:    "list",
+		Aliases: []string{"show", "replay"},
+	}
+)
+
+// EventHistory provides access to recently dispatched source events.
+type EventHistory interface {
+	List(filter history.ListFilter) []history.Event
+	Get(id string) (history.Event, bool)
+}
+
+// EventsExecutor executes all commands that are related to the recent events history.
+type EventsExecutor struct {
+	log     logrus.FieldLogger
+	history EventHistory
+	nowFn   func() time.Time
+}
+
+// NewEventsExecutor returns a new EventsExecutor instance.
+func NewEventsExecutor(log logrus.FieldLogger, eventHistory EventHistory) *EventsExecutor {
+	if eventHistory == nil {
+		// history is disabled, e.g. when executors are used outside the agent
+		eventHistory = history.NewStore(0)
+	}
+	return &EventsExecutor{
+		log:     log,
+		history: eventHistory,
+		nowFn:   time.Now,
+	}
+}
+
+// Commands returns slice of commands the executor supports
+func (e *EventsExecutor) Commands() map[command.Verb]CommandFn {
+	return map[command.Verb]CommandFn{
+		command.EventsVerb: e.Events,
+	}
+}
+
+// FeatureName returns the name and aliases of the feature provided by this executor
+func (e *EventsExecutor) FeatureName() FeatureName {
+	return eventsFeatureName
+}
+
+// Events dispatches the events subcommands.
+func (e *EventsExecutor) Events(ctx context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	if len(cmdCtx.Args) < 2 {
+		return interactive.CoreMessage{}, errInvalidCommand
+	}
+
+	switch strings.ToLower(cmdCtx.Args[1]) {
+	case "list":
+		return e.List(ctx, cmdCtx)
+	case "show":
+		return e.Show(ctx, cmdCtx)
+	case "replay":
+		return e.Replay(ctx, cmdCtx)
+	default:
+		return interactive.CoreMessage{}, errUnsupportedCommand
+	}
+}
+
+// List returns a tabular representation of recent events.
+func (e *EventsExecutor) List(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	var (
+		sourceName string
+		since      time.Duration
+	)
+	f := pflag.NewFlagSet("events-list", pflag.ContinueOnError)
+	f.StringVar(&sourceName, "source", "", "Source name")
+	f.DurationVar(&since, "since", 0, "Only events newer than a relative duration like 5m or 1h")
+	if err := f.Parse(cmdCtx.Args[2:]); err != nil {
+		return interactive.CoreMessage{}, NewExecutionCommandError("while parsing flags: %s", err.Error())
+	}
+
+	filter := history.ListFilter{
+		SourceName: sourceName,
+	}
+	if since > 0 {
+		filter.Since = e.nowFn().Add(-since)
+	}
+
+	e.log.WithField("filter", filter).Debug("List recent events")
+	events := e.history.List(filter)
+	if len(events) == 0 {
+		return respond(eventHistoryEmpty, cmdCtx), nil
+	}
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "ID\tCREATED\tSOURCE\tSUMMARY")
+	for _, event := range events {
+		fmt.Fprintf(w, "\n%s\t%s\t%s\t%s", event.ID, event.CreatedAt.Format(time.RFC3339), event.SourceName, eventSummary(event.Message))
+	}
+	w.Flush()
+	return respond(buf.String(), cmdCtx), nil
+}
+
+// Show returns details of a given event.
+func (e *EventsExecutor) Show(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	event, err := e.getEvent(cmdCtx)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+
+	raw, err := json.MarshalIndent(event.RawObject, "", "  ")
+	if err != nil {
+		return interactive.CoreMessage{}, fmt.Errorf("while marshaling event %q: %w", event.ID, err)
+	}
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "ID:\t%s\n", event.ID)
+	fmt.Fprintf(w, "Created:\t%s\n", event.CreatedAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "Source:\t%s\n", event.SourceName)
+	fmt.Fprintf(w, "Plugin:\t%s\n", event.PluginName)
+	fmt.Fprintf(w, "Summary:\t%s\n", eventSummary(event.Message))
+	w.Flush()
+	fmt.Fprintf(buf, "\n%s", raw)
+
+	return respond(buf.String(), cmdCtx), nil
+}
+
+// Replay re-posts a given event to the current conversation.
+func (e *EventsExecutor) Replay(_ context.Context, cmdCtx CommandContext) (interactive.CoreMessage, error) {
+	event, err := e.getEvent(cmdCtx)
+	if err != nil {
+		return interactive.CoreMessage{}, err
+	}
+
+	e.log.WithFields(logrus.Fields{
+		"eventID":      event.ID,
+		"conversation": cmdCtx.Conversation.ID,
+	}).Debug("Replaying event")
+
+	// the replay is a new message in the current conversation, so it must neither edit the original message nor reply in its thread
+	msg := event.Message
+	msg.UpdateKey = ""
+	msg.ParentActivityID = ""
+
+	return interactive.CoreMessage{
+		Description: appendByUserOnlyIfNeeded(fmt.Sprintf("Replay of event `%s` from the `%s` source, originally reported at %s", event.ID, event.SourceName, event.CreatedAt.Format(time.RFC3339)), cmdCtx.User.Mention, cmdCtx.Conversation.CommandOrigin),
+		Message:     msg,
+	}, nil
+}
+
+func (e *EventsExecutor) getEvent(cmdCtx CommandContext) (history.Event, error) {
+	if len(cmdCtx.Args) < 3 {
+		return history.Event{}, NewExecutionCommandError(eventIDMissing)
+	}
+
+	id := cmdCtx.Args[2]
+	event, found := e.history.Get(id)
+	if !found {
+		return history.Event{}, NewExecutionCommandError(eventNotFound, id)
+	}
+	return event, nil
+}
+
+// eventSummary returns a single line which describes a given message.
+func eventSummary(msg api.Message) string {
+	var out string
+	for _, section := range msg.Sections {
+		if section.Header != "" {
+			out = section.Header
+			break
+		}
+		if section.Description != "" {
+			out = section.Description
+			break
+		}
+	}
+	if out == "" {
+		out = msg.BaseBody.Plaintext
+	}
+	if out == "" {
+		out = msg.BaseBody.CodeBlock
+	}
+
+	out, _, _ = strings.Cut(strings.TrimSpace(out), "\n")
+	if len(out) > eventSummaryMaxChars {
+		out = out[:eventSummaryMaxChars-3] + "..."
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package execute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/botkube/internal/history"
+	"github.com/kubeshop/botkube/pkg/api"
+	"github.com/kubeshop/botkube/pkg/loggerx"
+)
+
+func TestEventsExecutor(t *testing.T) {
+	// given
+	now := time.Date(2023, 10, 5, 12, 0, 0, 0, time.UTC)
+	store := history.NewStore(10)
+	store.Record(history.Event{
+		SourceName: "k8s-err",
+		PluginName: "botkube/kubernetes",
+		Message: api.Message{
+			Sections: []api.Section{
+				{Base: api.Base{Header: "Pod prod/api-0 failed"}},
+			},
+		},
+		RawObject: map[string]any{"kind": "Pod"},
+		CreatedAt: now.Add(-2 * time.Hour),
+	})
+	store.Record(history.Event{
+		SourceName: "k8s-err",
+		PluginName: "botkube/kubernetes",
+		Message: api.Message{
+			BaseBody: api.Body{Plaintext: "Deployment prod/api has no available replicas"},
+		},
+		CreatedAt: now.Add(-30 * time.Minute),
+	})
+	store.Record(history.Event{
+		SourceName: "keptn",
+		PluginName: "botkube/keptn",
+		Message: api.Message{
+			BaseBody: api.Body{Plaintext: "Deployment finished"},
+		},
+		CreatedAt: now.Add(-10 * time.Minute),
+	})
+
+	e := NewEventsExecutor(loggerx.NewNoop(), store)
+	e.nowFn = func() time.Time { return now }
+
+	tests := []struct {
+		name      string
+		args      []string
+		expOutput string
+	}{
+		{
+			name: "List all events",
+			args: []string{"events", "list"},
+			expOutput: heredoc.Doc(`
+				ID   CREATED              SOURCE  SUMMARY
+				1    2023-10-05T10:00:00Z k8s-err Pod prod/api-0 failed
+				2    2023-10-05T11:30:00Z k8s-err Deployment prod/api has no available replicas
+				3    2023-10-05T11:50:00Z keptn   Deployment finished`),
+		},
+		{
+			name: "List events for a given source from the last hour",
+			args: []string{"events", "list", "--source", "k8s-err", "--since", "1h"},
+			expOutput: heredoc.Doc(`
+				ID   CREATED              SOURCE  SUMMARY
+				2    2023-10-05T11:30:00Z k8s-err Deployment prod/api has no available replicas`),
+		},
+		{
+			name: "Show event",
+			args: []string{"events", "show", "1"},
+			expOutput: heredoc.Doc(`
+				ID:      1
+				Created: 2023-10-05T10:00:00Z
+				Source:  k8s-err
+				Plugin:  botkube/kubernetes
+				Summary: Pod prod/api-0 failed
+
+				{
+				  "kind": "Pod"
+				}`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cmdCtx := CommandContext{
+				Args:           tc.args,
+				ExecutorFilter: newExecutorTextFilter(""),
+			}
+
+			// when
+			msg, err := e.Events(context.Background(), cmdCtx)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tc.expOutput, msg.BaseBody.CodeBlock)
+		})
+	}
+}
+
+func TestEventsExecutorReplay(t *testing.T) {
+	// given
+	store := history.NewStore(10)
+	msg := api.Message{
+		BaseBody:         api.Body{Plaintext: "Pod prod/api-0 failed"},
+		ParentActivityID: "1700000000.000100",
+		UpdateKey:        "alert-group-1",
+	}
+	id := store.Record(history.Event{SourceName: "k8s-err", Message: msg})
+
+	e := NewEventsExecutor(loggerx.NewNoop(), store)
+
+	// when
+	out, err := e.Events(context.Background(), CommandContext{
+		Args:           []string{"events", "replay", id},
+		ExecutorFilter: newExecutorTextFilter(""),
+	})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, api.Message{
+		BaseBody: api.Body{Plaintext: "Pod prod/api-0 failed"},
+	}, out.Message)
+
+	// when
+	_, err = e.Events(context.Background(), CommandContext{
+		Args:           []string{"events", "replay", "42"},
+		ExecutorFilter: newExecutorTextFilter(""),
+	})
+
+	// then
+	require.EqualError(t, err, `Event "42" not found. It might have been already removed from the history.`)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/approval"
 	"github.com/kubeshop/botkube/internal/audit"
 	remoteapi "github.com/kubeshop/botkube/internal/remote"
 	"github.com/kubeshop/botkube/pkg/api"
@@ -62,6 +63,21 @@ type DefaultExecutor struct {
 	auditReporter         audit.AuditReporter
 	pluginHealthStats     *plugin.HealthStats
 	auditContext          map[string]interface{}
+	confirmations         *approval.Store
+
+	// err holds an error which occurred during the last execution. It's already reported in the returned message.
+	err error
+}
+
+// ErrorReporter is implemented by executors which expose command execution errors.
+type ErrorReporter interface {
+	// Err returns an error which occurred during the last execution, if any.
+	Err() error
+}
+
+// Err returns an error which occurred during the last execution, if any.
+func (e *DefaultExecutor) Err() error {
+	return e.err
 }
 
 // Execute executes commands and returns output
@@ -88,6 +104,7 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 
 	flags, err := ParseFlags(expandedRawCmd)
 	if err != nil {
+		e.err = err
 		e.log.WithError(err).WithField("msg", expandedRawCmd).Error("Failed to parse user message")
 		return interactive.CoreMessage{
 			Description: header(cmdCtx),
@@ -134,23 +151,21 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 	isPluginCmd := e.pluginExecutor.CanHandle(e.conversation.ExecutorBindings, cmdCtx.Args)
 	if isPluginCmd {
 		_, fullPluginName := e.pluginExecutor.getEnabledPlugins(e.conversation.ExecutorBindings, cmdCtx.Args[0])
-		e.reportCommand(ctx, fullPluginName, e.pluginExecutor.GetCommandPrefix(cmdCtx.Args), cmdCtx.ExecutorFilter.IsActive(), cmdCtx)
-
-		if isHelpCmd(cmdCtx.Args) {
-			return e.ExecuteHelp(ctx, cmdCtx)
+		if msg, allowed := e.enforceCommandPolicy(ctx, fullPluginName, &cmdCtx); !allowed {
+			return msg
 		}
 
-		out, err := e.pluginExecutor.Execute(ctx, e.conversation.ExecutorBindings, e.conversation.SlackState, cmdCtx)
-		switch {
-		case err == nil:
-		case IsExecutionCommandError(err):
-			return respond(err.Error(), cmdCtx)
-		default:
-			// TODO: Return error when the DefaultExecutor is refactored as a part of https://github.com/kubeshop/botkube/issues/589
-			e.log.Errorf("while executing command %q: %s", cmdCtx.CleanCmd, err.Error())
-			return empty
+		run := func(ctx context.Context) interactive.CoreMessage {
+			e.reportCommand(ctx, fullPluginName, e.pluginExecutor.GetCommandPrefix(cmdCtx.Args), cmdCtx.ExecutorFilter.IsActive(), cmdCtx)
+			if isHelpCmd(cmdCtx.Args) {
+				return e.ExecuteHelp(ctx, cmdCtx)
+			}
+			return e.executePluginCommand(ctx, cmdCtx)
+		}
+		if msg, required := e.requireConfirmation(&cmdCtx, run); required {
+			return msg
 		}
-		return out
+		return run(ctx)
 	}
 
 	help, found := GetInstallHelpForKnownPlugin(cmdCtx.Args)
@@ -166,12 +181,14 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 
 	fn, foundRes, foundFn := e.cmdsMapping.FindFn(cmdVerb, cmdRes)
 	if !foundRes {
+		e.err = errUnsupportedCommand
 		e.reportCommand(ctx, "", anonymizedInvalidVerb, false, cmdCtx)
 		e.log.Infof("received unsupported command: %q", cmdCtx.CleanCmd)
 		return respond(unsupportedCmdMsg, cmdCtx)
 	}
 
 	if !foundFn {
+		e.err = errUnsupportedCommand
 		reportedCmd := string(cmdVerb)
 		if cmdRes != "" {
 			e.log.Infof("received unsupported resource: %q", cmdCtx.CleanCmd)
@@ -190,6 +207,7 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 	}
 
 	msg, err := fn(ctx, cmdCtx)
+	e.err = err
 	switch {
 	case err == nil:
 	case errors.Is(err, errInvalidCommand):
@@ -207,6 +225,21 @@ func (e *DefaultExecutor) Execute(ctx context.Context) interactive.CoreMessage {
 	return msg
 }
 
+func (e *DefaultExecutor) executePluginCommand(ctx context.Context, cmdCtx CommandContext) interactive.CoreMessage {
+	out, err := e.pluginExecutor.Execute(ctx, e.conversation.ExecutorBindings, e.conversation.SlackState, cmdCtx)
+	e.err = err
+	switch {
+	case err == nil:
+	case IsExecutionCommandError(err):
+		return respond(err.Error(), cmdCtx)
+	default:
+		// TODO: Return error when the DefaultExecutor is refactored as a part of https://github.com/kubeshop/botkube/issues/589
+		e.log.Errorf("while executing command %q: %s", cmdCtx.CleanCmd, err.Error())
+		return interactive.CoreMessage{}
+	}
+	return out
+}
+
 func (e *DefaultExecutor) ExecuteHelp(ctx context.Context, cmdCtx CommandContext) interactive.CoreMessage {
 	msg, err := e.pluginExecutor.Help(ctx, e.conversation.ExecutorBindings, cmdCtx)
 	if err != nil {
@@ -216,6 +249,16 @@ func (e *DefaultExecutor) ExecuteHelp(ctx context.Context, cmdCtx CommandContext
 	return msg
 }
 
+// IsEmptyResponse returns true if a given message reports that the command returned no output.
+func IsEmptyResponse(msg api.Message) bool {
+	code := strings.TrimSpace(msg.BaseBody.CodeBlock)
+	plaintext := strings.TrimSpace(msg.BaseBody.Plaintext)
+	if code == "" && (plaintext == "" || plaintext == emptyResponseMsg) {
+		return !msg.HasSections()
+	}
+	return false
+}
+
 func respond(body string, cmdCtx CommandContext) interactive.CoreMessage {
 	body = cmdCtx.ExecutorFilter.Apply(body)
 	msgBody := api.Body{
@@ -8,6 +8,7 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/kubeshop/botkube/internal/analytics"
+	"github.com/kubeshop/botkube/internal/approval"
 	"github.com/kubeshop/botkube/internal/audit"
 	guard "github.com/kubeshop/botkube/internal/command"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
@@ -35,6 +36,7 @@ type DefaultExecutorFactory struct {
 	cmdsMapping           *CommandMapping
 	auditReporter         audit.AuditReporter
 	pluginHealthStats     *plugin.HealthStats
+	confirmations         *approval.Store
 }
 
 // DefaultExecutorFactoryParams contains input parameters for DefaultExecutorFactory.
@@ -49,6 +51,10 @@ type DefaultExecutorFactoryParams struct {
 	BotKubeVersion    string
 	AuditReporter     audit.AuditReporter
 	PluginHealthStats *plugin.HealthStats
+	EventHistory      EventHistory
+	ActionStats       ActionStats
+	ActionApprovals   ActionApprovals
+	ActionPreviewer   ActionPreviewer
 }
 
 // Executor is an interface for processes to execute commands
@@ -75,6 +81,11 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		params.Log.WithField("component", "Action Executor"),
 		params.CfgManager,
 		params.Cfg,
+		params.ActionStats,
+		params.ActionApprovals,
+		params.EventHistory,
+		params.ActionPreviewer,
+		params.AuditReporter,
 	)
 	sourceBindingExecutor := NewSourceBindingExecutor(
 		params.Log.WithField("component", "SourceBinding Executor"),
@@ -116,6 +127,21 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		params.Log.WithField("component", "Alias Executor"),
 		params.Cfg,
 	)
+	eventsExecutor := NewEventsExecutor(
+		params.Log.WithField("component", "Events Executor"),
+		params.EventHistory,
+	)
+	deprecationsExecutor := NewDeprecationsExecutor(
+		params.Log.WithField("component", "Deprecations Executor"),
+		params.Cfg,
+		params.RestCfg,
+	)
+	confirmations := approval.NewStore()
+	confirmationExecutor := NewCommandConfirmationExecutor(
+		params.Log.WithField("component", "Command Confirmation Executor"),
+		confirmations,
+		params.AuditReporter,
+	)
 
 	executors := []CommandExecutor{
 		actionExecutor,
@@ -129,6 +155,9 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		execExecutor,
 		sourceExecutor,
 		aliasExecutor,
+		eventsExecutor,
+		deprecationsExecutor,
+		confirmationExecutor,
 	}
 	mappings, err := NewCmdsMapping(executors)
 	if err != nil {
@@ -157,6 +186,7 @@ func NewExecutorFactory(params DefaultExecutorFactoryParams) (*DefaultExecutorFa
 		cmdsMapping:           mappings,
 		auditReporter:         params.AuditReporter,
 		pluginHealthStats:     params.PluginHealthStats,
+		confirmations:         confirmations,
 	}, nil
 }
 
@@ -173,6 +203,8 @@ type Conversation struct {
 	URL              string
 	Text             string
 	ParentActivityID string
+	// CommandPolicy restricts commands which can be run in the conversation.
+	CommandPolicy config.CommandPolicy
 }
 
 // NewDefaultInput an input for NewDefault
@@ -188,8 +220,12 @@ type NewDefaultInput struct {
 
 // UserInput contains details about the user.
 type UserInput struct {
+	// ID is the immutable user ID on the communication platform. It's empty if the platform doesn't expose it.
+	ID          string
 	Mention     string
 	DisplayName string
+	// Email is empty if the communication platform doesn't expose it.
+	Email string
 }
 
 // NewDefault creates new Default Executor.
@@ -212,6 +248,7 @@ func (f *DefaultExecutorFactory) NewDefault(cfg NewDefaultInput) Executor {
 		cmdsMapping:           f.cmdsMapping,
 		auditReporter:         f.auditReporter,
 		pluginHealthStats:     f.pluginHealthStats,
+		confirmations:         f.confirmations,
 		user:                  cfg.User,
 		notifierHandler:       cfg.NotifierHandler,
 		conversation:          cfg.Conversation,
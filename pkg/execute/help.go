@@ -24,7 +24,7 @@ type HelpExecutor struct {
 // NewHelpExecutor returns a new HelpExecutor instance
 func NewHelpExecutor(log logrus.FieldLogger, cfg config.Config) *HelpExecutor {
 	collector := plugin.NewCollector(log)
-	enabledPluginExecutors, _ := collector.GetAllEnabledAndUsedPlugins(&cfg)
+	enabledPluginExecutors, _, _ := collector.GetAllEnabledAndUsedPlugins(&cfg)
 
 	return &HelpExecutor{
 		log:                    log,
@@ -106,6 +106,12 @@ func TestRemoveBotkubeRelatedFlags(t *testing.T) {
 			ClusterName: "api",
 			Filter:      "=./Users/botkube/somefile.txt",
 		},
+		{
+			Name:        "Confirmation flag is passed through",
+			Input:       "@botkube kubectl delete pod nginx --yes --cluster-name foo",
+			Cmd:         "@botkube kubectl delete pod nginx --yes",
+			ClusterName: "foo",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.Name, func(t *testing.T) {
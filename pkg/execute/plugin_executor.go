@@ -15,6 +15,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/api/executor"
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/execute/command"
 	"github.com/kubeshop/botkube/pkg/plugin"
 )
 
@@ -81,14 +82,7 @@ func (e *PluginExecutor) Execute(ctx context.Context, bindings []string, slackSt
 		return interactive.CoreMessage{}, fmt.Errorf("while collecting configs: %w", err)
 	}
 
-	channel := cmdCtx.Conversation.DisplayName
-	if channel == "" {
-		channel = cmdCtx.Conversation.ID
-	}
-
-	input := plugin.KubeConfigInput{
-		Channel: channel,
-	}
+	input := kubeConfigInput(cmdCtx)
 	e.log.WithField("input", input).Debug("Generating Kubeconfig...")
 
 	kubeconfig, err := plugin.GenerateKubeConfig(e.restCfg, e.cfg.Settings.ClusterName, plugins[0].Context, input)
@@ -332,3 +326,23 @@ func (e *PluginExecutor) getEnabledPlugins(bindings []string, cmdName string) ([
 
 	return out, fullPluginName
 }
+
+// kubeConfigInput returns the input for generating kubeconfig for a given command context.
+func kubeConfigInput(cmdCtx CommandContext) plugin.KubeConfigInput {
+	channel := cmdCtx.Conversation.DisplayName
+	if channel == "" {
+		channel = cmdCtx.Conversation.ID
+	}
+
+	input := plugin.KubeConfigInput{
+		Channel: channel,
+	}
+	// automated commands aren't executed by chat users, so they always use the mapping fallback
+	if cmdCtx.Conversation.CommandOrigin != command.AutomationOrigin {
+		input.User = plugin.KubeConfigUser{
+			ID:    cmdCtx.User.ID,
+			Email: cmdCtx.User.Email,
+		}
+	}
+	return input
+}
@@ -18,12 +18,14 @@ import (
 	"github.com/kubeshop/botkube/pkg/bot/interactive"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/execute/command"
+	"github.com/kubeshop/botkube/pkg/plugin"
 )
 
 const (
 	editedSourcesMsgFmt              = ":white_check_mark: %s adjusted the Botkube notifications settings to %s messages for this channel. Expect Botkube reload in a few seconds..."
 	editedSourcesMsgWithoutReloadFmt = ":white_check_mark: %s adjusted the Botkube notifications settings to %s messages.\nAs the Config Watcher is disabled, you need to restart Botkube manually to apply the changes."
 	unknownSourcesMsgFmt             = ":exclamation: The %s %s not found in configuration. To learn how to add custom source, visit https://docs.botkube.io/configuration/source."
+	streamLastErrMaxChars            = 60
 )
 
 var (
@@ -88,13 +90,14 @@ func (e *SourceBindingExecutor) Status(_ context.Context, cmdCtx CommandContext)
 
 	buf := new(bytes.Buffer)
 	w := tabwriter.NewWriter(buf, 0, 0, 1, ' ', 0)
-	fmt.Fprintln(w, "NAME\tDISPLAY NAME")
+	fmt.Fprintln(w, "NAME\tDISPLAY NAME\tSTREAM\tRESTARTS\tLAST_ERROR")
 	for _, name := range sources {
 		description := ""
 		if s, ok := e.cfg.Sources[name]; ok {
 			description = s.DisplayName
 		}
-		fmt.Fprintf(w, "%s\t%s\n", name, description)
+		status, restarts, threshold, _, lastErr := streamStats(cmdCtx.PluginHealthStats, name)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\n", name, description, status, restarts, threshold, lastErr)
 	}
 	w.Flush()
 	return respond(buf.String(), cmdCtx), nil
@@ -347,3 +350,14 @@ func removeQuotationMarks(in string) (string, error) {
 	result, _, err := transform.String(runes.Remove(runes.Predicate(isQuotationMark)), in)
 	return result, err
 }
+
+func streamStats(stats *plugin.HealthStats, source string) (status string, restarts int, threshold int, timestamp string, lastErr string) {
+	if stats == nil {
+		return "-", 0, 0, "", ""
+	}
+	status, restarts, threshold, timestamp, lastErr = stats.GetStreamStats(source)
+	if len(lastErr) > streamLastErrMaxChars {
+		lastErr = lastErr[:streamLastErrMaxChars-3] + "..."
+	}
+	return status, restarts, threshold, timestamp, lastErr
+}
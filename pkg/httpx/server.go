@@ -2,6 +2,7 @@ package httpx
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"time"
@@ -15,6 +16,9 @@ const readHeaderTimeout = 1 * time.Minute
 type Server struct {
 	srv *http.Server
 	log logrus.FieldLogger
+
+	certFile string
+	keyFile  string
 }
 
 // NewServer creates a new HTTP server.
@@ -25,6 +29,15 @@ func NewServer(log logrus.FieldLogger, addr string, handler http.Handler) *Serve
 	}
 }
 
+// NewTLSServer creates a new HTTPS server which uses a given certificate and key files.
+func NewTLSServer(log logrus.FieldLogger, addr string, handler http.Handler, tlsCfg *tls.Config, certFile, keyFile string) *Server {
+	srv := NewServer(log, addr, handler)
+	srv.srv.TLSConfig = tlsCfg
+	srv.certFile = certFile
+	srv.keyFile = keyFile
+	return srv
+}
+
 // Serve starts the HTTP server and blocks unil the channel is closed or an error occurs.
 func (s *Server) Serve(ctx context.Context) error {
 	go func() {
@@ -36,9 +49,16 @@ func (s *Server) Serve(ctx context.Context) error {
 	}()
 
 	s.log.Infof("Starting server on address %q", s.srv.Addr)
-	if err := s.srv.ListenAndServe(); err != http.ErrServerClosed {
+	if err := s.listenAndServe(); err != http.ErrServerClosed {
 		return fmt.Errorf("while starting server: %w", err)
 	}
 
 	return nil
 }
+
+func (s *Server) listenAndServe() error {
+	if s.srv.TLSConfig == nil {
+		return s.srv.ListenAndServe()
+	}
+	return s.srv.ListenAndServeTLS(s.certFile, s.keyFile)
+}
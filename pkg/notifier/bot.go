@@ -18,7 +18,14 @@ type Bot interface {
 	SendMessageToAll(context.Context, interactive.CoreMessage) error
 
 	// SendMessage sends a generic message for a given source bindings.
-	SendMessage(context.Context, interactive.CoreMessage, []string) error
+	// It returns references of the sent messages, which can be used to reply in their threads.
+	// Platforms without threads support return no references.
+	SendMessage(context.Context, interactive.CoreMessage, []string) ([]MessageRef, error)
+
+	// SendMessageToChannels sends a generic message to given channels, regardless of their source bindings.
+	// Unknown channels and channels with disabled notifications are skipped.
+	// Similarly to SendMessage, it returns references of the sent messages.
+	SendMessageToChannels(context.Context, interactive.CoreMessage, []string) ([]MessageRef, error)
 
 	// IntegrationName returns a name of a given communication platform.
 	IntegrationName() config.CommPlatformIntegration
@@ -27,6 +34,15 @@ type Bot interface {
 	Type() config.IntegrationType
 }
 
+// MessageRef references a message sent to a given channel.
+type MessageRef struct {
+	// Channel is the channel identifier accepted by Bot.SendMessageToChannels.
+	Channel string
+	// ID is the platform activity ID of the message, e.g. Slack message timestamp.
+	// It can be used as api.Message.ParentActivityID to send a message in the thread of the referenced one.
+	ID string
+}
+
 // SendPlaintextMessage sends a plaintext message to specified providers.
 func SendPlaintextMessage(ctx context.Context, notifiers []Bot, msg string) error {
 	if msg == "" {
@@ -0,0 +1,102 @@
+package notifier
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// ChannelError describes a failure of sending a message to a given channel.
+type ChannelError struct {
+	Channel string
+	Err     error
+}
+
+// NewChannelError returns a new ChannelError instance.
+func NewChannelError(channel string, err error) *ChannelError {
+	return &ChannelError{Channel: channel, Err: err}
+}
+
+// Error returns the error message.
+func (e *ChannelError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ChannelError) Unwrap() error {
+	return e.Err
+}
+
+// PermanentError describes a failure which won't be fixed by sending the message again,
+// e.g. a channel that doesn't exist or invalid credentials.
+type PermanentError struct {
+	Err error
+}
+
+// NewPermanentError returns a new PermanentError instance.
+func NewPermanentError(err error) *PermanentError {
+	return &PermanentError{Err: err}
+}
+
+// Error returns the error message.
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// IsPermanentError returns true if a given error is a PermanentError.
+// For multiple errors, it returns true only if all of them are permanent.
+func IsPermanentError(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, err := range flattenErrors(err) {
+		var permanentErr *PermanentError
+		if !errors.As(err, &permanentErr) {
+			return false
+		}
+	}
+	return true
+}
+
+// FailedChannels returns channels which failed to receive a message with a temporary error and may be retried.
+// Channels which failed permanently are omitted. It returns false if any of the errors isn't related to a channel,
+// so it's unknown which channels received the message.
+func FailedChannels(err error) ([]string, bool) {
+	var (
+		channels []string
+		seen     = map[string]struct{}{}
+	)
+	for _, err := range flattenErrors(err) {
+		var channelErr *ChannelError
+		if !errors.As(err, &channelErr) {
+			return nil, false
+		}
+		if IsPermanentError(channelErr.Err) {
+			continue
+		}
+		if _, found := seen[channelErr.Channel]; found {
+			continue
+		}
+		seen[channelErr.Channel] = struct{}{}
+		channels = append(channels, channelErr.Channel)
+	}
+	return channels, true
+}
+
+func flattenErrors(err error) []error {
+	multiErr, ok := err.(*multierror.Error)
+	if !ok {
+		return []error{err}
+	}
+
+	var out []error
+	for _, err := range multiErr.Errors {
+		out = append(out, flattenErrors(err)...)
+	}
+	return out
+}
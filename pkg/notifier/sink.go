@@ -12,6 +12,9 @@ type Sink interface {
 	// SendEvent sends a generic event for a given source bindings.
 	SendEvent(context.Context, any, []string) error
 
+	// SendRoutedEvent sends a generic event routed by a routing rule. Source bindings are ignored.
+	SendRoutedEvent(context.Context, any, []string) error
+
 	// IntegrationName returns a name of a given communication platform.
 	IntegrationName() config.CommPlatformIntegration
 
@@ -21,3 +24,39 @@ type Sink interface {
 	// GetStatus gets sink status
 	GetStatus() health.PlatformStatus
 }
+
+// PlaintextEventKind identifies PlaintextEvent also once it's unmarshaled from JSON, e.g. restored from the delivery queue spillover.
+const PlaintextEventKind = "botkube.io/PlaintextEvent"
+
+// PlaintextEvent is sent to sinks for events produced by sources streaming to interactive communication platforms.
+// Interactive messages cannot be forwarded as they are, so the raw event object is sent together with a plaintext rendering of the message.
+type PlaintextEvent struct {
+	Kind      string `json:"kind"`
+	RawObject any    `json:"rawObject,omitempty"`
+	Message   string `json:"message"`
+}
+
+// NewPlaintextEvent returns a new PlaintextEvent instance.
+func NewPlaintextEvent(rawObject any, message string) PlaintextEvent {
+	return PlaintextEvent{
+		Kind:      PlaintextEventKind,
+		RawObject: rawObject,
+		Message:   message,
+	}
+}
+
+// AsPlaintextEvent returns a given sink event as PlaintextEvent. Events restored from the delivery queue spillover
+// are unmarshaled into maps, so they are converted back if they were sent as PlaintextEvent.
+func AsPlaintextEvent(event any) (PlaintextEvent, bool) {
+	switch ev := event.(type) {
+	case PlaintextEvent:
+		return ev, true
+	case map[string]any:
+		if ev["kind"] != PlaintextEventKind {
+			return PlaintextEvent{}, false
+		}
+		message, _ := ev["message"].(string)
+		return NewPlaintextEvent(ev["rawObject"], message), true
+	}
+	return PlaintextEvent{}, false
+}
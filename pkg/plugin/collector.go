@@ -35,7 +35,8 @@ func collect[T botBindingsGetter](boundExecutors, boundSources map[string]struct
 
 // GetAllEnabledAndUsedPlugins returns the list of all plugins that are both enabled and bind to at
 // least one communicator or action (automation) that is enabled.
-func (c *Collector) GetAllEnabledAndUsedPlugins(cfg *config.Config) ([]string, []string) {
+// Transformers are used if they are referenced by at least one used source.
+func (c *Collector) GetAllEnabledAndUsedPlugins(cfg *config.Config) ([]string, []string, []string) {
 	var (
 		boundExecutors = map[string]struct{}{}
 		boundSources   = map[string]struct{}{}
@@ -124,8 +125,17 @@ func (c *Collector) GetAllEnabledAndUsedPlugins(cfg *config.Config) ([]string, [
 	}
 
 	// Collect all sources that are both enabled and bind to at least one communicator that is enabled.
-	usedSourcePlugins := map[string]struct{}{}
+	var (
+		usedSourcePlugins = map[string]struct{}{}
+		boundTransformers = map[string]struct{}{}
+	)
 	for groupName, groupItems := range cfg.Sources {
+		if _, found := boundSources[groupName]; found {
+			for _, name := range groupItems.Transformers {
+				boundTransformers[name] = struct{}{}
+			}
+		}
+
 		for name, source := range groupItems.Plugins {
 			l := c.log.WithFields(logrus.Fields{
 				"groupName": groupName,
@@ -148,5 +158,30 @@ func (c *Collector) GetAllEnabledAndUsedPlugins(cfg *config.Config) ([]string, [
 		}
 	}
 
-	return maps.Keys(usedExecutorPlugins), maps.Keys(usedSourcePlugins)
+	// Collect all transformers that are both enabled and referenced by at least one used source.
+	usedTransformerPlugins := map[string]struct{}{}
+	for groupName, groupItems := range cfg.Transformers {
+		for name, transformer := range groupItems.Plugins {
+			l := c.log.WithFields(logrus.Fields{
+				"groupName": groupName,
+				"pluginKey": name,
+			})
+
+			if !transformer.Enabled {
+				l.Debug("Transformer plugin defined but not enabled.")
+				continue
+			}
+
+			_, found := boundTransformers[groupName]
+			if !found {
+				l.Debug("Transformer plugin defined and enabled but not used by any source")
+				continue
+			}
+
+			l.Debug("Marking transformer plugin as enabled")
+			usedTransformerPlugins[name] = struct{}{}
+		}
+	}
+
+	return maps.Keys(usedExecutorPlugins), maps.Keys(usedSourcePlugins), maps.Keys(usedTransformerPlugins)
 }
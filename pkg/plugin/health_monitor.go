@@ -8,43 +8,49 @@ import (
 
 	"github.com/kubeshop/botkube/pkg/api/executor"
 	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/api/transformer"
 	"github.com/kubeshop/botkube/pkg/config"
 )
 
 // HealthMonitor restarts a failed plugin process and inform scheduler to start dispatching loop again with a new client that was generated.
 type HealthMonitor struct {
-	log                    logrus.FieldLogger
-	logConfig              config.Logger
-	sourceSupervisorChan   chan pluginMetadata
-	executorSupervisorChan chan pluginMetadata
-	schedulerChan          chan string
-	executorsStore         *store[executor.Executor]
-	sourcesStore           *store[source.Source]
-	policy                 config.PluginRestartPolicy
-	pluginHealthStats      *HealthStats
-	healthCheckInterval    time.Duration
+	log                       logrus.FieldLogger
+	logConfig                 config.Logger
+	sourceSupervisorChan      chan pluginMetadata
+	executorSupervisorChan    chan pluginMetadata
+	transformerSupervisorChan chan pluginMetadata
+	schedulerChan             chan string
+	executorsStore            *store[executor.Executor]
+	sourcesStore              *store[source.Source]
+	transformersStore         *store[transformer.Transformer]
+	policy                    config.PluginRestartPolicy
+	pluginHealthStats         *HealthStats
+	healthCheckInterval       time.Duration
 }
 
 // NewHealthMonitor returns a new HealthMonitor instance.
-func NewHealthMonitor(logger logrus.FieldLogger, logCfg config.Logger, policy config.PluginRestartPolicy, schedulerChan chan string, sourceSupervisorChan, executorSupervisorChan chan pluginMetadata, executorsStore *store[executor.Executor], sourcesStore *store[source.Source], healthCheckInterval time.Duration, stats *HealthStats) *HealthMonitor {
+func NewHealthMonitor(logger logrus.FieldLogger, logCfg config.Logger, policy config.PluginRestartPolicy, schedulerChan chan string, sourceSupervisorChan, executorSupervisorChan, transformerSupervisorChan chan pluginMetadata, executorsStore *store[executor.Executor], sourcesStore *store[source.Source], transformersStore *store[transformer.Transformer], healthCheckInterval time.Duration, stats *HealthStats) *HealthMonitor {
 	return &HealthMonitor{
-		log:                    logger,
-		logConfig:              logCfg,
-		policy:                 policy,
-		schedulerChan:          schedulerChan,
-		sourceSupervisorChan:   sourceSupervisorChan,
-		executorSupervisorChan: executorSupervisorChan,
-		executorsStore:         executorsStore,
-		sourcesStore:           sourcesStore,
-		pluginHealthStats:      stats,
-		healthCheckInterval:    healthCheckInterval,
+		log:                       logger,
+		logConfig:                 logCfg,
+		policy:                    policy,
+		schedulerChan:             schedulerChan,
+		sourceSupervisorChan:      sourceSupervisorChan,
+		executorSupervisorChan:    executorSupervisorChan,
+		transformerSupervisorChan: transformerSupervisorChan,
+		executorsStore:            executorsStore,
+		sourcesStore:              sourcesStore,
+		transformersStore:         transformersStore,
+		pluginHealthStats:         stats,
+		healthCheckInterval:       healthCheckInterval,
 	}
 }
 
-// Start starts monitor processes for sources and executors.
+// Start starts monitor processes for sources, executors and transformers.
 func (m *HealthMonitor) Start(ctx context.Context) {
 	go m.monitorSourcePluginHealth(ctx)
 	go m.monitorExecutorPluginHealth(ctx)
+	go m.monitorTransformerPluginHealth(ctx)
 }
 
 func (m *HealthMonitor) monitorSourcePluginHealth(ctx context.Context) {
@@ -110,6 +116,37 @@ func (m *HealthMonitor) monitorExecutorPluginHealth(ctx context.Context) {
 	}
 }
 
+func (m *HealthMonitor) monitorTransformerPluginHealth(ctx context.Context) {
+	m.log.Info("Starting transformer plugin supervisor...")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case plugin := <-m.transformerSupervisorChan:
+			m.log.Infof("Restarting transformer plugin %q, attempt %d/%d...", plugin.pluginKey, m.pluginHealthStats.GetRestartCount(plugin.pluginKey)+1, m.policy.Threshold)
+
+			if transformer, ok := m.transformersStore.EnabledPlugins.Get(plugin.pluginKey); ok && transformer.Cleanup != nil {
+				m.log.Debugf("Releasing resources of transformer plugin %q...", plugin.pluginKey)
+				transformer.Cleanup()
+			}
+
+			m.transformersStore.EnabledPlugins.Delete(plugin.pluginKey)
+			if ok := m.shouldRestartPlugin(plugin.pluginKey); !ok {
+				m.log.Warnf("Plugin %q has been restarted too many times. Deactivating...", plugin.pluginKey)
+				continue
+			}
+
+			p, err := createGRPCClient[transformer.Transformer](ctx, m.log, m.logConfig, plugin, TypeTransformer, m.transformerSupervisorChan, m.healthCheckInterval)
+			if err != nil {
+				m.log.WithError(err).Errorf("Failed to restart plugin %q.", plugin.pluginKey)
+				continue
+			}
+
+			m.transformersStore.EnabledPlugins.Insert(plugin.pluginKey, p)
+		}
+	}
+}
+
 func (m *HealthMonitor) shouldRestartPlugin(plugin string) bool {
 	restarts := m.pluginHealthStats.GetRestartCount(plugin)
 	m.pluginHealthStats.Increment(plugin)
@@ -3,17 +3,21 @@ package plugin
 import (
 	"sync"
 	"time"
+
+	"github.com/kubeshop/botkube/pkg/maputil"
 )
 
 const (
 	pluginRunning     = "Running"
 	pluginDeactivated = "Deactivated"
+	streamRestarting  = "Restarting"
 )
 
 // HealthStats holds information about plugin health and restarts.
 type HealthStats struct {
 	sync.RWMutex
 	pluginStats            map[string]pluginStats
+	streamStats            map[string]streamStats
 	globalRestartThreshold int
 }
 
@@ -23,10 +27,19 @@ type pluginStats struct {
 	lastTransitionTime string
 }
 
+type streamStats struct {
+	source             string
+	status             string
+	restartCount       int
+	lastTransitionTime string
+	lastError          string
+}
+
 // NewHealthStats returns a new HealthStats instance.
 func NewHealthStats(threshold int) *HealthStats {
 	return &HealthStats{
 		pluginStats:            map[string]pluginStats{},
+		streamStats:            map[string]streamStats{},
 		globalRestartThreshold: threshold,
 	}
 }
@@ -73,3 +86,108 @@ func (h *HealthStats) GetStats(plugin string) (status string, restarts int, thre
 	timestamp = h.pluginStats[plugin].lastTransitionTime
 	return
 }
+
+// ReportStreamRunning marks a given stream of a source as running.
+// A source may run multiple streams, e.g. for interactive and non-interactive platforms, so their restarts are counted separately.
+func (h *HealthStats) ReportStreamRunning(source, stream string) {
+	h.setStreamStatus(source, stream, pluginRunning)
+}
+
+// ReportStreamRestarting increments restart count for a given stream of a source and marks it as restarting.
+func (h *HealthStats) ReportStreamRestarting(source, stream string, reason error) {
+	h.Lock()
+	defer h.Unlock()
+
+	stats := h.streamStats[stream]
+	stats.source = source
+	stats.restartCount++
+	stats.status = streamRestarting
+	stats.lastTransitionTime = time.Now().Format(time.RFC3339)
+	if reason != nil {
+		stats.lastError = reason.Error()
+	}
+	h.streamStats[stream] = stats
+}
+
+// ResetStreamRestartCount resets restart count for a given stream, e.g. once it was healthy for a while.
+func (h *HealthStats) ResetStreamRestartCount(stream string) {
+	h.Lock()
+	defer h.Unlock()
+
+	stats, ok := h.streamStats[stream]
+	if !ok {
+		return
+	}
+	stats.restartCount = 0
+	h.streamStats[stream] = stats
+}
+
+// ReportStreamDeactivated marks a given stream of a source as deactivated, so it won't be restarted anymore.
+func (h *HealthStats) ReportStreamDeactivated(source, stream string) {
+	h.setStreamStatus(source, stream, pluginDeactivated)
+}
+
+// GetStreamRestartCount returns restart count for a given stream.
+func (h *HealthStats) GetStreamRestartCount(stream string) int {
+	h.RLock()
+	defer h.RUnlock()
+	return h.streamStats[stream].restartCount
+}
+
+// GetStreamStats returns source stream status, restart count, restart threshold, last transition time and last error.
+// If the source runs multiple streams, stats of the least healthy one are returned.
+func (h *HealthStats) GetStreamStats(source string) (status string, restarts int, threshold int, timestamp string, lastErr string) {
+	h.RLock()
+	defer h.RUnlock()
+	threshold = h.globalRestartThreshold
+
+	var (
+		worst streamStats
+		found bool
+	)
+	for _, stream := range maputil.SortKeys(h.streamStats) {
+		stats := h.streamStats[stream]
+		if stats.source != source {
+			continue
+		}
+		if !found || stats.isLessHealthyThan(worst) {
+			worst = stats
+			found = true
+		}
+	}
+	if !found {
+		status = pluginRunning
+		return
+	}
+
+	return worst.status, worst.restartCount, threshold, worst.lastTransitionTime, worst.lastError
+}
+
+func (h *HealthStats) setStreamStatus(source, stream, status string) {
+	h.Lock()
+	defer h.Unlock()
+
+	stats := h.streamStats[stream]
+	stats.source = source
+	if stats.status == status {
+		h.streamStats[stream] = stats
+		return
+	}
+	stats.status = status
+	stats.lastTransitionTime = time.Now().Format(time.RFC3339)
+	h.streamStats[stream] = stats
+}
+
+// streamStatusSeverity orders stream statuses from the healthiest one.
+var streamStatusSeverity = map[string]int{
+	pluginRunning:     0,
+	streamRestarting:  1,
+	pluginDeactivated: 2,
+}
+
+func (s streamStats) isLessHealthyThan(other streamStats) bool {
+	if streamStatusSeverity[s.status] != streamStatusSeverity[other.status] {
+		return streamStatusSeverity[s.status] > streamStatusSeverity[other.status]
+	}
+	return s.restartCount > other.restartCount
+}
@@ -0,0 +1,43 @@
+package plugin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStatsCountsStreamRestartsSeparately(t *testing.T) {
+	// given
+	stats := NewHealthStats(3)
+	const (
+		interactive    = "k8s-events/kubernetes/interactive/true"
+		nonInteractive = "k8s-events/kubernetes/interactive/false"
+	)
+
+	// when
+	stats.ReportStreamRunning("k8s-events", interactive)
+	stats.ReportStreamRunning("k8s-events", nonInteractive)
+	stats.ReportStreamRestarting("k8s-events", nonInteractive, errors.New("stream was closed"))
+	stats.ReportStreamRestarting("k8s-events", nonInteractive, errors.New("stream was closed"))
+
+	// then
+	assert.Zero(t, stats.GetStreamRestartCount(interactive))
+	assert.Equal(t, 2, stats.GetStreamRestartCount(nonInteractive))
+
+	status, restarts, threshold, _, lastErr := stats.GetStreamStats("k8s-events")
+	assert.Equal(t, streamRestarting, status)
+	assert.Equal(t, 2, restarts)
+	assert.Equal(t, 3, threshold)
+	assert.Equal(t, "stream was closed", lastErr)
+
+	// when
+	stats.ReportStreamRunning("k8s-events", nonInteractive)
+	stats.ResetStreamRestartCount(nonInteractive)
+
+	// then
+	status, restarts, _, _, lastErr = stats.GetStreamStats("k8s-events")
+	assert.Equal(t, pluginRunning, status)
+	assert.Zero(t, restarts)
+	assert.Equal(t, "stream was closed", lastErr)
+}
@@ -22,9 +22,11 @@ const (
 	TypeSource Type = "source"
 	// TypeExecutor represents the executor plugin.
 	TypeExecutor Type = "executor"
+	// TypeTransformer represents the transformer plugin.
+	TypeTransformer Type = "transformer"
 )
 
-var allKnownTypes = []Type{TypeSource, TypeExecutor}
+var allKnownTypes = []Type{TypeSource, TypeExecutor, TypeTransformer}
 
 // IsValid checks if type is a known type.
 func (t Type) IsValid() bool {
@@ -212,7 +212,7 @@ func (i *IndexBuilder) getPluginMetadata(dir string, index []pluginBinariesIndex
 }
 
 func (i *IndexBuilder) appendIndexEntry(entries map[string][]pluginBinariesIndex, entryName string, pNameRegex *regexp.Regexp) error {
-	if !strings.HasPrefix(entryName, TypeExecutor.String()) && !strings.HasPrefix(entryName, TypeSource.String()) {
+	if !strings.HasPrefix(entryName, TypeExecutor.String()) && !strings.HasPrefix(entryName, TypeSource.String()) && !strings.HasPrefix(entryName, TypeTransformer.String()) {
 		i.log.WithField("file", entryName).Debug("Ignoring file as not recognized as plugin")
 		return nil
 	}
@@ -31,7 +31,7 @@ func TestValidateIndexErrors(t *testing.T) {
 			* entries[7]: 1 error occurred:
 				* field name cannot be empty
 			* entries[8]: 1 error occurred:
-				* field type is not valid, allowed values are [source executor]
+				* field type is not valid, allowed values are [source executor transformer]
 			* entries[9]: 1 error occurred:
 				* dependency URL for key "kubectl" and platform "linux/arm64" cannot be empty`)
 
@@ -1,11 +1,15 @@
 package plugin
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"text/template"
 
+	sprig "github.com/go-task/slim-sprig"
 	"github.com/pkg/errors"
 	"k8s.io/client-go/rest"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
@@ -22,6 +26,16 @@ const (
 // KubeConfigInput defines the input for GenerateKubeConfig.
 type KubeConfigInput struct {
 	Channel string
+	User    KubeConfigUser
+}
+
+// KubeConfigUser holds details of the chat user who executes a given command.
+// It's empty if the command isn't executed by a chat user, for example, for automated actions.
+type KubeConfigUser struct {
+	// ID is the immutable chat platform user ID, such as Slack member ID. Editable names are not used on purpose,
+	// as users could change them to impersonate someone else.
+	ID    string
+	Email string
 }
 
 // GenerateKubeConfig generates kubeconfig based on RBAC policy.
@@ -40,6 +54,15 @@ func GenerateKubeConfig(restCfg *rest.Config, clusterName string, pluginCtx conf
 		return nil, nil
 	}
 
+	user, err := generateUserSubject(rbac.User, rbac.Group, input)
+	if err != nil {
+		return nil, fmt.Errorf("while resolving user subject: %w", err)
+	}
+	groups, err := generateGroupSubject(rbac.Group, input)
+	if err != nil {
+		return nil, fmt.Errorf("while resolving group subject: %w", err)
+	}
+
 	apiCfg := clientcmdapi.Config{
 		Kind:       "Config",
 		APIVersion: "v1",
@@ -72,8 +95,8 @@ func GenerateKubeConfig(restCfg *rest.Config, clusterName string, pluginCtx conf
 					TokenFile:             restCfg.BearerTokenFile,
 					ClientCertificateData: restCfg.CertData,
 					ClientKeyData:         restCfg.KeyData,
-					Impersonate:           generateUserSubject(rbac.User, rbac.Group, input),
-					ImpersonateGroups:     generateGroupSubject(rbac.Group, input),
+					Impersonate:           user,
+					ImpersonateGroups:     groups,
 				},
 			},
 		},
@@ -87,21 +110,27 @@ func GenerateKubeConfig(restCfg *rest.Config, clusterName string, pluginCtx conf
 	return yamlKubeConfig, nil
 }
 
-func generateUserSubject(rbac config.UserPolicySubject, group config.GroupPolicySubject, input KubeConfigInput) (user string) {
+func generateUserSubject(rbac config.UserPolicySubject, group config.GroupPolicySubject, input KubeConfigInput) (string, error) {
 	switch rbac.Type {
 	case config.StaticPolicySubjectType:
-		user = rbac.Prefix + rbac.Static.Value
+		return rbac.Prefix + rbac.Static.Value, nil
 	case config.ChannelNamePolicySubjectType:
-		user = rbac.Prefix + input.Channel
+		return rbac.Prefix + input.Channel, nil
+	case config.UserNamePolicySubjectType, config.UserEmailPolicySubjectType:
+		value, err := mapUserSubject(rbac.Type, rbac.Mapping, input.User)
+		if err != nil {
+			return "", err
+		}
+		return rbac.Prefix + value, nil
 	default:
 		if group.Type != config.EmptyPolicySubjectType {
-			user = config.RBACDefaultUser
+			return config.RBACDefaultUser, nil
 		}
 	}
-	return
+	return "", nil
 }
 
-func generateGroupSubject(rbac config.GroupPolicySubject, input KubeConfigInput) (group []string) {
+func generateGroupSubject(rbac config.GroupPolicySubject, input KubeConfigInput) (group []string, err error) {
 	switch rbac.Type {
 	case config.StaticPolicySubjectType:
 		for _, value := range rbac.Static.Values {
@@ -109,8 +138,106 @@ func generateGroupSubject(rbac config.GroupPolicySubject, input KubeConfigInput)
 		}
 	case config.ChannelNamePolicySubjectType:
 		group = append(group, rbac.Prefix+input.Channel)
+	case config.UserNamePolicySubjectType, config.UserEmailPolicySubjectType:
+		values, err := mapGroupSubject(rbac.Type, rbac.Mapping, input.User)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range values {
+			group = append(group, rbac.Prefix+value)
+		}
+	}
+	return group, nil
+}
+
+// mapUserSubject returns the user subject for a given chat user. Users listed explicitly take precedence over the template,
+// and the fallback is used if the chat user can't be mapped in any other way.
+func mapUserSubject(subjectType config.PolicySubjectType, mapping config.UserSubjectMapping, user KubeConfigUser) (string, error) {
+	chatUser := chatUserIdentity(subjectType, user)
+	if chatUser != "" {
+		for _, entry := range mapping.Users {
+			if chatUserMatches(subjectType, entry.ChatUser, chatUser) {
+				return entry.Value, nil
+			}
+		}
+
+		value, err := renderSubjectTemplate(mapping.Template, user)
+		if err != nil {
+			return "", err
+		}
+		if value != "" {
+			return value, nil
+		}
+	}
+
+	if mapping.Fallback == "" {
+		return "", unmappedChatUserError(subjectType, chatUser)
+	}
+	return mapping.Fallback, nil
+}
+
+// mapGroupSubject returns group subjects for a given chat user, following the same rules as mapUserSubject.
+func mapGroupSubject(subjectType config.PolicySubjectType, mapping config.GroupSubjectMapping, user KubeConfigUser) ([]string, error) {
+	chatUser := chatUserIdentity(subjectType, user)
+	if chatUser != "" {
+		for _, entry := range mapping.Users {
+			if chatUserMatches(subjectType, entry.ChatUser, chatUser) {
+				return entry.Values, nil
+			}
+		}
+
+		value, err := renderSubjectTemplate(mapping.Template, user)
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			return []string{value}, nil
+		}
+	}
+
+	if len(mapping.Fallback) == 0 {
+		return nil, unmappedChatUserError(subjectType, chatUser)
+	}
+	return mapping.Fallback, nil
+}
+
+func chatUserIdentity(subjectType config.PolicySubjectType, user KubeConfigUser) string {
+	if subjectType == config.UserEmailPolicySubjectType {
+		return user.Email
+	}
+	return user.ID
+}
+
+// chatUserMatches compares emails case-insensitively, as their domain part is case-insensitive and chat platforms don't normalize it.
+func chatUserMatches(subjectType config.PolicySubjectType, expected, actual string) bool {
+	if subjectType == config.UserEmailPolicySubjectType {
+		return strings.EqualFold(expected, actual)
+	}
+	return expected == actual
+}
+
+func renderSubjectTemplate(tplText string, user KubeConfigUser) (string, error) {
+	if tplText == "" {
+		return "", nil
+	}
+
+	tpl, err := template.New("rbac-subject").Funcs(sprig.FuncMap()).Parse(tplText)
+	if err != nil {
+		return "", fmt.Errorf("while parsing subject template %q: %w", tplText, err)
+	}
+
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, user); err != nil {
+		return "", fmt.Errorf("while rendering subject template %q: %w", tplText, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+func unmappedChatUserError(subjectType config.PolicySubjectType, chatUser string) error {
+	if chatUser == "" {
+		return fmt.Errorf("%s policy requires a chat user, but it's unknown and there is no fallback configured", subjectType)
 	}
-	return
+	return fmt.Errorf("chat user %q is not mapped to any Kubernetes subject and there is no fallback configured", chatUser)
 }
 
 // PersistKubeConfig creates a temporary kubeconfig file and returns its path and a function to delete it.
@@ -0,0 +1,86 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/botkube/pkg/config"
+)
+
+func TestGenerateKubeConfigChatUserMapping(t *testing.T) {
+	// given
+	rbac := &config.PolicyRule{
+		User: config.UserPolicySubject{
+			Type:   config.UserEmailPolicySubjectType,
+			Prefix: "chat:",
+			Mapping: config.UserSubjectMapping{
+				Users: []config.UserSubjectMappingEntry{
+					{ChatUser: "Jane@Example.com", Value: "cluster-admin-jane"},
+				},
+				Template: `{{ .Email | trimSuffix "@example.com" }}`,
+			},
+		},
+		Group: config.GroupPolicySubject{
+			Type: config.UserNamePolicySubjectType,
+			Mapping: config.GroupSubjectMapping{
+				Users: []config.GroupSubjectMappingEntry{
+					{ChatUser: "U01JANE", Values: []string{"sre", "on-call"}},
+				},
+				Fallback: []string{"read-only"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		user      KubeConfigUser
+		expUser   string
+		expGroups []string
+		expErrMsg string
+	}{
+		{
+			name:      "Listed user",
+			user:      KubeConfigUser{ID: "U01JANE", Email: "jane@example.com"},
+			expUser:   "chat:cluster-admin-jane",
+			expGroups: []string{"sre", "on-call"},
+		},
+		{
+			name:      "User mapped by template and group fallback",
+			user:      KubeConfigUser{ID: "U02JOHN", Email: "john@example.com"},
+			expUser:   "chat:john",
+			expGroups: []string{"read-only"},
+		},
+		{
+			name:      "Unknown user without fallback",
+			user:      KubeConfigUser{ID: "U03BOT"},
+			expErrMsg: "while resolving user subject: UserEmail policy requires a chat user, but it's unknown and there is no fallback configured",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// when
+			out, err := GenerateKubeConfig(&rest.Config{Host: "https://localhost"}, "", config.PluginContext{RBAC: rbac}, KubeConfigInput{
+				Channel: "sre",
+				User:    tc.user,
+			})
+
+			// then
+			if tc.expErrMsg != "" {
+				assert.EqualError(t, err, tc.expErrMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			var kubeConfig clientcmdapi.Config
+			require.NoError(t, yaml.Unmarshal(out, &kubeConfig))
+			require.Len(t, kubeConfig.AuthInfos, 1)
+			assert.Equal(t, tc.expUser, kubeConfig.AuthInfos[0].AuthInfo.Impersonate)
+			assert.Equal(t, tc.expGroups, kubeConfig.AuthInfos[0].AuthInfo.ImpersonateGroups)
+		})
+	}
+}
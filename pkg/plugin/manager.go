@@ -22,6 +22,7 @@ import (
 	"github.com/kubeshop/botkube/pkg/api"
 	"github.com/kubeshop/botkube/pkg/api/executor"
 	"github.com/kubeshop/botkube/pkg/api/source"
+	"github.com/kubeshop/botkube/pkg/api/transformer"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/formatx"
 	"github.com/kubeshop/botkube/pkg/httpx"
@@ -45,8 +46,9 @@ const (
 // This map is used in order to identify a plugin called Dispense.
 // This map is globally available and must stay consistent in order for all the plugins to work.
 var pluginMap = map[string]plugin.Plugin{
-	TypeSource.String():   &source.Plugin{},
-	TypeExecutor.String(): &executor.Plugin{},
+	TypeSource.String():      &source.Plugin{},
+	TypeExecutor.String():    &executor.Plugin{},
+	TypeTransformer.String(): &transformer.Plugin{},
 }
 
 // IndexRenderData returns plugin index render data.
@@ -54,7 +56,7 @@ type IndexRenderData struct {
 	Remote remote.Config `yaml:"remote"`
 }
 
-// Manager provides functionality for managing executor and source plugins.
+// Manager provides functionality for managing executor, source and transformer plugins.
 type Manager struct {
 	isStarted       atomic.Bool
 	log             logrus.FieldLogger
@@ -63,9 +65,10 @@ type Manager struct {
 	httpClient      *http.Client
 	indexRenderData IndexRenderData
 
-	sourceSupervisorChan   chan pluginMetadata
-	executorSupervisorChan chan pluginMetadata
-	schedulerChan          chan string
+	sourceSupervisorChan      chan pluginMetadata
+	executorSupervisorChan    chan pluginMetadata
+	transformerSupervisorChan chan pluginMetadata
+	schedulerChan             chan string
 
 	executorsToEnable []string
 	executorsStore    *store[executor.Executor]
@@ -73,6 +76,9 @@ type Manager struct {
 	sourcesStore    *store[source.Source]
 	sourcesToEnable []string
 
+	transformersStore    *store[transformer.Transformer]
+	transformersToEnable []string
+
 	healthCheckInterval time.Duration
 	monitor             *HealthMonitor
 }
@@ -83,11 +89,13 @@ type pluginMetadata struct {
 }
 
 // NewManager returns a new Manager instance.
-func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.PluginManagement, executors, sources []string, schedulerChan chan string, stats *HealthStats) *Manager {
+func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.PluginManagement, executors, sources, transformers []string, schedulerChan chan string, stats *HealthStats) *Manager {
 	sourceSupervisorChan := make(chan pluginMetadata)
 	executorSupervisorChan := make(chan pluginMetadata)
+	transformerSupervisorChan := make(chan pluginMetadata)
 	executorsStore := newStore[executor.Executor]()
 	sourcesStore := newStore[source.Source]()
+	transformersStore := newStore[transformer.Transformer]()
 
 	remoteCfg, _ := remote.GetConfig()
 	indexRenderData := IndexRenderData{
@@ -95,19 +103,22 @@ func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.Plug
 	}
 
 	return &Manager{
-		cfg:                    cfg,
-		httpClient:             httpx.NewHTTPClient(),
-		indexRenderData:        indexRenderData,
-		sourceSupervisorChan:   sourceSupervisorChan,
-		executorSupervisorChan: executorSupervisorChan,
-		schedulerChan:          schedulerChan,
-		executorsToEnable:      executors,
-		executorsStore:         &executorsStore,
-		sourcesToEnable:        sources,
-		sourcesStore:           &sourcesStore,
-		log:                    logger.WithField("component", "Plugin Manager"),
-		logConfig:              logCfg, // used when we create on-demand loggers for plugins
-		healthCheckInterval:    cfg.HealthCheckInterval,
+		cfg:                       cfg,
+		httpClient:                httpx.NewHTTPClient(),
+		indexRenderData:           indexRenderData,
+		sourceSupervisorChan:      sourceSupervisorChan,
+		executorSupervisorChan:    executorSupervisorChan,
+		transformerSupervisorChan: transformerSupervisorChan,
+		schedulerChan:             schedulerChan,
+		executorsToEnable:         executors,
+		executorsStore:            &executorsStore,
+		sourcesToEnable:           sources,
+		sourcesStore:              &sourcesStore,
+		transformersToEnable:      transformers,
+		transformersStore:         &transformersStore,
+		log:                       logger.WithField("component", "Plugin Manager"),
+		logConfig:                 logCfg, // used when we create on-demand loggers for plugins
+		healthCheckInterval:       cfg.HealthCheckInterval,
 		monitor: NewHealthMonitor(
 			logger.WithField("component", "Plugin Health Monitor"),
 			logCfg,
@@ -115,8 +126,10 @@ func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.Plug
 			schedulerChan,
 			sourceSupervisorChan,
 			executorSupervisorChan,
+			transformerSupervisorChan,
 			&executorsStore,
 			&sourcesStore,
+			&transformersStore,
 			cfg.HealthCheckInterval,
 			stats,
 		),
@@ -125,14 +138,15 @@ func NewManager(logger logrus.FieldLogger, logCfg config.Logger, cfg config.Plug
 
 // Start downloads and starts all enabled plugins.
 func (m *Manager) Start(ctx context.Context) error {
-	if len(m.executorsToEnable) == 0 && len(m.sourcesToEnable) == 0 {
+	if len(m.executorsToEnable) == 0 && len(m.sourcesToEnable) == 0 && len(m.transformersToEnable) == 0 {
 		m.log.Info("No external plugins are enabled.")
 		return nil
 	}
 
 	m.log.WithFields(logrus.Fields{
-		"enabledExecutors": strings.Join(m.executorsToEnable, ","),
-		"enabledSources":   strings.Join(m.sourcesToEnable, ","),
+		"enabledExecutors":    strings.Join(m.executorsToEnable, ","),
+		"enabledSources":      strings.Join(m.sourcesToEnable, ","),
+		"enabledTransformers": strings.Join(m.transformersToEnable, ","),
 	}).Info("Starting Plugin Manager for all enabled plugins")
 
 	err := m.start(ctx, false)
@@ -178,6 +192,16 @@ func (m *Manager) start(ctx context.Context, forceUpdate bool) error {
 	}
 	m.sourcesStore.EnabledPlugins = sourcesClients
 
+	transformersPlugins, err := m.loadPlugins(ctx, TypeTransformer, m.transformersToEnable, m.transformersStore.Repository)
+	if err != nil {
+		return err
+	}
+	transformersClients, err := createGRPCClients[transformer.Transformer](ctx, m.log, m.logConfig, transformersPlugins, TypeTransformer, m.transformerSupervisorChan, m.healthCheckInterval)
+	if err != nil {
+		return fmt.Errorf("while creating transformer plugins: %w", err)
+	}
+	m.transformersStore.EnabledPlugins = transformersClients
+
 	return nil
 }
 
@@ -209,12 +233,27 @@ func (m *Manager) GetSource(name string) (source.Source, error) {
 	return client.Client, nil
 }
 
+// GetTransformer returns the transformer client for a given plugin.
+func (m *Manager) GetTransformer(name string) (transformer.Transformer, error) {
+	if !m.isStarted.Load() {
+		return nil, ErrNotStartedPluginManager
+	}
+
+	client, found := m.transformersStore.EnabledPlugins.Get(name)
+	if !found || client.Client == nil {
+		return nil, fmt.Errorf("client for transformer plugin %q not found", name)
+	}
+
+	return client.Client, nil
+}
+
 // Shutdown performs any necessary cleanup.
 // This method blocks until all cleanup is finished.
 func (m *Manager) Shutdown() {
 	var wg sync.WaitGroup
 	releasePlugins(&wg, m.sourcesStore.EnabledPlugins)
 	releasePlugins(&wg, m.executorsStore.EnabledPlugins)
+	releasePlugins(&wg, m.transformersStore.EnabledPlugins)
 	wg.Wait()
 }
 
@@ -300,6 +339,7 @@ func (m *Manager) collectEnabledRepositories() ([]string, error) {
 
 	requestedRepositories := collect(m.executorsToEnable, TypeExecutor)
 	requestedRepositories = append(requestedRepositories, collect(m.sourcesToEnable, TypeSource)...)
+	requestedRepositories = append(requestedRepositories, collect(m.transformersToEnable, TypeTransformer)...)
 
 	if err := issues.ErrorOrNil(); err != nil {
 		return nil, err
@@ -340,12 +380,13 @@ func (m *Manager) loadRepositoriesMetadata(ctx context.Context, forceUpdate bool
 		rawIndexes[repo] = data
 	}
 
-	executorsRepos, sourcesRepos, err := newStoreRepositories(rawIndexes)
+	executorsRepos, sourcesRepos, transformersRepos, err := newStoreRepositories(rawIndexes)
 	if err != nil {
 		return fmt.Errorf("while building repositories store: %w", err)
 	}
 	m.executorsStore.Repository = executorsRepos
 	m.sourcesStore.Repository = sourcesRepos
+	m.transformersStore.Repository = transformersRepos
 
 	return nil
 }
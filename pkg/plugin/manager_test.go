@@ -20,6 +20,7 @@ func TestCollectEnabledRepositories(t *testing.T) {
 
 		enabledExecutors    []string
 		enabledSources      []string
+		enabledTransformers []string
 		definedRepositories map[string]config.PluginsRepository
 
 		expErrMsg string
@@ -48,6 +49,15 @@ func TestCollectEnabledRepositories(t *testing.T) {
 					* repository "botkube" is not defined, but it is referred by executor plugin called "botkube/kubectl"
 					* repository "mszostok" is not defined, but it is referred by executor plugin called "mszostok/hakuna-matata"`),
 		},
+		{
+			name: "report not defined repositories for transformer plugins",
+			enabledTransformers: []string{
+				"mszostok/redact",
+			},
+			expErrMsg: heredoc.Doc(`
+				1 error occurred:
+					* repository "mszostok" is not defined, but it is referred by transformer plugin called "mszostok/redact"`),
+		},
 		{
 			name: "report not defined repositories for source and executor plugins",
 			enabledSources: []string{
@@ -73,7 +83,7 @@ func TestCollectEnabledRepositories(t *testing.T) {
 			// given
 			manager := NewManager(loggerx.NewNoop(), config.Logger{}, config.PluginManagement{
 				Repositories: tc.definedRepositories,
-			}, tc.enabledExecutors, tc.enabledSources, make(chan string), NewHealthStats(1))
+			}, tc.enabledExecutors, tc.enabledSources, tc.enabledTransformers, make(chan string), NewHealthStats(1))
 
 			// when
 			out, err := manager.collectEnabledRepositories()
@@ -75,20 +75,21 @@ func (p *storePlugins[T]) Delete(key string) {
 	delete(p.data, key)
 }
 
-func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepository, error) {
+func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepository, storeRepository, error) {
 	var (
-		executorsRepositories = storeRepository{}
-		sourcesRepositories   = storeRepository{}
+		executorsRepositories    = storeRepository{}
+		sourcesRepositories      = storeRepository{}
+		transformersRepositories = storeRepository{}
 	)
 
 	for repo, data := range indexes {
 		var index Index
 		if err := yaml.Unmarshal(data, &index); err != nil {
-			return nil, nil, fmt.Errorf("while unmarshaling index: %w", err)
+			return nil, nil, nil, fmt.Errorf("while unmarshaling index: %w", err)
 		}
 
 		if err := index.Validate(); err != nil {
-			return nil, nil, fmt.Errorf("while validating %s index: %w", repo, err)
+			return nil, nil, nil, fmt.Errorf("while validating %s index: %w", repo, err)
 		}
 
 		for _, entry := range index.Entries {
@@ -115,6 +116,16 @@ func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepo
 					JSONSchema:       entry.JSONSchema,
 					Recommended:      entry.Recommended,
 				})
+			case TypeTransformer:
+				transformersRepositories.Insert(repo, entry.Name, storeEntry{
+					Description:      entry.Description,
+					DocumentationURL: entry.DocumentationURL,
+					Version:          entry.Version,
+					URLs:             binURLs,
+					Dependencies:     depURLs,
+					JSONSchema:       entry.JSONSchema,
+					Recommended:      entry.Recommended,
+				})
 			}
 		}
 	}
@@ -128,7 +139,11 @@ func newStoreRepositories(indexes map[string][]byte) (storeRepository, storeRepo
 		sort.Sort(byIndexEntryVersion(sourcesRepositories[key]))
 	}
 
-	return executorsRepositories, sourcesRepositories, nil
+	for key := range transformersRepositories {
+		sort.Sort(byIndexEntryVersion(transformersRepositories[key]))
+	}
+
+	return executorsRepositories, sourcesRepositories, transformersRepositories, nil
 }
 
 func (s storeRepository) Insert(repo, name string, entry storeEntry) {
@@ -109,13 +109,27 @@ func TestNewStoreRepository(t *testing.T) {
 		},
 	}
 
+	expectedTransformers := storeRepository{
+		"mszostok/redact": {
+			{
+				Description: "Transformer suitable for e2e testing. It redacts configured fields.",
+				Version:     "v1.0.0",
+				URLs: map[string]URL{
+					"linux/amd64": {URL: "https://github.com/mszostok/botkube-plugins/releases/download/v1.0.0/transformer_redact_linux_amd64"},
+					"linux/arm64": {URL: "https://github.com/mszostok/botkube-plugins/releases/download/v1.0.0/transformer_redact_linux_arm64"},
+				},
+			},
+		},
+	}
+
 	// when
-	executors, sources, err := newStoreRepositories(repositories)
+	executors, sources, transformers, err := newStoreRepositories(repositories)
 
 	// then
 	require.NoError(t, err)
 	assert.Equal(t, expectedExecutors, executors)
 	assert.Equal(t, expectedSources, sources)
+	assert.Equal(t, expectedTransformers, transformers)
 }
 
 func loadTestdataFile(t *testing.T, name string) []byte {
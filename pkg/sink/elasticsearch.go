@@ -209,13 +209,32 @@ func (e *Elasticsearch) flushIndex(ctx context.Context, indexCfg config.ELSIndex
 
 // SendEvent sends an event to a configured elasticsearch server.
 func (e *Elasticsearch) SendEvent(ctx context.Context, rawData any, sources []string) error {
-	e.log.Debugf(">> Sending to Elasticsearch: %+v", rawData)
-
-	errs := multierror.New()
+	var indices []config.ELSIndex
 	for _, indexCfg := range e.indices {
 		if !sliceutil.Intersect(indexCfg.Bindings.Sources, sources) {
 			continue
 		}
+		indices = append(indices, indexCfg)
+	}
+
+	return e.sendToIndices(ctx, rawData, indices)
+}
+
+// SendRoutedEvent sends an event routed by a routing rule to all configured indices.
+func (e *Elasticsearch) SendRoutedEvent(ctx context.Context, rawData any, _ []string) error {
+	indices := make([]config.ELSIndex, 0, len(e.indices))
+	for _, indexCfg := range e.indices {
+		indices = append(indices, indexCfg)
+	}
+
+	return e.sendToIndices(ctx, rawData, indices)
+}
+
+func (e *Elasticsearch) sendToIndices(ctx context.Context, rawData any, indices []config.ELSIndex) error {
+	e.log.Debugf(">> Sending to Elasticsearch: %+v", rawData)
+
+	errs := multierror.New()
+	for _, indexCfg := range indices {
 		err := e.flushIndex(ctx, indexCfg, rawData)
 		if err != nil {
 			e.setFailureReason(health.FailureReasonConnectionError, fmt.Sprintf("while sending event to Elasticsearch index %q: %s", indexCfg.Name, err.Error()))
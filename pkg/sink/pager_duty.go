@@ -15,6 +15,7 @@ import (
 	"github.com/kubeshop/botkube/internal/config/remote"
 	"github.com/kubeshop/botkube/internal/health"
 	"github.com/kubeshop/botkube/pkg/config"
+	"github.com/kubeshop/botkube/pkg/notifier"
 	"github.com/kubeshop/botkube/pkg/sliceutil"
 )
 
@@ -89,6 +90,11 @@ func (w *PagerDuty) SendEvent(ctx context.Context, rawData any, sources []string
 		return nil
 	}
 
+	return w.SendRoutedEvent(ctx, rawData, sources)
+}
+
+// SendRoutedEvent sends an event routed by a routing rule. Source bindings are ignored.
+func (w *PagerDuty) SendRoutedEvent(ctx context.Context, rawData any, sources []string) error {
 	in := &incomingEvent{
 		Source:    strings.Join(sources, ","),
 		Data:      rawData,
@@ -136,8 +142,17 @@ func (w *PagerDuty) resolveEventMeta(in *incomingEvent) eventMetadata {
 		IsAlert: true,
 	}
 
+	data := in.Data
+	if plaintextEvent, ok := notifier.AsPlaintextEvent(in.Data); ok {
+		// metadata is resolved from the raw object, the rendered message is used only if nothing more specific is found
+		data = plaintextEvent.RawObject
+		if summary, _, _ := strings.Cut(strings.TrimSpace(plaintextEvent.Message), "\n"); summary != "" {
+			out.Summary = summary
+		}
+	}
+
 	var ev eventPayload
-	err := mapstructure.Decode(in.Data, &ev)
+	err := mapstructure.Decode(data, &ev)
 	if err != nil {
 		// we failed, so let's treat it as an error
 		w.log.WithError(err).Error("Failed to decode event. Forwarding it to PagerDuty as an alert.")
@@ -14,6 +14,7 @@ import (
 	"github.com/kubeshop/botkube/internal/config/remote"
 	"github.com/kubeshop/botkube/pkg/config"
 	"github.com/kubeshop/botkube/pkg/loggerx"
+	"github.com/kubeshop/botkube/pkg/notifier"
 )
 
 func TestPagerDuty_SendEvent(t *testing.T) {
@@ -26,7 +27,7 @@ func TestPagerDuty_SendEvent(t *testing.T) {
 		eventType  string
 		statusCode int
 		expPath    string
-		givenEvent map[string]any
+		givenEvent any
 	}{
 		{
 			name:       "alert event",
@@ -38,6 +39,21 @@ func TestPagerDuty_SendEvent(t *testing.T) {
 			givenEvent: fixK8sDeployUpdateAlert(),
 			expPath:    "/v2/change/enqueue",
 		},
+		{
+			name:       "change event from interactive source",
+			givenEvent: notifier.NewPlaintextEvent(fixK8sDeployUpdateAlert(), "Deployment updated\nDeployment 'default/nginx' has been updated."),
+			expPath:    "/v2/change/enqueue",
+		},
+		{
+			name:       "change event from interactive source restored from spillover",
+			givenEvent: fixJSONRoundTrip(t, notifier.NewPlaintextEvent(fixK8sDeployUpdateAlert(), "Deployment updated")),
+			expPath:    "/v2/change/enqueue",
+		},
+		{
+			name:       "interactive event without raw object",
+			givenEvent: notifier.NewPlaintextEvent(nil, "Argo CD app 'guestbook' has been synced."),
+			expPath:    "/v2/enqueue",
+		},
 	}
 
 	for _, tc := range tests {
@@ -107,3 +123,15 @@ func fixK8sDeployUpdateAlert() map[string]any {
 		"TimeStamp":   "2024-05-14T19:47:24.828568+09:00",
 	}
 }
+
+// fixJSONRoundTrip returns a given event in the same form as once it's restored from the delivery queue spillover.
+func fixJSONRoundTrip(t *testing.T, event any) any {
+	t.Helper()
+
+	raw, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var out any
+	require.NoError(t, json.Unmarshal(raw, &out))
+	return out
+}
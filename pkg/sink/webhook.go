@@ -74,6 +74,11 @@ func (w *Webhook) SendEvent(ctx context.Context, rawData any, sources []string)
 	return nil
 }
 
+// SendRoutedEvent sends an event routed by a routing rule. It is the same as SendEvent, as Webhook does not filter events by source bindings.
+func (w *Webhook) SendRoutedEvent(ctx context.Context, rawData any, sources []string) error {
+	return w.SendEvent(ctx, rawData, sources)
+}
+
 // PostWebhook posts webhook to listener
 func (w *Webhook) PostWebhook(ctx context.Context, jsonPayload *WebhookPayload) (err error) {
 	message, err := json.Marshal(jsonPayload)
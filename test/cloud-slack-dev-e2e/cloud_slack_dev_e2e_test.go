@@ -365,7 +365,7 @@ func TestCloudSlackE2E(t *testing.T) {
 			require.NoError(t, err)
 			tester.PostMessageToBot(t, channel.Identifier(), "list actions")
 			t.Log("Waiting for actions list...")
-			expectedActionsListMsg := fmt.Sprintf("%s\n```\nACTION       ENABLED  DISPLAY NAME\naction_xxx22 true     Action Name\n```", cmdHeader("list actions"))
+			expectedActionsListMsg := fmt.Sprintf("%s\n```\nACTION       ENABLED  DISPLAY NAME EXECUTIONS STATUS LAST_EXECUTION\naction_xxx22 true     Action Name  0          Active\n```", cmdHeader("list actions"))
 			err = tester.WaitForLastMessageEqual(tester.BotUserID(), channel.ID(), expectedActionsListMsg)
 			require.NoError(t, err)
 		})
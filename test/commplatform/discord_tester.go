@@ -443,3 +443,8 @@ func (d *DiscordTester) ReplaceBotNamePlaceholder(msg *interactive.CoreMessage,
 func (d *DiscordTester) OnChannel() BotDriver {
 	return d
 }
+
+// InChannelThreads is not supported for Discord, messages are posted on the channel.
+func (d *DiscordTester) InChannelThreads() BotDriver {
+	return d
+}
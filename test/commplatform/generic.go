@@ -54,6 +54,9 @@ type BotDriver interface {
 	// For example, in the context of source notification, we need to alter that default behavior
 	// and expect the message on the channel instead.
 	OnChannel() BotDriver
+	// InChannelThreads sets the expectation that the message should be posted in a thread of one of the recent channel messages.
+	// Bots which don't support threads expect the message on the channel instead.
+	InChannelThreads() BotDriver
 }
 
 type MessageAssertion func(content string) (bool, int, string)
@@ -76,6 +76,7 @@ type SlackTester struct {
 	recentlyPostedMsgTS        map[string]string
 	channelsByName             map[string]Channel
 	restoreRecentlyPostedMsgTS func()
+	inChannelThreads           bool
 }
 
 func (s *SlackTester) ReplaceBotNamePlaceholder(msg *interactive.CoreMessage, clusterName string) {
@@ -279,6 +280,9 @@ func (s *SlackTester) WaitForMessagePosted(userID, channelID string, limitMessag
 }
 
 func (s *SlackTester) getMessages(channelID string, limitMessages int) ([]slack.Message, error) {
+	if s.inChannelThreads {
+		return s.getThreadMessages(channelID, limitMessages)
+	}
 	if ts := s.recentlyPostedMsgTS[channelID]; ts != "" {
 		replies, _, _, err := s.cli.GetConversationReplies(&slack.GetConversationRepliesParameters{
 			ChannelID: channelID,
@@ -299,6 +303,35 @@ func (s *SlackTester) getMessages(channelID string, limitMessages int) ([]slack.
 	return nil, err
 }
 
+// getThreadMessages returns replies posted in threads of the recent channel messages.
+func (s *SlackTester) getThreadMessages(channelID string, limitMessages int) ([]slack.Message, error) {
+	history, err := s.cli.GetConversationHistory(&slack.GetConversationHistoryParameters{
+		ChannelID: channelID, Limit: limitMessages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []slack.Message
+	for _, msg := range history.Messages {
+		if msg.ReplyCount == 0 {
+			continue
+		}
+		replies, _, _, err := s.cli.GetConversationReplies(&slack.GetConversationRepliesParameters{
+			ChannelID: channelID,
+			Timestamp: msg.Timestamp,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(replies) > 0 {
+			// the first message is the thread parent
+			out = append(out, replies[1:]...)
+		}
+	}
+	return out, nil
+}
+
 func (s *SlackTester) WaitForInteractiveMessagePosted(userID, channelID string, limitMessages int, assertFn MessageAssertion) error {
 	defer s.restoreMsgTsIfNeeded()
 	var fetchedMessages []slack.Message
@@ -588,6 +621,20 @@ func (s *SlackTester) OnChannel() BotDriver {
 	return s
 }
 
+// InChannelThreads sets the expectation that messages should be posted in threads of the recent channel messages,
+// e.g. automated action results are posted in the threads of event messages.
+// After first assertion it restores expectation.
+func (s *SlackTester) InChannelThreads() BotDriver {
+	s.OnChannel()
+	restore := s.restoreRecentlyPostedMsgTS
+	s.inChannelThreads = true
+	s.restoreRecentlyPostedMsgTS = func() {
+		restore()
+		s.inChannelThreads = false
+	}
+	return s
+}
+
 func (s *SlackTester) restoreMsgTsIfNeeded() {
 	if s.restoreRecentlyPostedMsgTS != nil {
 		s.restoreRecentlyPostedMsgTS()
@@ -1215,7 +1215,7 @@ func runBotTest(t *testing.T,
 					strings.Count(msg, pod.Name) == podNameCount,
 				0, ""
 		}
-		err = botDriver.OnChannel().WaitForMessagePosted(botDriver.BotUserID(), botDriver.FirstChannel().ID(), 2, automationAssertionFn)
+		err = botDriver.InChannelThreads().WaitForMessagePosted(botDriver.BotUserID(), botDriver.FirstChannel().ID(), 3, automationAssertionFn)
 		require.NoError(t, err)
 
 		t.Log("Creating Service...")
@@ -1245,7 +1245,7 @@ func runBotTest(t *testing.T,
 		t.Log("Ensuring bot didn't post anything new on first channel...")
 		time.Sleep(appCfg.Slack.MessageWaitTimeout)
 		// same expected message as before
-		err = botDriver.OnChannel().WaitForMessagePosted(botDriver.BotUserID(), botDriver.FirstChannel().ID(), 2, automationAssertionFn)
+		err = botDriver.InChannelThreads().WaitForMessagePosted(botDriver.BotUserID(), botDriver.FirstChannel().ID(), 3, automationAssertionFn)
 		require.NoError(t, err)
 
 		t.Log("Ensuring bot automation was executed and label created Service...")
@@ -1262,16 +1262,26 @@ func runBotTest(t *testing.T,
 
 	t.Run("List actions", func(t *testing.T) {
 		command := "list actions"
-		expectedBody := codeBlock(heredoc.Doc(`
-			ACTION                     ENABLED  DISPLAY NAME
-			describe-created-resource  false    Describe created resource
-			get-created-resource       true     Get created resource
-			label-created-svc-resource true     Label created Service
-			show-logs-on-error         false    Show logs on error`))
+		// execution counts depend on previous test cases, so only stable columns are checked
+		expectedRows := []string{
+			"describe-created-resource  false    Describe created resource",
+			"get-created-resource       true     Get created resource",
+			"label-created-svc-resource true     Label created Service",
+			"show-logs-on-error         false    Show logs on error",
+		}
 
-		expectedMessage := fmt.Sprintf("%s\n%s", cmdHeader(command), expectedBody)
 		botDriver.PostMessageToBot(t, botDriver.FirstChannel().Identifier(), command)
-		err := botDriver.WaitForLastMessageContains(botDriver.BotUserID(), botDriver.FirstChannel().ID(), expectedMessage)
+		err := botDriver.WaitForMessagePosted(botDriver.BotUserID(), botDriver.FirstChannel().ID(), 1, func(msg string) (bool, int, string) {
+			if !strings.Contains(msg, cmdHeader(command)) || !hasAllColumns(msg, "ACTION", "ENABLED", "DISPLAY NAME", "EXECUTIONS", "STATUS", "LAST_EXECUTION") {
+				return false, 0, ""
+			}
+			for _, row := range expectedRows {
+				if !strings.Contains(msg, row) {
+					return false, 0, ""
+				}
+			}
+			return true, 0, ""
+		})
 		assert.NoError(t, err)
 	})
 